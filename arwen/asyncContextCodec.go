@@ -0,0 +1,240 @@
+package arwen
+
+import (
+	"encoding/binary"
+	"encoding/json"
+)
+
+// AsyncContextInfoVersion is the version of the binary format in which
+// AsyncContextInfo is saved to storage. Being the first byte of the encoded
+// data, it also distinguishes the binary format from the legacy JSON one,
+// which always starts with '{'.
+const AsyncContextInfoVersion = byte(2)
+
+// asyncContextInfoVersionNoDeadline is the first version of the binary
+// format, in which async contexts had no deadline
+const asyncContextInfoVersionNoDeadline = byte(1)
+
+const legacyJSONMarker = byte('{')
+
+const uint32Len = 4
+const uint64Len = 8
+
+// SerializeAsyncContextInfo encodes the AsyncContextInfo in the current binary
+// format. The async contexts are encoded in the order given by
+// GetContextIdentifiers() and the async calls in their original order, so that
+// the same AsyncContextInfo always results in the same bytes.
+func SerializeAsyncContextInfo(asyncInfo *AsyncContextInfo) []byte {
+	contextIdentifiers := asyncInfo.GetContextIdentifiers()
+
+	data := []byte{AsyncContextInfoVersion}
+	data = appendBytes(data, asyncInfo.CallerAddr)
+	data = appendBytes(data, asyncInfo.ReturnData)
+	data = appendUint32(data, uint32(len(contextIdentifiers)))
+	for _, contextIdentifier := range contextIdentifiers {
+		asyncContext := asyncInfo.AsyncContextMap[contextIdentifier]
+		data = appendBytes(data, []byte(contextIdentifier))
+		data = appendBytes(data, []byte(asyncContext.Callback))
+		data = appendUint64(data, asyncContext.Deadline)
+		data = appendUint32(data, uint32(len(asyncContext.AsyncCalls)))
+		for _, asyncCall := range asyncContext.AsyncCalls {
+			data = appendAsyncGeneratedCall(data, asyncCall)
+		}
+	}
+
+	return data
+}
+
+// DeserializeAsyncContextInfo decodes an AsyncContextInfo saved to storage,
+// either in the current binary format or in the legacy JSON one.
+func DeserializeAsyncContextInfo(data []byte) (*AsyncContextInfo, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidAsyncContextInfo
+	}
+
+	switch data[0] {
+	case asyncContextInfoVersionNoDeadline:
+		return deserializeAsyncContextInfoBinary(data[1:], false)
+	case AsyncContextInfoVersion:
+		return deserializeAsyncContextInfoBinary(data[1:], true)
+	case legacyJSONMarker:
+		return deserializeLegacyAsyncContextInfo(data)
+	default:
+		return nil, ErrUnknownAsyncContextInfoVersion
+	}
+}
+
+// SerializeAsyncGeneratedCall encodes a single AsyncGeneratedCall in the
+// format used for the async calls within a serialized AsyncContextInfo.
+func SerializeAsyncGeneratedCall(asyncCall *AsyncGeneratedCall) []byte {
+	return appendAsyncGeneratedCall(nil, asyncCall)
+}
+
+// DeserializeAsyncGeneratedCall decodes an AsyncGeneratedCall encoded by
+// SerializeAsyncGeneratedCall.
+func DeserializeAsyncGeneratedCall(data []byte) (*AsyncGeneratedCall, error) {
+	reader := &asyncCodecReader{data: data}
+	asyncCall := reader.readAsyncGeneratedCall()
+	if reader.err != nil {
+		return nil, reader.err
+	}
+	if len(reader.data) != 0 {
+		return nil, ErrInvalidAsyncContextInfo
+	}
+
+	return asyncCall, nil
+}
+
+func deserializeAsyncContextInfoBinary(data []byte, hasDeadline bool) (*AsyncContextInfo, error) {
+	reader := &asyncCodecReader{data: data}
+
+	asyncInfo := &AsyncContextInfo{
+		CallerAddr:      reader.readBytes(),
+		ReturnData:      reader.readBytes(),
+		AsyncContextMap: make(map[string]*AsyncContext),
+	}
+
+	numContexts := reader.readUint32()
+	for i := uint32(0); i < numContexts && reader.err == nil; i++ {
+		contextIdentifier := string(reader.readBytes())
+		asyncContext := &AsyncContext{
+			Callback: string(reader.readBytes()),
+		}
+		if hasDeadline {
+			asyncContext.Deadline = reader.readUint64()
+		}
+
+		numCalls := reader.readUint32()
+		for j := uint32(0); j < numCalls && reader.err == nil; j++ {
+			asyncContext.AsyncCalls = append(asyncContext.AsyncCalls, reader.readAsyncGeneratedCall())
+		}
+
+		asyncInfo.AddAsyncContext(contextIdentifier, asyncContext)
+	}
+
+	if reader.err != nil {
+		return nil, reader.err
+	}
+	if len(reader.data) != 0 {
+		return nil, ErrInvalidAsyncContextInfo
+	}
+
+	return asyncInfo, nil
+}
+
+func deserializeLegacyAsyncContextInfo(data []byte) (*AsyncContextInfo, error) {
+	asyncInfo := &AsyncContextInfo{}
+	err := json.Unmarshal(data, asyncInfo)
+	if err != nil {
+		return nil, ErrInvalidAsyncContextInfo
+	}
+
+	if asyncInfo.AsyncContextMap == nil {
+		asyncInfo.AsyncContextMap = make(map[string]*AsyncContext)
+	}
+
+	return asyncInfo, nil
+}
+
+func appendAsyncGeneratedCall(data []byte, asyncCall *AsyncGeneratedCall) []byte {
+	data = append(data, byte(asyncCall.Status))
+	data = appendBytes(data, asyncCall.Destination)
+	data = appendBytes(data, asyncCall.Data)
+	data = appendUint64(data, asyncCall.GasLimit)
+	data = appendBytes(data, asyncCall.ValueBytes)
+	data = appendBytes(data, []byte(asyncCall.SuccessCallback))
+	data = appendBytes(data, []byte(asyncCall.ErrorCallback))
+	data = appendUint64(data, asyncCall.ProvidedGas)
+	return data
+}
+
+func appendUint32(data []byte, value uint32) []byte {
+	encoded := make([]byte, uint32Len)
+	binary.BigEndian.PutUint32(encoded, value)
+	return append(data, encoded...)
+}
+
+func appendUint64(data []byte, value uint64) []byte {
+	encoded := make([]byte, uint64Len)
+	binary.BigEndian.PutUint64(encoded, value)
+	return append(data, encoded...)
+}
+
+func appendBytes(data []byte, value []byte) []byte {
+	data = appendUint32(data, uint32(len(value)))
+	return append(data, value...)
+}
+
+// asyncCodecReader consumes the encoded data field by field; after the first
+// error, all the following reads return zero values and the error is kept.
+type asyncCodecReader struct {
+	data []byte
+	err  error
+}
+
+func (reader *asyncCodecReader) next(length uint64) []byte {
+	if reader.err != nil {
+		return nil
+	}
+	if uint64(len(reader.data)) < length {
+		reader.err = ErrInvalidAsyncContextInfo
+		return nil
+	}
+
+	field := reader.data[:length]
+	reader.data = reader.data[length:]
+	return field
+}
+
+func (reader *asyncCodecReader) readByte() byte {
+	field := reader.next(1)
+	if field == nil {
+		return 0
+	}
+	return field[0]
+}
+
+func (reader *asyncCodecReader) readUint32() uint32 {
+	field := reader.next(uint32Len)
+	if field == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(field)
+}
+
+func (reader *asyncCodecReader) readUint64() uint64 {
+	field := reader.next(uint64Len)
+	if field == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(field)
+}
+
+func (reader *asyncCodecReader) readBytes() []byte {
+	length := reader.readUint32()
+	if length == 0 {
+		return nil
+	}
+
+	field := reader.next(uint64(length))
+	if field == nil {
+		return nil
+	}
+
+	value := make([]byte, length)
+	copy(value, field)
+	return value
+}
+
+func (reader *asyncCodecReader) readAsyncGeneratedCall() *AsyncGeneratedCall {
+	return &AsyncGeneratedCall{
+		Status:          AsyncCallStatus(reader.readByte()),
+		Destination:     reader.readBytes(),
+		Data:            reader.readBytes(),
+		GasLimit:        reader.readUint64(),
+		ValueBytes:      reader.readBytes(),
+		SuccessCallback: string(reader.readBytes()),
+		ErrorCallback:   string(reader.readBytes()),
+		ProvidedGas:     reader.readUint64(),
+	}
+}
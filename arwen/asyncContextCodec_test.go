@@ -0,0 +1,176 @@
+package arwen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestAsyncContextInfo() *AsyncContextInfo {
+	asyncInfo := &AsyncContextInfo{
+		CallerAddr: []byte("caller"),
+		ReturnData: []byte("return data"),
+	}
+
+	asyncInfo.AddAsyncContext("second", &AsyncContext{
+		AsyncCalls: []*AsyncGeneratedCall{
+			{
+				Status:      AsyncCallResolved,
+				Destination: []byte("destination3"),
+				GasLimit:    3000,
+			},
+		},
+	})
+	asyncInfo.AddAsyncContext("first", &AsyncContext{
+		Callback: "firstCallback",
+		AsyncCalls: []*AsyncGeneratedCall{
+			{
+				Status:          AsyncCallPending,
+				Destination:     []byte("destination1"),
+				Data:            []byte("function@01"),
+				GasLimit:        1000,
+				ValueBytes:      []byte{10},
+				SuccessCallback: "success",
+				ErrorCallback:   "error",
+				ProvidedGas:     2000,
+			},
+			{
+				Status:      AsyncCallRejected,
+				Destination: []byte("destination2"),
+				Data:        []byte("function"),
+			},
+		},
+	})
+	asyncInfo.AddAsyncContext("third", &AsyncContext{
+		Callback: "thirdCallback",
+		Deadline: 1234,
+	})
+
+	return asyncInfo
+}
+
+func TestAsyncContextCodec_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	asyncInfo := makeTestAsyncContextInfo()
+	data := SerializeAsyncContextInfo(asyncInfo)
+	require.Equal(t, AsyncContextInfoVersion, data[0])
+
+	decoded, err := DeserializeAsyncContextInfo(data)
+	require.Nil(t, err)
+	require.Equal(t, asyncInfo, decoded)
+	require.Equal(t, []string{"second", "first", "third"}, decoded.GetContextIdentifiers())
+}
+
+func TestAsyncContextCodec_VersionWithoutDeadline(t *testing.T) {
+	t.Parallel()
+
+	data := []byte{1}
+	data = appendBytes(data, []byte("caller"))
+	data = appendBytes(data, nil)
+	data = appendUint32(data, 1)
+	data = appendBytes(data, []byte("context"))
+	data = appendBytes(data, []byte("contextCallback"))
+	data = appendUint32(data, 1)
+	data = appendAsyncGeneratedCall(data, &AsyncGeneratedCall{Destination: []byte("destination")})
+
+	decoded, err := DeserializeAsyncContextInfo(data)
+	require.Nil(t, err)
+
+	expected := &AsyncContextInfo{CallerAddr: []byte("caller")}
+	expected.AddAsyncContext("context", &AsyncContext{
+		Callback:   "contextCallback",
+		AsyncCalls: []*AsyncGeneratedCall{{Destination: []byte("destination")}},
+	})
+	require.Equal(t, expected, decoded)
+}
+
+func TestAsyncContextCodec_RoundTrip_Empty(t *testing.T) {
+	t.Parallel()
+
+	asyncInfo := &AsyncContextInfo{
+		AsyncContextMap: make(map[string]*AsyncContext),
+	}
+
+	decoded, err := DeserializeAsyncContextInfo(SerializeAsyncContextInfo(asyncInfo))
+	require.Nil(t, err)
+	require.Equal(t, asyncInfo, decoded)
+}
+
+func TestAsyncContextCodec_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	expected := SerializeAsyncContextInfo(makeTestAsyncContextInfo())
+	for i := 0; i < 100; i++ {
+		require.Equal(t, expected, SerializeAsyncContextInfo(makeTestAsyncContextInfo()))
+	}
+}
+
+func TestAsyncContextCodec_LegacyJSON(t *testing.T) {
+	t.Parallel()
+
+	asyncInfo := makeTestAsyncContextInfo()
+	data, err := json.Marshal(asyncInfo)
+	require.Nil(t, err)
+
+	decoded, err := DeserializeAsyncContextInfo(data)
+	require.Nil(t, err)
+	require.Equal(t, asyncInfo, decoded)
+
+	legacyAsyncInfo := makeTestAsyncContextInfo()
+	legacyAsyncInfo.AsyncContextOrder = nil
+	data, err = json.Marshal(legacyAsyncInfo)
+	require.Nil(t, err)
+
+	decoded, err = DeserializeAsyncContextInfo(data)
+	require.Nil(t, err)
+	require.Equal(t, []string{"first", "second", "third"}, decoded.GetContextIdentifiers())
+
+	decoded, err = DeserializeAsyncContextInfo([]byte(`{"CallerAddr":null}`))
+	require.Nil(t, err)
+	require.NotNil(t, decoded.AsyncContextMap)
+	require.Len(t, decoded.AsyncContextMap, 0)
+}
+
+func TestAsyncContextCodec_Invalid(t *testing.T) {
+	t.Parallel()
+
+	decoded, err := DeserializeAsyncContextInfo(nil)
+	require.Equal(t, ErrInvalidAsyncContextInfo, err)
+	require.Nil(t, decoded)
+
+	decoded, err = DeserializeAsyncContextInfo([]byte{3, 0, 0})
+	require.Equal(t, ErrUnknownAsyncContextInfoVersion, err)
+	require.Nil(t, decoded)
+
+	decoded, err = DeserializeAsyncContextInfo([]byte("{invalid"))
+	require.Equal(t, ErrInvalidAsyncContextInfo, err)
+	require.Nil(t, decoded)
+
+	data := SerializeAsyncContextInfo(makeTestAsyncContextInfo())
+	for length := 1; length < len(data); length++ {
+		decoded, err = DeserializeAsyncContextInfo(data[:length])
+		require.Equal(t, ErrInvalidAsyncContextInfo, err)
+		require.Nil(t, decoded)
+	}
+
+	decoded, err = DeserializeAsyncContextInfo(append(data, 0))
+	require.Equal(t, ErrInvalidAsyncContextInfo, err)
+	require.Nil(t, decoded)
+}
+
+func TestAsyncGeneratedCallCodec_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	asyncCall := makeTestAsyncContextInfo().AsyncContextMap["first"].AsyncCalls[0]
+	data := SerializeAsyncGeneratedCall(asyncCall)
+
+	decoded, err := DeserializeAsyncGeneratedCall(data)
+	require.Nil(t, err)
+	require.Equal(t, asyncCall, decoded)
+
+	decoded, err = DeserializeAsyncGeneratedCall(data[:len(data)-1])
+	require.Equal(t, ErrInvalidAsyncContextInfo, err)
+	require.Nil(t, decoded)
+}
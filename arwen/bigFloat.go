@@ -0,0 +1,191 @@
+package arwen
+
+import (
+	"math/big"
+)
+
+// MaxBigFloatDecimals is the largest number of decimals a BigFloat may have
+const MaxBigFloatDecimals = 64
+
+// BigFloat is a fixed-point decimal number, equal to mantissa / 10^decimals.
+// Unlike big.Float, all its operations are exact, or round at an explicit
+// number of decimals, so results are the same on every platform.
+type BigFloat struct {
+	mantissa *big.Int
+	decimals int32
+}
+
+// NewBigFloat creates a BigFloat equal to mantissa / 10^decimals
+func NewBigFloat(mantissa int64, decimals int32) (*BigFloat, error) {
+	err := checkBigFloatDecimals(decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BigFloat{
+		mantissa: big.NewInt(mantissa),
+		decimals: decimals,
+	}, nil
+}
+
+// Mantissa returns a copy of the mantissa of x
+func (x *BigFloat) Mantissa() *big.Int {
+	return big.NewInt(0).Set(x.mantissa)
+}
+
+// Decimals returns the number of decimals of x
+func (x *BigFloat) Decimals() int32 {
+	return x.decimals
+}
+
+// Rat returns x as an exact rational number
+func (x *BigFloat) Rat() *big.Rat {
+	return big.NewRat(1, 1).SetFrac(x.mantissa, powerOfTen(x.decimals))
+}
+
+// Cmp compares x and y, returning -1, 0 or +1
+func (x *BigFloat) Cmp(y *BigFloat) int {
+	decimals := maxDecimals(x.decimals, y.decimals)
+	return rescale(x.mantissa, x.decimals, decimals, false).Cmp(rescale(y.mantissa, y.decimals, decimals, false))
+}
+
+// Set sets z to x and returns z
+func (z *BigFloat) Set(x *BigFloat) *BigFloat {
+	z.mantissa = big.NewInt(0).Set(x.mantissa)
+	z.decimals = x.decimals
+	return z
+}
+
+// Add sets z to the exact sum x+y and returns z
+func (z *BigFloat) Add(x, y *BigFloat) *BigFloat {
+	decimals := maxDecimals(x.decimals, y.decimals)
+	mantissa := rescale(x.mantissa, x.decimals, decimals, false)
+	mantissa.Add(mantissa, rescale(y.mantissa, y.decimals, decimals, false))
+	return z.setParts(mantissa, decimals)
+}
+
+// Sub sets z to the exact difference x-y and returns z
+func (z *BigFloat) Sub(x, y *BigFloat) *BigFloat {
+	decimals := maxDecimals(x.decimals, y.decimals)
+	mantissa := rescale(x.mantissa, x.decimals, decimals, false)
+	mantissa.Sub(mantissa, rescale(y.mantissa, y.decimals, decimals, false))
+	return z.setParts(mantissa, decimals)
+}
+
+// Mul sets z to the exact product x*y and returns z; it fails if the product
+// would have more than MaxBigFloatDecimals decimals
+func (z *BigFloat) Mul(x, y *BigFloat) (*BigFloat, error) {
+	decimals := x.decimals + y.decimals
+	err := checkBigFloatDecimals(decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	mantissa := big.NewInt(0).Mul(x.mantissa, y.mantissa)
+	return z.setParts(mantissa, decimals), nil
+}
+
+// Quo sets z to the quotient x/y truncated towards zero at the given number
+// of decimals and returns z
+func (z *BigFloat) Quo(x, y *BigFloat, decimals int32) (*BigFloat, error) {
+	err := checkBigFloatDecimals(decimals)
+	if err != nil {
+		return nil, err
+	}
+	if y.mantissa.Sign() == 0 {
+		return nil, ErrDivZero
+	}
+
+	// x/y = (xm * 10^(yd+decimals)) / (ym * 10^xd), at the given decimals
+	numerator := big.NewInt(0).Mul(x.mantissa, powerOfTen(y.decimals+decimals))
+	denominator := big.NewInt(0).Mul(y.mantissa, powerOfTen(x.decimals))
+	mantissa := numerator.Quo(numerator, denominator)
+	return z.setParts(mantissa, decimals), nil
+}
+
+// Round sets z to x rounded half away from zero at the given number of
+// decimals and returns z
+func (z *BigFloat) Round(x *BigFloat, decimals int32) (*BigFloat, error) {
+	err := checkBigFloatDecimals(decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return z.setParts(rescale(x.mantissa, x.decimals, decimals, true), decimals), nil
+}
+
+// Truncate sets z to x truncated towards zero at the given number of decimals
+// and returns z
+func (z *BigFloat) Truncate(x *BigFloat, decimals int32) (*BigFloat, error) {
+	err := checkBigFloatDecimals(decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return z.setParts(rescale(x.mantissa, x.decimals, decimals, false), decimals), nil
+}
+
+// SetBigInt sets z to value / 10^decimals, i.e. interprets value as a
+// fixed-point number with the given number of decimals, and returns z
+func (z *BigFloat) SetBigInt(value *big.Int, decimals int32) (*BigFloat, error) {
+	err := checkBigFloatDecimals(decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return z.setParts(big.NewInt(0).Set(value), decimals), nil
+}
+
+// BigInt returns x * 10^decimals truncated towards zero, i.e. the fixed-point
+// representation of x with the given number of decimals
+func (x *BigFloat) BigInt(decimals int32) (*big.Int, error) {
+	err := checkBigFloatDecimals(decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return rescale(x.mantissa, x.decimals, decimals, false), nil
+}
+
+func (z *BigFloat) setParts(mantissa *big.Int, decimals int32) *BigFloat {
+	z.mantissa = mantissa
+	z.decimals = decimals
+	return z
+}
+
+func checkBigFloatDecimals(decimals int32) error {
+	if decimals < 0 || decimals > MaxBigFloatDecimals {
+		return ErrBigFloatDecimalsOutOfRange
+	}
+	return nil
+}
+
+func maxDecimals(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func powerOfTen(exponent int32) *big.Int {
+	return big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+// rescale returns a new mantissa representing mantissa / 10^from with to
+// decimals, either rounded half away from zero or truncated towards zero
+func rescale(mantissa *big.Int, from int32, to int32, round bool) *big.Int {
+	if to >= from {
+		return big.NewInt(0).Mul(mantissa, powerOfTen(to-from))
+	}
+
+	divisor := powerOfTen(from - to)
+	quotient, remainder := big.NewInt(0).QuoRem(mantissa, divisor, big.NewInt(0))
+	if round && remainder.Sign() != 0 {
+		doubleRemainder := remainder.Abs(remainder)
+		doubleRemainder.Lsh(doubleRemainder, 1)
+		if doubleRemainder.Cmp(divisor) >= 0 {
+			quotient.Add(quotient, big.NewInt(int64(mantissa.Sign())))
+		}
+	}
+	return quotient
+}
@@ -0,0 +1,217 @@
+package arwen
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBigFloat(t *testing.T, mantissa string, decimals int32) *BigFloat {
+	value, ok := big.NewInt(0).SetString(mantissa, 10)
+	require.True(t, ok)
+	bigFloat, err := (&BigFloat{}).SetBigInt(value, decimals)
+	require.Nil(t, err)
+	return bigFloat
+}
+
+func testBigFloats(t *testing.T) []*BigFloat {
+	return []*BigFloat{
+		newTestBigFloat(t, "0", 0),
+		newTestBigFloat(t, "0", 18),
+		newTestBigFloat(t, "1", 0),
+		newTestBigFloat(t, "-1", 0),
+		newTestBigFloat(t, "15", 1),
+		newTestBigFloat(t, "-25", 1),
+		newTestBigFloat(t, "1000000000000000000", 18),
+		newTestBigFloat(t, "333333333333333333", 18),
+		newTestBigFloat(t, "-123456789012345678901234567890", 18),
+		newTestBigFloat(t, "18446744073709551616", 5),
+	}
+}
+
+// ratTruncate returns r * 10^decimals truncated towards zero
+func ratTruncate(r *big.Rat, decimals int32) *big.Int {
+	scaled := big.NewRat(1, 1).Mul(r, big.NewRat(1, 1).SetInt(powerOfTen(decimals)))
+	return big.NewInt(0).Quo(scaled.Num(), scaled.Denom())
+}
+
+// ratRound returns r * 10^decimals rounded half away from zero
+func ratRound(r *big.Rat, decimals int32) *big.Int {
+	scaled := big.NewRat(1, 1).Mul(r, big.NewRat(1, 1).SetInt(powerOfTen(decimals)))
+	half := big.NewRat(1, 2)
+	if scaled.Sign() < 0 {
+		scaled.Sub(scaled, half)
+	} else {
+		scaled.Add(scaled, half)
+	}
+	return big.NewInt(0).Quo(scaled.Num(), scaled.Denom())
+}
+
+func requireBigFloatEqualsRat(t *testing.T, expected *big.Rat, actual *BigFloat) {
+	require.Equal(t, 0, expected.Cmp(actual.Rat()), "expected %s, got %s", expected.RatString(), actual.Rat().RatString())
+}
+
+func requireBigIntEqual(t *testing.T, expected *big.Int, actual *big.Int) {
+	require.Equal(t, 0, expected.Cmp(actual), "expected %s, got %s", expected, actual)
+}
+
+func TestBigFloat_New(t *testing.T) {
+	value, err := NewBigFloat(15, 1)
+	require.Nil(t, err)
+	requireBigIntEqual(t, big.NewInt(15), value.Mantissa())
+	require.Equal(t, int32(1), value.Decimals())
+	requireBigFloatEqualsRat(t, big.NewRat(3, 2), value)
+
+	_, err = NewBigFloat(1, -1)
+	require.Equal(t, ErrBigFloatDecimalsOutOfRange, err)
+
+	_, err = NewBigFloat(1, MaxBigFloatDecimals+1)
+	require.Equal(t, ErrBigFloatDecimalsOutOfRange, err)
+}
+
+func TestBigFloat_CrossCheckArithmetic(t *testing.T) {
+	values := testBigFloats(t)
+	for _, x := range values {
+		for _, y := range values {
+			sum := (&BigFloat{}).Add(x, y)
+			requireBigFloatEqualsRat(t, big.NewRat(1, 1).Add(x.Rat(), y.Rat()), sum)
+
+			difference := (&BigFloat{}).Sub(x, y)
+			requireBigFloatEqualsRat(t, big.NewRat(1, 1).Sub(x.Rat(), y.Rat()), difference)
+
+			product, err := (&BigFloat{}).Mul(x, y)
+			require.Nil(t, err)
+			requireBigFloatEqualsRat(t, big.NewRat(1, 1).Mul(x.Rat(), y.Rat()), product)
+
+			require.Equal(t, x.Rat().Cmp(y.Rat()), x.Cmp(y))
+
+			for _, decimals := range []int32{0, 2, 18} {
+				quotient, err := (&BigFloat{}).Quo(x, y, decimals)
+				if y.Rat().Sign() == 0 {
+					require.Equal(t, ErrDivZero, err)
+					continue
+				}
+				require.Nil(t, err)
+				require.Equal(t, decimals, quotient.Decimals())
+				expected := ratTruncate(big.NewRat(1, 1).Quo(x.Rat(), y.Rat()), decimals)
+				requireBigIntEqual(t, expected, quotient.Mantissa())
+			}
+		}
+	}
+}
+
+func TestBigFloat_CrossCheckRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	randomBigFloat := func() *BigFloat {
+		mantissa := big.NewInt(0).Rand(random, powerOfTen(30))
+		if random.Intn(2) == 0 {
+			mantissa.Neg(mantissa)
+		}
+		value, _ := (&BigFloat{}).SetBigInt(mantissa, int32(random.Intn(25)))
+		return value
+	}
+
+	for i := 0; i < 1000; i++ {
+		x, y := randomBigFloat(), randomBigFloat()
+		decimals := int32(random.Intn(25))
+
+		product, err := (&BigFloat{}).Mul(x, y)
+		require.Nil(t, err)
+		requireBigFloatEqualsRat(t, big.NewRat(1, 1).Mul(x.Rat(), y.Rat()), product)
+
+		if y.Rat().Sign() != 0 {
+			quotient, err := (&BigFloat{}).Quo(x, y, decimals)
+			require.Nil(t, err)
+			requireBigIntEqual(t, ratTruncate(big.NewRat(1, 1).Quo(x.Rat(), y.Rat()), decimals), quotient.Mantissa())
+		}
+
+		rounded, err := (&BigFloat{}).Round(x, decimals)
+		require.Nil(t, err)
+		requireBigIntEqual(t, ratRound(x.Rat(), decimals), rounded.Mantissa())
+
+		truncated, err := (&BigFloat{}).Truncate(x, decimals)
+		require.Nil(t, err)
+		requireBigIntEqual(t, ratTruncate(x.Rat(), decimals), truncated.Mantissa())
+	}
+}
+
+func TestBigFloat_RoundTruncate(t *testing.T) {
+	testCases := []struct {
+		mantissa  string
+		decimals  int32
+		precision int32
+		rounded   string
+		truncated string
+	}{
+		{"15", 1, 0, "2", "1"},
+		{"-15", 1, 0, "-2", "-1"},
+		{"14", 1, 0, "1", "1"},
+		{"-14", 1, 0, "-1", "-1"},
+		{"1999999999999999999", 18, 2, "200", "199"},
+		{"5", 0, 2, "500", "500"},
+	}
+
+	for _, testCase := range testCases {
+		x := newTestBigFloat(t, testCase.mantissa, testCase.decimals)
+
+		rounded, err := (&BigFloat{}).Round(x, testCase.precision)
+		require.Nil(t, err)
+		require.Equal(t, testCase.precision, rounded.Decimals())
+		require.Equal(t, 0, newTestBigFloat(t, testCase.rounded, testCase.precision).Cmp(rounded))
+
+		truncated, err := (&BigFloat{}).Truncate(x, testCase.precision)
+		require.Nil(t, err)
+		require.Equal(t, testCase.precision, truncated.Decimals())
+		require.Equal(t, 0, newTestBigFloat(t, testCase.truncated, testCase.precision).Cmp(truncated))
+	}
+}
+
+func TestBigFloat_Aliasing(t *testing.T) {
+	x := newTestBigFloat(t, "15", 1)
+	y := newTestBigFloat(t, "25", 1)
+
+	x.Add(x, y)
+	requireBigFloatEqualsRat(t, big.NewRat(4, 1), x)
+
+	_, err := x.Mul(x, x)
+	require.Nil(t, err)
+	requireBigFloatEqualsRat(t, big.NewRat(16, 1), x)
+
+	_, err = x.Quo(x, y, 3)
+	require.Nil(t, err)
+	requireBigFloatEqualsRat(t, big.NewRat(64, 10), x)
+	requireBigFloatEqualsRat(t, big.NewRat(5, 2), y)
+}
+
+func TestBigFloat_BigIntConversion(t *testing.T) {
+	tokenAmount, _ := big.NewInt(0).SetString("1500000000000000000", 10)
+	x, err := (&BigFloat{}).SetBigInt(tokenAmount, 18)
+	require.Nil(t, err)
+	requireBigFloatEqualsRat(t, big.NewRat(3, 2), x)
+
+	integral, err := x.BigInt(0)
+	require.Nil(t, err)
+	requireBigIntEqual(t, big.NewInt(1), integral)
+
+	scaled, err := x.BigInt(20)
+	require.Nil(t, err)
+	requireBigIntEqual(t, big.NewInt(0).Mul(tokenAmount, big.NewInt(100)), scaled)
+
+	_, err = x.BigInt(MaxBigFloatDecimals + 1)
+	require.Equal(t, ErrBigFloatDecimalsOutOfRange, err)
+
+	_, err = (&BigFloat{}).SetBigInt(tokenAmount, -1)
+	require.Equal(t, ErrBigFloatDecimalsOutOfRange, err)
+}
+
+func TestBigFloat_MulDecimalsOutOfRange(t *testing.T) {
+	x := newTestBigFloat(t, "1", 40)
+
+	_, err := (&BigFloat{}).Mul(x, x)
+	require.Equal(t, ErrBigFloatDecimalsOutOfRange, err)
+
+	_, err = (&BigFloat{}).Quo(x, x, MaxBigFloatDecimals+1)
+	require.Equal(t, ErrBigFloatDecimalsOutOfRange, err)
+}
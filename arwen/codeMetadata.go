@@ -0,0 +1,71 @@
+package arwen
+
+const (
+	// MetadataUpgradeable is the bit for the upgradeable flag, in the first byte
+	MetadataUpgradeable = 1
+	// MetadataNotReadable is the bit for the not readable flag, in the first
+	// byte, by which the owner forbids other contracts to read protected keys
+	MetadataNotReadable = 4
+	// MetadataPayable is the bit for the payable flag, in the second byte
+	MetadataPayable = 2
+	// MetadataNonReentrant is the bit for the non-reentrant flag, in the second byte
+	MetadataNonReentrant = 4
+)
+
+// CodeMetadata represents the flags a smart contract is deployed with
+type CodeMetadata struct {
+	Upgradeable  bool
+	NotReadable  bool
+	Payable      bool
+	NonReentrant bool
+}
+
+// CodeMetadataFromBytes parses and validates the serialized code metadata.
+// Empty metadata belongs to contracts deployed before code metadata was
+// introduced; these contracts are not upgradeable, but they accept call value.
+func CodeMetadataFromBytes(bytes []byte) (*CodeMetadata, error) {
+	if len(bytes) == 0 {
+		return &CodeMetadata{Payable: true}, nil
+	}
+
+	if len(bytes) != CodeMetadataLen {
+		return nil, ErrInvalidCodeMetadata
+	}
+
+	knownFlags := [CodeMetadataLen]byte{
+		MetadataUpgradeable | MetadataNotReadable,
+		MetadataPayable | MetadataNonReentrant,
+	}
+	for i, flags := range bytes {
+		if flags&^knownFlags[i] != 0 {
+			return nil, ErrInvalidCodeMetadata
+		}
+	}
+
+	return &CodeMetadata{
+		Upgradeable:  (bytes[0] & MetadataUpgradeable) != 0,
+		NotReadable:  (bytes[0] & MetadataNotReadable) != 0,
+		Payable:      (bytes[1] & MetadataPayable) != 0,
+		NonReentrant: (bytes[1] & MetadataNonReentrant) != 0,
+	}, nil
+}
+
+// ToBytes serializes the code metadata
+func (metadata *CodeMetadata) ToBytes() []byte {
+	bytes := make([]byte, CodeMetadataLen)
+
+	if metadata.Upgradeable {
+		bytes[0] |= MetadataUpgradeable
+	}
+	if metadata.NotReadable {
+		bytes[0] |= MetadataNotReadable
+	}
+	if metadata.Payable {
+		bytes[1] |= MetadataPayable
+	}
+	if metadata.NonReentrant {
+		bytes[1] |= MetadataNonReentrant
+	}
+
+	return bytes
+}
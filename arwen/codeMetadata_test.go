@@ -0,0 +1,74 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeMetadata_FromBytes(t *testing.T) {
+	t.Parallel()
+
+	metadata, err := CodeMetadataFromBytes([]byte{1, 0})
+	require.Nil(t, err)
+	require.Equal(t, &CodeMetadata{Upgradeable: true}, metadata)
+
+	metadata, err = CodeMetadataFromBytes([]byte{4, 2})
+	require.Nil(t, err)
+	require.Equal(t, &CodeMetadata{NotReadable: true, Payable: true}, metadata)
+
+	metadata, err = CodeMetadataFromBytes([]byte{5, 2})
+	require.Nil(t, err)
+	require.Equal(t, &CodeMetadata{Upgradeable: true, NotReadable: true, Payable: true}, metadata)
+
+	metadata, err = CodeMetadataFromBytes([]byte{0, 6})
+	require.Nil(t, err)
+	require.Equal(t, &CodeMetadata{Payable: true, NonReentrant: true}, metadata)
+}
+
+func TestCodeMetadata_FromBytes_Empty(t *testing.T) {
+	t.Parallel()
+
+	metadata, err := CodeMetadataFromBytes(nil)
+	require.Nil(t, err)
+	require.Equal(t, &CodeMetadata{Payable: true}, metadata)
+}
+
+func TestCodeMetadata_FromBytes_Invalid(t *testing.T) {
+	t.Parallel()
+
+	metadata, err := CodeMetadataFromBytes([]byte{1})
+	require.Equal(t, ErrInvalidCodeMetadata, err)
+	require.Nil(t, metadata)
+
+	metadata, err = CodeMetadataFromBytes([]byte{1, 0, 0})
+	require.Equal(t, ErrInvalidCodeMetadata, err)
+	require.Nil(t, metadata)
+
+	metadata, err = CodeMetadataFromBytes([]byte{2, 0})
+	require.Equal(t, ErrInvalidCodeMetadata, err)
+	require.Nil(t, metadata)
+
+	metadata, err = CodeMetadataFromBytes([]byte{0, 1})
+	require.Equal(t, ErrInvalidCodeMetadata, err)
+	require.Nil(t, metadata)
+
+	metadata, err = CodeMetadataFromBytes([]byte{0, 8})
+	require.Equal(t, ErrInvalidCodeMetadata, err)
+	require.Nil(t, metadata)
+}
+
+func TestCodeMetadata_ToBytes(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []byte{0, 0}, (&CodeMetadata{}).ToBytes())
+	require.Equal(t, []byte{1, 0}, (&CodeMetadata{Upgradeable: true}).ToBytes())
+	require.Equal(t, []byte{4, 0}, (&CodeMetadata{NotReadable: true}).ToBytes())
+	require.Equal(t, []byte{0, 2}, (&CodeMetadata{Payable: true}).ToBytes())
+	require.Equal(t, []byte{0, 4}, (&CodeMetadata{NonReentrant: true}).ToBytes())
+
+	metadata := &CodeMetadata{Upgradeable: true, NotReadable: true, Payable: true, NonReentrant: true}
+	parsed, err := CodeMetadataFromBytes(metadata.ToBytes())
+	require.Nil(t, err)
+	require.Equal(t, metadata, parsed)
+}
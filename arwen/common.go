@@ -1,6 +1,8 @@
 package arwen
 
 import (
+	"sort"
+
 	vmcommon "github.com/kalyan3104/dme-vm-common"
 	"github.com/kalyan3104/dme-vm-go/config"
 )
@@ -24,6 +26,20 @@ const (
 	AsyncUnknown
 )
 
+// String returns the name of the execution mode, as it appears in traces
+func (mode AsyncCallExecutionMode) String() string {
+	switch mode {
+	case SyncCall:
+		return "syncCall"
+	case AsyncBuiltinFunc:
+		return "asyncBuiltinFunc"
+	case AsyncUnknown:
+		return "asyncUnknown"
+	default:
+		return "unknown"
+	}
+}
+
 const CallbackDefault = "callBack"
 const TimeLockKeyPrefix = "timelock"
 const AsyncDataPrefix = "asyncCalls"
@@ -51,6 +67,109 @@ type VMHostParameters struct {
 	GasSchedule                  config.GasScheduleMap
 	ProtocolBuiltinFunctions     vmcommon.FunctionNames
 	Kalyan3104ProtectedKeyPrefix []byte
+	DisallowFloatingPoint        bool
+
+	// WASMValidationPolicy holds the limits enforced on the bytecode of
+	// contracts before they are deployed or upgraded; the zero value does not
+	// enforce any limit. DisallowFloatingPoint also rejects floating-point
+	// instructions, and MaxMemoryPages applies when the policy sets no
+	// memory limit of its own.
+	WASMValidationPolicy WASMValidationPolicy
+
+	// PayableCheckEnabled rejects the call value sent to contracts which are
+	// not payable; it activates the check for all the contracts at once,
+	// including those deployed before the payable flag was enforced
+	PayableCheckEnabled bool
+
+	// MaxMemoryPages limits the memory of each contract instance, in pages
+	// of 64KiB, both initially and after growing it; zero disables the
+	// limit. Unlike the limit checked when contracts are deployed, it also
+	// applies to contracts which are already deployed.
+	MaxMemoryPages uint32
+
+	// MemoryGrowGasEnabled charges the MemoryGrowPerPage gas for the pages of
+	// memory grown by each execution; it activates the charge for all the
+	// contracts at once. With MaxMemoryPages set, the gas left must also
+	// cover growing the memory up to that limit before the execution starts,
+	// so that any growth is paid for.
+	MemoryGrowGasEnabled bool
+
+	// MaxCallDepth limits how many executions may be nested under the one
+	// started by the VM; zero leaves nesting limited only by the maximum
+	// number of running Wasmer instances
+	MaxCallDepth uint64
+
+	// LogLimits limits the logs written by contracts; the zero value selects
+	// the DefaultLogLimits
+	LogLimits LogLimits
+
+	// DisableLogLimits lets contracts write logs without any limit,
+	// regardless of LogLimits
+	DisableLogLimits bool
+
+	// SignatureVerifier verifies the signatures checked by contracts, using
+	// the schemes and lengths documented in the crypto package; nil selects
+	// the verifier of the crypto package. It is not passed to Arwen processes
+	// started by the node, which always use the verifier of the crypto package.
+	SignatureVerifier SignatureVerifier `json:"-"`
+}
+
+// LogLimits holds the limits enforced on the logs written by contracts, each
+// log counting its topics and their size along with the size of its data; a
+// zero limit is not enforced
+type LogLimits struct {
+	MaxTopics               int
+	MaxSize                 int
+	MaxTopicsPerTransaction int
+	MaxSizePerTransaction   int
+}
+
+// DefaultLogLimits holds the recommended limits of the logs written by contracts
+var DefaultLogLimits = LogLimits{
+	MaxTopics:               8,
+	MaxSize:                 64 * 1024,
+	MaxTopicsPerTransaction: 1024,
+	MaxSizePerTransaction:   1024 * 1024,
+}
+
+// exceeds returns true if the value is above the limit and the limit is enforced
+func exceeds(value int, limit int) bool {
+	return limit > 0 && value > limit
+}
+
+// TooManyTopics returns true if a log may not have the given number of topics
+func (limits LogLimits) TooManyTopics(numTopics int) bool {
+	return exceeds(numTopics, limits.MaxTopics)
+}
+
+// TooLarge returns true if a log may not have the given size
+func (limits LogLimits) TooLarge(size int) bool {
+	return exceeds(size, limits.MaxSize)
+}
+
+// TransactionLimitsExceeded returns true if the logs of a transaction may not
+// have the given number of topics and size in total
+func (limits LogLimits) TransactionLimitsExceeded(numTopics int, size int) bool {
+	return exceeds(numTopics, limits.MaxTopicsPerTransaction) || exceeds(size, limits.MaxSizePerTransaction)
+}
+
+// WASMValidationPolicy holds the limits enforced on the bytecode of contracts
+// before they are deployed; a zero limit is not enforced
+type WASMValidationPolicy struct {
+	MaxFunctions    uint32
+	MaxLocals       uint32
+	MaxGlobals      uint32
+	MaxTableSize    uint32
+	MaxDataSegments uint32
+	MaxMemoryPages  uint32
+
+	// RestrictImports allows contracts to import only the functions of the
+	// "env" namespace provided by the VM
+	RestrictImports bool
+
+	// DisallowFloatingPoint rejects contracts containing floating-point
+	// arithmetic, conversion or reinterpret instructions
+	DisallowFloatingPoint bool
 }
 
 // AsyncCallInfo contains the information required to handle the asynchronous call of another SmartContract
@@ -95,6 +214,26 @@ type AsyncGeneratedCall struct {
 type AsyncContext struct {
 	Callback   string
 	AsyncCalls []*AsyncGeneratedCall
+
+	// Deadline is the block timestamp after which the pending async calls of
+	// this context are rejected; 0 means that the async calls never expire
+	Deadline uint64
+}
+
+// IsExpired returns true if the deadline of the async context has passed at the given block timestamp
+func (ac *AsyncContext) IsExpired(timestamp uint64) bool {
+	return ac.Deadline > 0 && timestamp > ac.Deadline
+}
+
+// IsResolved returns true if none of the async calls of the context are pending anymore
+func (ac *AsyncContext) IsResolved() bool {
+	for _, asyncCall := range ac.AsyncCalls {
+		if asyncCall.Status == AsyncCallPending {
+			return false
+		}
+	}
+
+	return true
 }
 
 // AsyncContextInfo is the structure resulting after a smart contract call that has initiated
@@ -103,6 +242,77 @@ type AsyncContextInfo struct {
 	CallerAddr      []byte
 	ReturnData      []byte
 	AsyncContextMap map[string]*AsyncContext
+
+	// AsyncContextOrder holds the identifiers of the async contexts in the
+	// order they were added, so that they are always processed in this order
+	AsyncContextOrder []string
+}
+
+// AddAsyncCall appends the async call to the async context with the given
+// identifier, creating the async context with the given callback if needed
+func (aci *AsyncContextInfo) AddAsyncCall(contextIdentifier string, callback string, asyncCall *AsyncGeneratedCall) {
+	asyncContext, ok := aci.AsyncContextMap[contextIdentifier]
+	if !ok {
+		asyncContext = &AsyncContext{
+			Callback:   callback,
+			AsyncCalls: make([]*AsyncGeneratedCall, 0),
+		}
+		aci.AddAsyncContext(contextIdentifier, asyncContext)
+	}
+
+	asyncContext.AsyncCalls = append(asyncContext.AsyncCalls, asyncCall)
+}
+
+// AddAsyncContext sets the async context with the given identifier; a new
+// identifier is placed after all the existing ones
+func (aci *AsyncContextInfo) AddAsyncContext(contextIdentifier string, asyncContext *AsyncContext) {
+	if aci.AsyncContextMap == nil {
+		aci.AsyncContextMap = make(map[string]*AsyncContext)
+	}
+
+	_, ok := aci.AsyncContextMap[contextIdentifier]
+	if !ok {
+		aci.AsyncContextOrder = append(aci.AsyncContextOrder, contextIdentifier)
+	}
+
+	aci.AsyncContextMap[contextIdentifier] = asyncContext
+}
+
+// DeleteAsyncContext removes the async context with the given identifier
+func (aci *AsyncContextInfo) DeleteAsyncContext(contextIdentifier string) {
+	delete(aci.AsyncContextMap, contextIdentifier)
+
+	for i, identifier := range aci.AsyncContextOrder {
+		if identifier == contextIdentifier {
+			aci.AsyncContextOrder = append(aci.AsyncContextOrder[:i], aci.AsyncContextOrder[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetContextIdentifiers returns the identifiers of the async contexts in the
+// order they were added. Async contexts set directly in AsyncContextMap, like
+// the ones read from the legacy storage format, follow in lexicographic order.
+func (aci *AsyncContextInfo) GetContextIdentifiers() []string {
+	identifiers := make([]string, 0, len(aci.AsyncContextMap))
+	ordered := make(map[string]bool, len(aci.AsyncContextOrder))
+	for _, identifier := range aci.AsyncContextOrder {
+		_, ok := aci.AsyncContextMap[identifier]
+		if ok && !ordered[identifier] {
+			identifiers = append(identifiers, identifier)
+			ordered[identifier] = true
+		}
+	}
+
+	unordered := make([]string, 0)
+	for identifier := range aci.AsyncContextMap {
+		if !ordered[identifier] {
+			unordered = append(unordered, identifier)
+		}
+	}
+	sort.Strings(unordered)
+
+	return append(identifiers, unordered...)
 }
 
 // GetDestination returns the destination of an async call
@@ -0,0 +1,90 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncContextInfo_AddAsyncCall_KeepsCreationOrder(t *testing.T) {
+	t.Parallel()
+
+	asyncInfo := &AsyncContextInfo{}
+	asyncInfo.AddAsyncCall("c", "", &AsyncGeneratedCall{Destination: []byte("first")})
+	asyncInfo.AddAsyncCall("a", "callbackA", &AsyncGeneratedCall{Destination: []byte("second")})
+	asyncInfo.AddAsyncCall("c", "ignored", &AsyncGeneratedCall{Destination: []byte("third")})
+	asyncInfo.AddAsyncCall("b", "", &AsyncGeneratedCall{Destination: []byte("fourth")})
+
+	require.Equal(t, []string{"c", "a", "b"}, asyncInfo.GetContextIdentifiers())
+	require.Equal(t, "", asyncInfo.AsyncContextMap["c"].Callback)
+	require.Equal(t, "callbackA", asyncInfo.AsyncContextMap["a"].Callback)
+
+	calls := asyncInfo.AsyncContextMap["c"].AsyncCalls
+	require.Len(t, calls, 2)
+	require.Equal(t, []byte("first"), calls[0].Destination)
+	require.Equal(t, []byte("third"), calls[1].Destination)
+}
+
+func TestAsyncContextInfo_DeleteAsyncContext(t *testing.T) {
+	t.Parallel()
+
+	asyncInfo := &AsyncContextInfo{}
+	asyncInfo.AddAsyncContext("c", &AsyncContext{})
+	asyncInfo.AddAsyncContext("a", &AsyncContext{})
+	asyncInfo.AddAsyncContext("b", &AsyncContext{})
+
+	asyncInfo.DeleteAsyncContext("a")
+	require.Equal(t, []string{"c", "b"}, asyncInfo.GetContextIdentifiers())
+	require.NotContains(t, asyncInfo.AsyncContextMap, "a")
+
+	asyncInfo.DeleteAsyncContext("missing")
+	require.Equal(t, []string{"c", "b"}, asyncInfo.GetContextIdentifiers())
+
+	asyncInfo.AddAsyncContext("a", &AsyncContext{})
+	require.Equal(t, []string{"c", "b", "a"}, asyncInfo.GetContextIdentifiers())
+}
+
+func TestAsyncContextInfo_GetContextIdentifiers_Unordered(t *testing.T) {
+	t.Parallel()
+
+	asyncInfo := &AsyncContextInfo{
+		AsyncContextMap: map[string]*AsyncContext{
+			"z": {},
+			"x": {},
+			"y": {},
+		},
+		AsyncContextOrder: []string{"y", "missing"},
+	}
+
+	for i := 0; i < 10; i++ {
+		require.Equal(t, []string{"y", "x", "z"}, asyncInfo.GetContextIdentifiers())
+	}
+}
+
+func TestAsyncContext_IsExpired(t *testing.T) {
+	t.Parallel()
+
+	asyncContext := &AsyncContext{}
+	require.False(t, asyncContext.IsExpired(0))
+	require.False(t, asyncContext.IsExpired(1000))
+
+	asyncContext.Deadline = 100
+	require.False(t, asyncContext.IsExpired(99))
+	require.False(t, asyncContext.IsExpired(100))
+	require.True(t, asyncContext.IsExpired(101))
+}
+
+func TestAsyncContext_IsResolved(t *testing.T) {
+	t.Parallel()
+
+	asyncContext := &AsyncContext{
+		AsyncCalls: []*AsyncGeneratedCall{
+			{Status: AsyncCallResolved},
+			{Status: AsyncCallPending},
+		},
+	}
+	require.False(t, asyncContext.IsResolved())
+
+	asyncContext.AsyncCalls[1].Status = AsyncCallRejected
+	require.True(t, asyncContext.IsResolved())
+}
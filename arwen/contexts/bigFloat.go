@@ -0,0 +1,95 @@
+package contexts
+
+import (
+	"github.com/kalyan3104/dme-vm-go/arwen"
+)
+
+type bigFloatMap map[int32]*arwen.BigFloat
+
+type bigFloatContext struct {
+	values     bigFloatMap
+	stateStack []bigFloatMap
+}
+
+// NewBigFloatContext creates a new bigFloatContext
+func NewBigFloatContext() (*bigFloatContext, error) {
+	context := &bigFloatContext{
+		values:     make(bigFloatMap),
+		stateStack: make([]bigFloatMap, 0),
+	}
+
+	return context, nil
+}
+
+func (context *bigFloatContext) InitState() {
+	context.values = make(bigFloatMap)
+}
+
+func (context *bigFloatContext) PushState() {
+	newState := context.clone()
+	context.stateStack = append(context.stateStack, newState)
+}
+
+func (context *bigFloatContext) PopSetActiveState() {
+	stateStackLen := len(context.stateStack)
+	prevValues := context.stateStack[stateStackLen-1]
+	context.stateStack = context.stateStack[:stateStackLen-1]
+
+	context.values = prevValues
+}
+
+func (context *bigFloatContext) PopDiscard() {
+	stateStackLen := len(context.stateStack)
+	context.stateStack = context.stateStack[:stateStackLen-1]
+}
+
+func (context *bigFloatContext) ClearStateStack() {
+	context.stateStack = make([]bigFloatMap, 0)
+}
+
+func (context *bigFloatContext) clone() bigFloatMap {
+	newState := make(bigFloatMap, len(context.values))
+	for handle, bigFloat := range context.values {
+		newState[handle] = newZeroBigFloat().Set(bigFloat)
+	}
+	return newState
+}
+
+func (context *bigFloatContext) Put(value *arwen.BigFloat) int32 {
+	newHandle := int32(len(context.values))
+	for {
+		if _, ok := context.values[newHandle]; !ok {
+			break
+		}
+		newHandle++
+	}
+
+	context.values[newHandle] = value
+
+	return newHandle
+}
+
+func (context *bigFloatContext) GetOne(handle int32) *arwen.BigFloat {
+	if _, ok := context.values[handle]; !ok {
+		context.values[handle] = newZeroBigFloat()
+	}
+
+	return context.values[handle]
+}
+
+func (context *bigFloatContext) GetTwo(handle1 int32, handle2 int32) (*arwen.BigFloat, *arwen.BigFloat) {
+	return context.GetOne(handle1), context.GetOne(handle2)
+}
+
+func (context *bigFloatContext) GetThree(handle1 int32, handle2 int32, handle3 int32) (*arwen.BigFloat, *arwen.BigFloat, *arwen.BigFloat) {
+	return context.GetOne(handle1), context.GetOne(handle2), context.GetOne(handle3)
+}
+
+func (context *bigFloatContext) IsInterfaceNil() bool {
+	return context == nil
+}
+
+func newZeroBigFloat() *arwen.BigFloat {
+	zero, _ := arwen.NewBigFloat(0, 0)
+	return zero
+}
@@ -0,0 +1,93 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/stretchr/testify/require"
+)
+
+func newBigFloatValue(t *testing.T, mantissa int64, decimals int32) *arwen.BigFloat {
+	value, err := arwen.NewBigFloat(mantissa, decimals)
+	require.Nil(t, err)
+	return value
+}
+
+func TestNewBigFloat(t *testing.T) {
+	t.Parallel()
+
+	bigFloatContext, err := NewBigFloatContext()
+
+	require.Nil(t, err)
+	require.False(t, bigFloatContext.IsInterfaceNil())
+	require.NotNil(t, bigFloatContext.values)
+	require.NotNil(t, bigFloatContext.stateStack)
+	require.Equal(t, 0, len(bigFloatContext.values))
+	require.Equal(t, 0, len(bigFloatContext.stateStack))
+}
+
+func TestBigFloatContext_InitPushPopState(t *testing.T) {
+	t.Parallel()
+
+	bigFloatContext, _ := NewBigFloatContext()
+	bigFloatContext.InitState()
+
+	value1 := newBigFloatValue(t, 15, 1)
+	index1 := bigFloatContext.Put(value1)
+	require.Equal(t, int32(0), index1)
+	require.Equal(t, 0, value1.Cmp(bigFloatContext.GetOne(index1)))
+
+	// Copy active state to stack, then clean it. The previous value should not
+	// be accessible.
+	bigFloatContext.PushState()
+	require.Equal(t, 1, len(bigFloatContext.stateStack))
+	bigFloatContext.InitState()
+
+	index2 := bigFloatContext.Put(newBigFloatValue(t, 25, 2))
+	require.Equal(t, int32(0), index2)
+	require.Equal(t, 0, newBigFloatValue(t, 25, 2).Cmp(bigFloatContext.GetOne(index2)))
+
+	// Copy active state to stack, keeping it active; changes to the active
+	// state must not reach the copy on the stack.
+	bigFloatContext.PushState()
+	require.Equal(t, 2, len(bigFloatContext.stateStack))
+	bigFloatContext.GetOne(index2).Add(bigFloatContext.GetOne(index2), newBigFloatValue(t, 1, 0))
+
+	// Discard the top of the stack; the added value is still active.
+	bigFloatContext.PopDiscard()
+	require.Equal(t, 1, len(bigFloatContext.stateStack))
+	require.Equal(t, 0, newBigFloatValue(t, 125, 2).Cmp(bigFloatContext.GetOne(index2)))
+
+	// Restore the first active state by popping to the active state (which is
+	// lost).
+	bigFloatContext.PopSetActiveState()
+	require.Equal(t, 0, len(bigFloatContext.stateStack))
+	require.Equal(t, 0, value1.Cmp(bigFloatContext.GetOne(index1)))
+
+	bigFloatContext.PushState()
+	bigFloatContext.ClearStateStack()
+	require.Equal(t, 0, len(bigFloatContext.stateStack))
+}
+
+func TestBigFloatContext_PutGet(t *testing.T) {
+	t.Parallel()
+
+	bigFloatContext, _ := NewBigFloatContext()
+
+	index1 := bigFloatContext.Put(newBigFloatValue(t, 1, 0))
+	index2 := bigFloatContext.Put(newBigFloatValue(t, -2, 1))
+	index3 := bigFloatContext.Put(newBigFloatValue(t, 3, 18))
+	require.Equal(t, int32(0), index1)
+	require.Equal(t, int32(1), index2)
+	require.Equal(t, int32(2), index3)
+
+	value1, value2, value3 := bigFloatContext.GetThree(index1, index2, index3)
+	require.Equal(t, 0, newBigFloatValue(t, 1, 0).Cmp(value1))
+	require.Equal(t, 0, newBigFloatValue(t, -2, 1).Cmp(value2))
+	require.Equal(t, 0, newBigFloatValue(t, 3, 18).Cmp(value3))
+
+	// An unknown handle is created as zero.
+	value := bigFloatContext.GetOne(123)
+	require.Equal(t, 0, newBigFloatValue(t, 0, 0).Cmp(value))
+	require.Equal(t, int32(4), bigFloatContext.Put(newBigFloatValue(t, 4, 0)))
+}
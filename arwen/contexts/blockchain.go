@@ -108,11 +108,26 @@ func (context *blockchainContext) IncreaseNonce(address []byte) {
 	outputAccount.Nonce = nonce + 1
 }
 
+// GetCodeHash returns the code hash stored in the account, or hashes its code
+// if the account does not provide one
 func (context *blockchainContext) GetCodeHash(addr []byte) ([]byte, error) {
-	code, err := context.GetCode(addr)
+	account, err := context.blockChainHook.GetUserAccount(addr)
 	if err != nil {
 		return nil, err
 	}
+	if arwen.IfNil(account) {
+		return nil, arwen.ErrInvalidAccount
+	}
+
+	code := account.GetCode()
+	if len(code) == 0 {
+		return nil, arwen.ErrContractNotFound
+	}
+
+	codeHash := account.GetCodeHash()
+	if len(codeHash) > 0 {
+		return codeHash, nil
+	}
 
 	return context.host.Crypto().Keccak256(code)
 }
@@ -145,6 +160,18 @@ func (context *blockchainContext) GetCodeSize(address []byte) (int32, error) {
 	return result, nil
 }
 
+func (context *blockchainContext) GetCodeMetadata(address []byte) (*arwen.CodeMetadata, error) {
+	account, err := context.blockChainHook.GetUserAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	if arwen.IfNil(account) {
+		return nil, arwen.ErrInvalidAccount
+	}
+
+	return arwen.CodeMetadataFromBytes(account.GetCodeMetadata())
+}
+
 func (context *blockchainContext) BlockHash(number int64) []byte {
 	if number < 0 {
 		return nil
@@ -236,6 +236,16 @@ func TestBlockchainContext_GetCodeHashAndSize(t *testing.T) {
 	require.Equal(t, expectedCodeHash, codeHash)
 	require.Nil(t, err)
 
+	// GetCodeHash: Test the code hash stored in the account is preferred
+	blockchainHook.AddAccount(&mock.AccountMock{
+		Address:  []byte("account_with_code_hash"),
+		Code:     expectedCode,
+		CodeHash: []byte("stored code hash"),
+	})
+	codeHash, err = blockchainContext.GetCodeHash([]byte("account_with_code_hash"))
+	require.Equal(t, []byte("stored code hash"), codeHash)
+	require.Nil(t, err)
+
 	// GetCodeSize: Test if error is propagated from blockchain hook
 	blockchainHook.Err = errTestError
 	size, err := blockchainContext.GetCodeSize(address)
@@ -249,6 +259,44 @@ func TestBlockchainContext_GetCodeHashAndSize(t *testing.T) {
 	require.Equal(t, int32(len(expectedCode)), size)
 }
 
+func TestBlockchainContext_GetCodeMetadata(t *testing.T) {
+	t.Parallel()
+
+	blockchainHook := mock.NewBlockchainHookMock()
+	blockchainHook.AddAccounts([]*mock.AccountMock{
+		{Address: []byte("account_upgradeable"), Code: []byte("somecode"), CodeMetadata: []byte{1, 0}},
+		{Address: []byte("account_payable"), Code: []byte("somecode"), CodeMetadata: []byte{0, 2}},
+		{Address: []byte("account_legacy"), Code: []byte("somecode")},
+		{Address: []byte("account_invalid"), Code: []byte("somecode"), CodeMetadata: []byte{8, 8}},
+	})
+
+	host := &mock.VmHostMock{}
+	blockchainContext, _ := NewBlockchainContext(host, blockchainHook)
+
+	// GetCodeMetadata: Test if error is propagated from blockchain hook
+	blockchainHook.Err = errTestError
+	codeMetadata, err := blockchainContext.GetCodeMetadata([]byte("account_upgradeable"))
+	require.Equal(t, errTestError, err)
+	require.Nil(t, codeMetadata)
+	blockchainHook.Err = nil
+
+	codeMetadata, err = blockchainContext.GetCodeMetadata([]byte("account_upgradeable"))
+	require.Nil(t, err)
+	require.Equal(t, &arwen.CodeMetadata{Upgradeable: true}, codeMetadata)
+
+	codeMetadata, err = blockchainContext.GetCodeMetadata([]byte("account_payable"))
+	require.Nil(t, err)
+	require.Equal(t, &arwen.CodeMetadata{Payable: true}, codeMetadata)
+
+	codeMetadata, err = blockchainContext.GetCodeMetadata([]byte("account_legacy"))
+	require.Nil(t, err)
+	require.Equal(t, &arwen.CodeMetadata{Payable: true}, codeMetadata)
+
+	codeMetadata, err = blockchainContext.GetCodeMetadata([]byte("account_invalid"))
+	require.Equal(t, arwen.ErrInvalidCodeMetadata, err)
+	require.Nil(t, codeMetadata)
+}
+
 func TestBlockchainContext_NewAddress(t *testing.T) {
 	t.Parallel()
 
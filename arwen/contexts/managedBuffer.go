@@ -0,0 +1,115 @@
+package contexts
+
+import (
+	"github.com/kalyan3104/dme-vm-go/arwen"
+)
+
+type managedBufferMap map[int32][]byte
+
+type managedBufferContext struct {
+	values     managedBufferMap
+	stateStack []managedBufferMap
+}
+
+// NewManagedBufferContext creates a new managedBufferContext
+func NewManagedBufferContext() (*managedBufferContext, error) {
+	context := &managedBufferContext{
+		values:     make(managedBufferMap),
+		stateStack: make([]managedBufferMap, 0),
+	}
+
+	return context, nil
+}
+
+func (context *managedBufferContext) InitState() {
+	context.values = make(managedBufferMap)
+}
+
+func (context *managedBufferContext) PushState() {
+	newState := context.clone()
+	context.stateStack = append(context.stateStack, newState)
+}
+
+func (context *managedBufferContext) PopSetActiveState() {
+	stateStackLen := len(context.stateStack)
+	prevValues := context.stateStack[stateStackLen-1]
+	context.stateStack = context.stateStack[:stateStackLen-1]
+
+	context.values = prevValues
+}
+
+func (context *managedBufferContext) PopDiscard() {
+	stateStackLen := len(context.stateStack)
+	context.stateStack = context.stateStack[:stateStackLen-1]
+}
+
+func (context *managedBufferContext) ClearStateStack() {
+	context.stateStack = make([]managedBufferMap, 0)
+}
+
+func (context *managedBufferContext) clone() managedBufferMap {
+	newState := make(managedBufferMap, len(context.values))
+	for handle, buffer := range context.values {
+		newState[handle] = append([]byte{}, buffer...)
+	}
+	return newState
+}
+
+func (context *managedBufferContext) NewBuffer() int32 {
+	return context.NewBufferFromBytes(nil)
+}
+
+func (context *managedBufferContext) NewBufferFromBytes(bytes []byte) int32 {
+	newHandle := int32(len(context.values))
+	for {
+		if _, ok := context.values[newHandle]; !ok {
+			break
+		}
+		newHandle++
+	}
+
+	context.values[newHandle] = append([]byte{}, bytes...)
+
+	return newHandle
+}
+
+func (context *managedBufferContext) SetBytes(handle int32, bytes []byte) {
+	context.values[handle] = append([]byte{}, bytes...)
+}
+
+func (context *managedBufferContext) GetBytes(handle int32) ([]byte, error) {
+	buffer, ok := context.values[handle]
+	if !ok {
+		return nil, arwen.ErrNoManagedBufferUnderThisHandle
+	}
+
+	return buffer, nil
+}
+
+func (context *managedBufferContext) AppendBytes(handle int32, bytes []byte) error {
+	buffer, ok := context.values[handle]
+	if !ok {
+		return arwen.ErrNoManagedBufferUnderThisHandle
+	}
+
+	context.values[handle] = append(buffer, bytes...)
+
+	return nil
+}
+
+func (context *managedBufferContext) GetSlice(handle int32, startPosition int32, sliceLength int32) ([]byte, error) {
+	buffer, err := context.GetBytes(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	if startPosition < 0 || sliceLength < 0 || int64(startPosition)+int64(sliceLength) > int64(len(buffer)) {
+		return nil, arwen.ErrBadBounds
+	}
+
+	return buffer[startPosition : startPosition+sliceLength], nil
+}
+
+func (context *managedBufferContext) IsInterfaceNil() bool {
+	return context == nil
+}
@@ -0,0 +1,142 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewManagedBuffer(t *testing.T) {
+	t.Parallel()
+
+	managedBufferContext, err := NewManagedBufferContext()
+
+	require.Nil(t, err)
+	require.False(t, managedBufferContext.IsInterfaceNil())
+	require.NotNil(t, managedBufferContext.values)
+	require.NotNil(t, managedBufferContext.stateStack)
+	require.Equal(t, 0, len(managedBufferContext.values))
+	require.Equal(t, 0, len(managedBufferContext.stateStack))
+}
+
+func TestManagedBufferContext_InitPushPopState(t *testing.T) {
+	t.Parallel()
+
+	managedBufferContext, _ := NewManagedBufferContext()
+	managedBufferContext.InitState()
+
+	handle1 := managedBufferContext.NewBufferFromBytes([]byte("first"))
+	require.Equal(t, int32(0), handle1)
+
+	// Copy active state to stack, then clean it. The previous value should not
+	// be accessible.
+	managedBufferContext.PushState()
+	require.Equal(t, 1, len(managedBufferContext.stateStack))
+	managedBufferContext.InitState()
+
+	_, err := managedBufferContext.GetBytes(handle1)
+	require.Equal(t, arwen.ErrNoManagedBufferUnderThisHandle, err)
+
+	handle2 := managedBufferContext.NewBufferFromBytes([]byte("second"))
+	require.Equal(t, int32(0), handle2)
+
+	// Copy active state to stack, keeping it active; changes to the active
+	// state must not reach the copy on the stack.
+	managedBufferContext.PushState()
+	require.Equal(t, 2, len(managedBufferContext.stateStack))
+
+	err = managedBufferContext.AppendBytes(handle2, []byte("!"))
+	require.Nil(t, err)
+
+	// Discard the top of the stack; the appended value is still active.
+	managedBufferContext.PopDiscard()
+	require.Equal(t, 1, len(managedBufferContext.stateStack))
+	bytes, _ := managedBufferContext.GetBytes(handle2)
+	require.Equal(t, []byte("second!"), bytes)
+
+	// Restore the first active state by popping to the active state (which is
+	// lost).
+	managedBufferContext.PopSetActiveState()
+	require.Equal(t, 0, len(managedBufferContext.stateStack))
+	bytes, _ = managedBufferContext.GetBytes(handle1)
+	require.Equal(t, []byte("first"), bytes)
+
+	managedBufferContext.PushState()
+	managedBufferContext.ClearStateStack()
+	require.Equal(t, 0, len(managedBufferContext.stateStack))
+}
+
+func TestManagedBufferContext_PushStateCopiesBytes(t *testing.T) {
+	t.Parallel()
+
+	managedBufferContext, _ := NewManagedBufferContext()
+	handle := managedBufferContext.NewBufferFromBytes(make([]byte, 4, 16))
+
+	managedBufferContext.PushState()
+	_ = managedBufferContext.AppendBytes(handle, []byte("tail"))
+	managedBufferContext.PopSetActiveState()
+
+	bytes, _ := managedBufferContext.GetBytes(handle)
+	require.Equal(t, make([]byte, 4), bytes)
+}
+
+func TestManagedBufferContext_NewSetGet(t *testing.T) {
+	t.Parallel()
+
+	managedBufferContext, _ := NewManagedBufferContext()
+
+	handle1 := managedBufferContext.NewBuffer()
+	require.Equal(t, int32(0), handle1)
+	bytes, err := managedBufferContext.GetBytes(handle1)
+	require.Nil(t, err)
+	require.Len(t, bytes, 0)
+
+	source := []byte("abc")
+	handle2 := managedBufferContext.NewBufferFromBytes(source)
+	require.Equal(t, int32(1), handle2)
+	source[0] = 'x'
+	bytes, _ = managedBufferContext.GetBytes(handle2)
+	require.Equal(t, []byte("abc"), bytes)
+
+	managedBufferContext.SetBytes(5, []byte("def"))
+	bytes, err = managedBufferContext.GetBytes(5)
+	require.Nil(t, err)
+	require.Equal(t, []byte("def"), bytes)
+
+	_, err = managedBufferContext.GetBytes(123)
+	require.Equal(t, arwen.ErrNoManagedBufferUnderThisHandle, err)
+
+	err = managedBufferContext.AppendBytes(123, []byte("def"))
+	require.Equal(t, arwen.ErrNoManagedBufferUnderThisHandle, err)
+}
+
+func TestManagedBufferContext_AppendGetSlice(t *testing.T) {
+	t.Parallel()
+
+	managedBufferContext, _ := NewManagedBufferContext()
+	handle := managedBufferContext.NewBufferFromBytes([]byte("abc"))
+
+	err := managedBufferContext.AppendBytes(handle, []byte("def"))
+	require.Nil(t, err)
+
+	slice, err := managedBufferContext.GetSlice(handle, 2, 3)
+	require.Nil(t, err)
+	require.Equal(t, []byte("cde"), slice)
+
+	slice, err = managedBufferContext.GetSlice(handle, 6, 0)
+	require.Nil(t, err)
+	require.Len(t, slice, 0)
+
+	_, err = managedBufferContext.GetSlice(handle, 4, 3)
+	require.Equal(t, arwen.ErrBadBounds, err)
+
+	_, err = managedBufferContext.GetSlice(handle, -1, 2)
+	require.Equal(t, arwen.ErrBadBounds, err)
+
+	_, err = managedBufferContext.GetSlice(handle, 0, -1)
+	require.Equal(t, arwen.ErrBadBounds, err)
+
+	_, err = managedBufferContext.GetSlice(123, 0, 0)
+	require.Equal(t, arwen.ErrNoManagedBufferUnderThisHandle, err)
+}
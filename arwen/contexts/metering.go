@@ -11,6 +11,18 @@ type meteringContext struct {
 	blockGasLimit         uint64
 	gasLockedForAsyncStep uint64
 	host                  arwen.VMHost
+
+	gasProfiling  bool
+	gasProfile    *arwen.GasProfile
+	gasCategory   string
+	profileFrames []*gasProfileFrame
+}
+
+// gasProfileFrame holds the gas attributed to categories during an execution,
+// so that the rest of the gas used by the execution is attributed to opcodes
+type gasProfileFrame struct {
+	gasAttributed  uint64
+	parentCategory string
 }
 
 // NewMeteringContext creates a new meteringContext
@@ -40,10 +52,20 @@ func (context *meteringContext) GasSchedule() *config.GasCost {
 }
 
 func (context *meteringContext) UseGas(gas uint64) {
+	context.ForwardGas(gas)
+	context.attributeGasUsed(context.currentGasCategory(), gas)
+}
+
+// ForwardGas uses the gas provided to a nested execution; unlike UseGas(), it
+// doesn't attribute the gas in the gas profile, because the nested execution
+// attributes it to the categories in which it is actually used
+func (context *meteringContext) ForwardGas(gas uint64) {
 	gasUsed := context.host.Runtime().GetPointsUsed() + gas
 	context.host.Runtime().SetPointsUsed(gasUsed)
 }
 
+// RestoreGas returns the gas left unused by a nested execution, previously
+// used by ForwardGas()
 func (context *meteringContext) RestoreGas(gas uint64) {
 	gasUsed := context.host.Runtime().GetPointsUsed()
 	if gas <= gasUsed {
@@ -55,6 +77,11 @@ func (context *meteringContext) RestoreGas(gas uint64) {
 func (context *meteringContext) FreeGas(gas uint64) {
 	refund := context.host.Output().GetRefund() + gas
 	context.host.Output().SetRefund(refund)
+
+	if context.isProfilingFrame() {
+		address := context.host.Runtime().GetSCAddress()
+		context.gasProfile.GetContract(address).AddGasRefunded(context.currentGasCategory(), gas)
+	}
 }
 
 func (context *meteringContext) GasLeft() uint64 {
@@ -97,6 +124,7 @@ func (context *meteringContext) deductAndLockGasIfAsyncStep() error {
 	input.GasProvided -= gasToDeduct
 
 	context.gasLockedForAsyncStep = gasToLock
+	context.attributeGasUsed(arwen.GasCategoryAsyncLock, gasToDeduct)
 
 	return nil
 }
@@ -105,6 +133,7 @@ func (context *meteringContext) deductAndLockGasIfAsyncStep() error {
 func (context *meteringContext) UnlockGasIfAsyncStep() {
 	input := context.host.Runtime().GetVMInput()
 	input.GasProvided += context.gasLockedForAsyncStep
+	context.attributeGasRestored(arwen.GasCategoryAsyncLock, context.gasLockedForAsyncStep)
 	context.gasLockedForAsyncStep = 0
 }
 
@@ -116,6 +145,20 @@ func (context *meteringContext) BlockGasLimit() uint64 {
 	return context.blockGasLimit
 }
 
+// UseGasForMemoryGrowth uses the gas for the pages of memory grown by an
+// instance, failing if there is not enough gas left
+func (context *meteringContext) UseGasForMemoryGrowth(pages uint32) error {
+	gasToUse := context.gasSchedule.BaseOperationCost.MemoryGrowPerPage * uint64(pages)
+	if gasToUse > context.GasLeft() {
+		return arwen.ErrNotEnoughGas
+	}
+
+	previousGasCategory := context.SetGasCategory(arwen.GasCategoryMemory)
+	context.UseGas(gasToUse)
+	context.SetGasCategory(previousGasCategory)
+	return nil
+}
+
 // DeductInitialGasForExecution deducts gas for compilation and locks gas if the execution is an asynchronous call
 func (context *meteringContext) DeductInitialGasForExecution(contract []byte) error {
 	costPerByte := context.gasSchedule.BaseOperationCost.CompilePerByte
@@ -160,5 +203,120 @@ func (context *meteringContext) deductInitialGas(
 	}
 
 	input.GasProvided -= initialCost
+	context.attributeGasUsed(arwen.GasCategoryDeployment, baseCost)
+	context.attributeGasUsed(arwen.GasCategoryCompilation, codeCost)
 	return nil
 }
+
+// SetGasProfiling enables or disables the gas profiling of the following transactions
+func (context *meteringContext) SetGasProfiling(enabled bool) {
+	context.gasProfiling = enabled
+	context.gasProfile = nil
+	context.gasCategory = ""
+	context.profileFrames = nil
+}
+
+// IsGasProfiling returns true if the gas profiling is enabled
+func (context *meteringContext) IsGasProfiling() bool {
+	return context.gasProfiling
+}
+
+// GasProfile returns the gas profile of the current or last transaction, or
+// nil if the gas profiling is disabled
+func (context *meteringContext) GasProfile() *arwen.GasProfile {
+	return context.gasProfile
+}
+
+// SetGasCategory sets the category to which the gas used by UseGas() is
+// attributed in the gas profile, and returns the previous category
+func (context *meteringContext) SetGasCategory(category string) string {
+	previousCategory := context.gasCategory
+	context.gasCategory = category
+	return previousCategory
+}
+
+// StartGasProfile starts a new gas profile for a transaction, if the gas
+// profiling is enabled
+func (context *meteringContext) StartGasProfile() {
+	if !context.gasProfiling {
+		return
+	}
+
+	context.gasProfile = arwen.NewGasProfile()
+	context.gasCategory = ""
+	context.profileFrames = nil
+	context.StartGasProfileFrame()
+}
+
+// StartGasProfileFrame starts attributing the gas used by a new execution
+func (context *meteringContext) StartGasProfileFrame() {
+	if context.gasProfile == nil {
+		return
+	}
+
+	context.profileFrames = append(context.profileFrames, &gasProfileFrame{
+		parentCategory: context.gasCategory,
+	})
+	context.gasCategory = ""
+}
+
+// EndGasProfileFrame ends the execution started by the last call to
+// StartGasProfileFrame(), which used the given gas in total; the gas which
+// was not attributed to other categories is attributed to the opcodes of the
+// contract with the given address. The gas is also attributed to the nested
+// calls of the contract which started the execution, if any.
+func (context *meteringContext) EndGasProfileFrame(address []byte, gasUsed uint64) {
+	if !context.isProfilingFrame() {
+		return
+	}
+
+	numFrames := len(context.profileFrames)
+	frame := context.profileFrames[numFrames-1]
+	context.profileFrames = context.profileFrames[:numFrames-1]
+	context.gasCategory = frame.parentCategory
+
+	if gasUsed > frame.gasAttributed {
+		context.gasProfile.GetContract(address).AddGasUsed(arwen.GasCategoryOpcodes, gasUsed-frame.gasAttributed)
+	}
+
+	if numFrames > 1 {
+		context.attributeGasUsed(arwen.GasCategoryNestedCalls, gasUsed)
+	}
+}
+
+func (context *meteringContext) isProfilingFrame() bool {
+	return context.gasProfiling && len(context.profileFrames) > 0
+}
+
+func (context *meteringContext) currentGasCategory() string {
+	if context.gasCategory == "" {
+		return arwen.GasCategoryHost
+	}
+	return context.gasCategory
+}
+
+func (context *meteringContext) attributeGasUsed(category string, gas uint64) {
+	if gas == 0 || !context.isProfilingFrame() {
+		return
+	}
+
+	address := context.host.Runtime().GetSCAddress()
+	context.gasProfile.GetContract(address).AddGasUsed(category, gas)
+	context.profileFrames[len(context.profileFrames)-1].gasAttributed += gas
+}
+
+func (context *meteringContext) attributeGasRestored(category string, gas uint64) {
+	if gas == 0 || !context.isProfilingFrame() {
+		return
+	}
+
+	address := context.host.Runtime().GetSCAddress()
+	context.gasProfile.GetContract(address).SubtractGasUsed(category, gas)
+
+	frame := context.profileFrames[len(context.profileFrames)-1]
+	if frame.gasAttributed < gas {
+		frame.gasAttributed = 0
+		return
+	}
+	frame.gasAttributed -= gas
+}
@@ -241,3 +241,96 @@ func TestMeteringContext_AsyncCallGasLocking(t *testing.T) {
 	meteringContext.UnlockGasIfAsyncStep()
 	require.Equal(t, gasProvided-1, meteringContext.GasLeft())
 }
+
+func TestMeteringContext_GasProfile(t *testing.T) {
+	t.Parallel()
+
+	addressA := []byte("contractA")
+	addressB := []byte("contractB")
+
+	mockRuntime := &mock.RuntimeContextMock{}
+	mockRuntime.SetVMInput(&vmcommon.VMInput{GasProvided: 10000})
+	mockRuntime.SetSCAddress(addressA)
+	mockOutput := &mock.OutputContextMock{GasRefund: big.NewInt(0)}
+	host := &mock.VmHostMock{
+		RuntimeContext: mockRuntime,
+		OutputContext:  mockOutput,
+	}
+	meteringContext, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+
+	meteringContext.StartGasProfile()
+	require.Nil(t, meteringContext.GasProfile())
+
+	meteringContext.SetGasProfiling(true)
+	require.True(t, meteringContext.IsGasProfiling())
+	meteringContext.StartGasProfile()
+
+	code := make([]byte, 10)
+	compilationCost := uint64(len(code)) * meteringContext.GasSchedule().BaseOperationCost.CompilePerByte
+	err := meteringContext.DeductInitialGasForExecution(code)
+	require.Nil(t, err)
+
+	mockRuntime.PointsUsed += 7
+	previousCategory := meteringContext.SetGasCategory(arwen.GasCategoryForAPI("getGasLeft"))
+	require.Equal(t, "", previousCategory)
+	meteringContext.UseGas(5)
+
+	meteringContext.StartGasProfileFrame()
+	gasLeftBefore := meteringContext.GasLeft()
+	meteringContext.ForwardGas(100)
+	mockRuntime.SetSCAddress(addressB)
+	meteringContext.FreeGas(2)
+	mockRuntime.SetSCAddress(addressA)
+	meteringContext.RestoreGas(60)
+	meteringContext.EndGasProfileFrame(addressB, gasLeftBefore-meteringContext.GasLeft())
+
+	require.Equal(t, arwen.GasCategoryForAPI("getGasLeft"), meteringContext.SetGasCategory(""))
+	meteringContext.UseGas(4)
+	meteringContext.EndGasProfileFrame(addressA, 10000-meteringContext.GasLeft())
+
+	profile := meteringContext.GasProfile()
+	require.Len(t, profile.Contracts, 2)
+
+	contractA := profile.Contracts[0]
+	require.Equal(t, addressA, contractA.Address)
+	require.Equal(t, map[string]uint64{
+		arwen.GasCategoryCompilation:          compilationCost,
+		arwen.GasCategoryForAPI("getGasLeft"): 5,
+		arwen.GasCategoryNestedCalls:          40,
+		arwen.GasCategoryHost:                 4,
+		arwen.GasCategoryOpcodes:              7,
+	}, contractA.GasUsed)
+	require.Equal(t, 10000-meteringContext.GasLeft(), contractA.TotalGasUsed())
+
+	contractB := profile.Contracts[1]
+	require.Equal(t, addressB, contractB.Address)
+	require.Equal(t, map[string]uint64{arwen.GasCategoryOpcodes: 40}, contractB.GasUsed)
+	require.Equal(t, map[string]uint64{arwen.GasCategoryHost: 2}, contractB.GasRefunded)
+}
+
+func TestMeteringContext_GasProfile_AsyncLock(t *testing.T) {
+	t.Parallel()
+
+	mockRuntime := &mock.RuntimeContextMock{}
+	mockRuntime.SetVMInput(&vmcommon.VMInput{GasProvided: 1000000, CallType: vmcommon.AsynchronousCall})
+	mockRuntime.SetSCAddress([]byte("contract"))
+	host := &mock.VmHostMock{
+		RuntimeContext: mockRuntime,
+	}
+	meteringContext, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringContext.SetGasProfiling(true)
+	meteringContext.StartGasProfile()
+
+	err := meteringContext.DeductInitialGasForExecution(nil)
+	require.Nil(t, err)
+
+	contract := meteringContext.GasProfile().GetContract([]byte("contract"))
+	require.NotZero(t, contract.GasUsed[arwen.GasCategoryAsyncLock])
+
+	locked := meteringContext.GetGasLockedForAsyncStep()
+	asyncCallStep := meteringContext.GasSchedule().Kalyan3104APICost.AsyncCallStep
+	require.Equal(t, asyncCallStep+locked, contract.GasUsed[arwen.GasCategoryAsyncLock])
+
+	meteringContext.UnlockGasIfAsyncStep()
+	require.Equal(t, asyncCallStep, contract.GasUsed[arwen.GasCategoryAsyncLock])
+}
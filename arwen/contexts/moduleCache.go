@@ -0,0 +1,87 @@
+package contexts
+
+import (
+	"container/list"
+
+	"github.com/kalyan3104/dme-vm-go/wasmer"
+)
+
+// moduleCache holds the most recently used compiled modules, keyed by the
+// hash of their code, evicting (and destroying) the least recently used
+// module when full
+type moduleCache struct {
+	maxSize int
+	modules map[string]*list.Element
+	lru     *list.List
+}
+
+type moduleCacheEntry struct {
+	codeHash string
+	module   *wasmer.Module
+}
+
+func newModuleCache(maxSize int) *moduleCache {
+	return &moduleCache{
+		maxSize: maxSize,
+		modules: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// get returns the module compiled from the code with the given hash, if cached
+func (cache *moduleCache) get(codeHash []byte) (*wasmer.Module, bool) {
+	element, ok := cache.modules[string(codeHash)]
+	if !ok {
+		return nil, false
+	}
+
+	cache.lru.MoveToFront(element)
+	return element.Value.(*moduleCacheEntry).module, true
+}
+
+// put adds the module compiled from the code with the given hash
+func (cache *moduleCache) put(codeHash []byte, module *wasmer.Module) {
+	if cache.maxSize <= 0 {
+		return
+	}
+
+	element, ok := cache.modules[string(codeHash)]
+	if ok {
+		entry := element.Value.(*moduleCacheEntry)
+		if entry.module != module {
+			entry.module.Destroy()
+			entry.module = module
+		}
+		cache.lru.MoveToFront(element)
+		return
+	}
+
+	for cache.lru.Len() >= cache.maxSize {
+		cache.removeElement(cache.lru.Back())
+	}
+
+	entry := &moduleCacheEntry{
+		codeHash: string(codeHash),
+		module:   module,
+	}
+	cache.modules[entry.codeHash] = cache.lru.PushFront(entry)
+}
+
+// len returns the number of cached modules
+func (cache *moduleCache) len() int {
+	return cache.lru.Len()
+}
+
+// clear destroys all the cached modules
+func (cache *moduleCache) clear() {
+	for cache.lru.Len() > 0 {
+		cache.removeElement(cache.lru.Back())
+	}
+}
+
+func (cache *moduleCache) removeElement(element *list.Element) {
+	entry := element.Value.(*moduleCacheEntry)
+	cache.lru.Remove(element)
+	delete(cache.modules, entry.codeHash)
+	entry.module.Destroy()
+}
@@ -0,0 +1,73 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/kalyan3104/dme-vm-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleCache_GetPut(t *testing.T) {
+	cache := newModuleCache(2)
+
+	module, ok := cache.get([]byte("alpha"))
+	require.False(t, ok)
+	require.Nil(t, module)
+
+	alpha := &wasmer.Module{}
+	cache.put([]byte("alpha"), alpha)
+	module, ok = cache.get([]byte("alpha"))
+	require.True(t, ok)
+	require.True(t, alpha == module)
+	require.Equal(t, 1, cache.len())
+}
+
+func TestModuleCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newModuleCache(2)
+
+	alpha := &wasmer.Module{}
+	beta := &wasmer.Module{}
+	gamma := &wasmer.Module{}
+
+	cache.put([]byte("alpha"), alpha)
+	cache.put([]byte("beta"), beta)
+
+	_, ok := cache.get([]byte("alpha"))
+	require.True(t, ok)
+
+	cache.put([]byte("gamma"), gamma)
+	require.Equal(t, 2, cache.len())
+
+	_, ok = cache.get([]byte("beta"))
+	require.False(t, ok)
+
+	module, ok := cache.get([]byte("alpha"))
+	require.True(t, ok)
+	require.True(t, alpha == module)
+
+	module, ok = cache.get([]byte("gamma"))
+	require.True(t, ok)
+	require.True(t, gamma == module)
+}
+
+func TestModuleCache_Disabled(t *testing.T) {
+	cache := newModuleCache(0)
+
+	cache.put([]byte("alpha"), &wasmer.Module{})
+	require.Equal(t, 0, cache.len())
+
+	_, ok := cache.get([]byte("alpha"))
+	require.False(t, ok)
+}
+
+func TestModuleCache_Clear(t *testing.T) {
+	cache := newModuleCache(2)
+
+	cache.put([]byte("alpha"), &wasmer.Module{})
+	cache.put([]byte("beta"), &wasmer.Module{})
+	cache.clear()
+	require.Equal(t, 0, cache.len())
+
+	_, ok := cache.get([]byte("alpha"))
+	require.False(t, ok)
+}
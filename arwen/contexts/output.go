@@ -11,16 +11,27 @@ import (
 var _ arwen.OutputContext = (*outputContext)(nil)
 
 type outputContext struct {
-	host        arwen.VMHost
-	outputState *vmcommon.VMOutput
-	stateStack  []*vmcommon.VMOutput
+	host           arwen.VMHost
+	outputState    *vmcommon.VMOutput
+	stateStack     []*vmcommon.VMOutput
+	logLimits      arwen.LogLimits
+	logsUsage      logsUsage
+	logsUsageStack []logsUsage
+}
+
+// logsUsage accumulates the logs written during the whole transaction,
+// including the ones written by nested calls, to enforce its limits
+type logsUsage struct {
+	numTopics int
+	size      int
 }
 
 // NewOutputContext creates a new outputContext
 func NewOutputContext(host arwen.VMHost) (*outputContext, error) {
 	context := &outputContext{
-		host:       host,
-		stateStack: make([]*vmcommon.VMOutput, 0),
+		host:           host,
+		stateStack:     make([]*vmcommon.VMOutput, 0),
+		logsUsageStack: make([]logsUsage, 0),
 	}
 
 	context.InitState()
@@ -30,6 +41,7 @@ func NewOutputContext(host arwen.VMHost) (*outputContext, error) {
 
 func (context *outputContext) InitState() {
 	context.outputState = newVMOutput()
+	context.logsUsage = logsUsage{}
 }
 
 func newVMOutput() *vmcommon.VMOutput {
@@ -60,6 +72,7 @@ func (context *outputContext) PushState() {
 	newState := newVMOutput()
 	mergeVMOutputs(newState, context.outputState)
 	context.stateStack = append(context.stateStack, newState)
+	context.logsUsageStack = append(context.logsUsageStack, context.logsUsage)
 }
 
 func (context *outputContext) PopSetActiveState() {
@@ -68,6 +81,7 @@ func (context *outputContext) PopSetActiveState() {
 	context.stateStack = context.stateStack[:stateStackLen-1]
 
 	context.outputState = prevState
+	context.popLogsUsage(true)
 }
 
 func (context *outputContext) PopMergeActiveState() {
@@ -84,15 +98,28 @@ func (context *outputContext) PopMergeActiveState() {
 	mergeVMOutputs(prevState, context.outputState)
 	context.outputState = newVMOutput()
 	mergeVMOutputs(context.outputState, prevState)
+	context.popLogsUsage(false)
 }
 
 func (context *outputContext) PopDiscard() {
 	stateStackLen := len(context.stateStack)
 	context.stateStack = context.stateStack[:stateStackLen-1]
+	context.popLogsUsage(false)
 }
 
 func (context *outputContext) ClearStateStack() {
 	context.stateStack = make([]*vmcommon.VMOutput, 0)
+	context.logsUsageStack = make([]logsUsage, 0)
+}
+
+// popLogsUsage pops the logs usage saved by PushState(), restoring it only if
+// the logs written since then are discarded
+func (context *outputContext) popLogsUsage(restore bool) {
+	stackLen := len(context.logsUsageStack)
+	if restore {
+		context.logsUsage = context.logsUsageStack[stackLen-1]
+	}
+	context.logsUsageStack = context.logsUsageStack[:stackLen-1]
 }
 
 // CensorVMOutput will cause the next executed SC to appear isolated, as if
@@ -159,10 +186,43 @@ func (context *outputContext) Finish(data []byte) {
 	context.outputState.ReturnData = append(context.outputState.ReturnData, data)
 }
 
-func (context *outputContext) WriteLog(address []byte, topics [][]byte, data []byte) {
+// SetLogLimits sets the limits enforced by WriteLog
+func (context *outputContext) SetLogLimits(limits arwen.LogLimits) {
+	context.logLimits = limits
+}
+
+// LogLimits returns the limits enforced by WriteLog
+func (context *outputContext) LogLimits() arwen.LogLimits {
+	return context.logLimits
+}
+
+// WriteLog appends a log entry, the first topic being its identifier; it fails
+// if the entry, or all the entries of the transaction, exceed the log limits
+func (context *outputContext) WriteLog(address []byte, topics [][]byte, data []byte) error {
 	if context.host.Runtime().ReadOnly() {
-		return
+		return nil
+	}
+
+	if context.logLimits.TooManyTopics(len(topics)) {
+		return arwen.ErrTooManyLogTopics
+	}
+
+	size := len(data)
+	for _, topic := range topics {
+		size += len(topic)
+	}
+	if context.logLimits.TooLarge(size) {
+		return arwen.ErrLogTooLarge
+	}
+
+	usage := logsUsage{
+		numTopics: context.logsUsage.numTopics + len(topics),
+		size:      context.logsUsage.size + size,
+	}
+	if context.logLimits.TransactionLimitsExceeded(usage.numTopics, usage.size) {
+		return arwen.ErrTransactionLogsLimitExceeded
 	}
+	context.logsUsage = usage
 
 	newLogEntry := &vmcommon.LogEntry{
 		Address: address,
@@ -171,13 +231,14 @@ func (context *outputContext) WriteLog(address []byte, topics [][]byte, data []b
 
 	if len(topics) == 0 {
 		context.outputState.Logs = append(context.outputState.Logs, newLogEntry)
-		return
+		return nil
 	}
 
 	newLogEntry.Identifier = topics[0]
 	newLogEntry.Topics = topics[1:]
 
 	context.outputState.Logs = append(context.outputState.Logs, newLogEntry)
+	return nil
 }
 
 // Transfer handles any necessary value transfer required and takes
@@ -200,6 +261,18 @@ func (context *outputContext) Transfer(destination []byte, sender []byte, gasLim
 	destAcc.Data = append(destAcc.Data, input...)
 	destAcc.GasLimit = gasLimit
 
+	tracer := context.host.Tracer()
+	if tracer != nil {
+		tracer.Trace(&arwen.TraceEvent{
+			Type:        arwen.TraceEventTransfer,
+			Address:     sender,
+			Destination: destination,
+			CallValue:   value,
+			GasProvided: gasLimit,
+			Data:        input,
+		})
+	}
+
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package contexts
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -377,6 +378,22 @@ func TestOutputContext_VMOutputError(t *testing.T) {
 	require.Equal(t, expected, vmOutput)
 }
 
+func TestOutputContext_CreateVMOutputInCaseOfWrappedError(t *testing.T) {
+	t.Parallel()
+
+	host := &mock.VmHostMock{}
+	host.MeteringContext = &mock.MeteringContextMock{}
+	outputContext, _ := NewOutputContext(host)
+
+	err := fmt.Errorf("%w: %s", arwen.ErrContractInvalid, arwen.ErrFunctionNonvoidSignature)
+	vmOutput := outputContext.CreateVMOutputInCaseOfError(err)
+	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
+	require.Equal(t, err.Error(), vmOutput.ReturnMessage)
+
+	vmOutput = outputContext.CreateVMOutputInCaseOfError(arwen.ErrFunctionNonvoidSignature)
+	require.Equal(t, vmcommon.FunctionWrongSignature, vmOutput.ReturnCode)
+}
+
 func TestOutputContext_Transfer(t *testing.T) {
 	t.Parallel()
 
@@ -472,7 +489,8 @@ func TestOutputContext_WriteLog(t *testing.T) {
 	data := []byte("data")
 	topics := make([][]byte, 0)
 
-	outputContext.WriteLog(address, topics, data)
+	err := outputContext.WriteLog(address, topics, data)
+	require.Nil(t, err)
 	require.Equal(t, len(outputContext.outputState.Logs), 1)
 	require.Equal(t, outputContext.outputState.Logs[0].Address, address)
 	require.Equal(t, outputContext.outputState.Logs[0].Data, data)
@@ -482,13 +500,163 @@ func TestOutputContext_WriteLog(t *testing.T) {
 	identifier := []byte("identifier")
 	topic := []byte("topic")
 	topics = append(topics, identifier)
-	outputContext.WriteLog(address, topics, data)
+	err = outputContext.WriteLog(address, topics, data)
+	require.Nil(t, err)
 
 	require.Equal(t, outputContext.outputState.Logs[1].Identifier, identifier)
 	require.Empty(t, outputContext.outputState.Logs[1].Topics)
 
 	topics = append(topics, topic)
-	outputContext.WriteLog(address, topics, data)
+	err = outputContext.WriteLog(address, topics, data)
+	require.Nil(t, err)
 
 	require.Equal(t, outputContext.outputState.Logs[2].Topics, [][]byte{topic})
 }
+
+func TestOutputContext_WriteLog_Limits(t *testing.T) {
+	t.Parallel()
+
+	host := &mock.VmHostMock{
+		RuntimeContext: &mock.RuntimeContextMock{},
+	}
+	outputContext, _ := NewOutputContext(host)
+	limits := arwen.DefaultLogLimits
+	outputContext.SetLogLimits(limits)
+
+	address := []byte("address")
+	topics := make([][]byte, limits.MaxTopics+1)
+
+	err := outputContext.WriteLog(address, topics, nil)
+	require.Equal(t, arwen.ErrTooManyLogTopics, err)
+
+	err = outputContext.WriteLog(address, topics[:limits.MaxTopics], nil)
+	require.Nil(t, err)
+
+	// The topics count towards the size of the log.
+	topics = [][]byte{[]byte("identifier")}
+	err = outputContext.WriteLog(address, topics, make([]byte, limits.MaxSize-len(topics[0])+1))
+	require.Equal(t, arwen.ErrLogTooLarge, err)
+
+	err = outputContext.WriteLog(address, topics, make([]byte, limits.MaxSize-len(topics[0])))
+	require.Nil(t, err)
+	require.Equal(t, 2, len(outputContext.outputState.Logs))
+}
+
+func TestOutputContext_WriteLog_TransactionLimits(t *testing.T) {
+	t.Parallel()
+
+	host := &mock.VmHostMock{
+		RuntimeContext: &mock.RuntimeContextMock{},
+	}
+	outputContext, _ := NewOutputContext(host)
+	limits := arwen.DefaultLogLimits
+	outputContext.SetLogLimits(limits)
+
+	address := []byte("address")
+	topics := make([][]byte, limits.MaxTopics)
+	for i := 0; i < limits.MaxTopicsPerTransaction/limits.MaxTopics; i++ {
+		err := outputContext.WriteLog(address, topics, nil)
+		require.Nil(t, err)
+	}
+
+	err := outputContext.WriteLog(address, topics[:1], nil)
+	require.Equal(t, arwen.ErrTransactionLogsLimitExceeded, err)
+
+	// Logs without topics are still limited by their size.
+	data := make([]byte, limits.MaxSize)
+	for i := 0; i < limits.MaxSizePerTransaction/limits.MaxSize; i++ {
+		err = outputContext.WriteLog(address, nil, data)
+		require.Nil(t, err)
+	}
+
+	err = outputContext.WriteLog(address, nil, data[:1])
+	require.Equal(t, arwen.ErrTransactionLogsLimitExceeded, err)
+
+	// A new transaction starts from a clean state.
+	outputContext.InitState()
+	err = outputContext.WriteLog(address, topics, data)
+	require.Nil(t, err)
+}
+
+func TestOutputContext_WriteLog_NestedCalls(t *testing.T) {
+	t.Parallel()
+
+	host := &mock.VmHostMock{
+		RuntimeContext: &mock.RuntimeContextMock{},
+	}
+	outputContext, _ := NewOutputContext(host)
+	limits := arwen.DefaultLogLimits
+	outputContext.SetLogLimits(limits)
+
+	address := []byte("address")
+	topics := make([][]byte, limits.MaxTopics)
+	numLogs := limits.MaxTopicsPerTransaction / limits.MaxTopics
+
+	err := outputContext.WriteLog(address, topics, []byte("parent"))
+	require.Nil(t, err)
+
+	// A failed nested call loses its logs, and their usage of the limits.
+	outputContext.PushState()
+	outputContext.CensorVMOutput()
+	for i := 1; i < numLogs; i++ {
+		err = outputContext.WriteLog(address, topics, []byte("failed child"))
+		require.Nil(t, err)
+	}
+	outputContext.PopSetActiveState()
+
+	require.Equal(t, 1, len(outputContext.outputState.Logs))
+	require.Equal(t, []byte("parent"), outputContext.outputState.Logs[0].Data)
+
+	// A successful nested call keeps both.
+	outputContext.PushState()
+	outputContext.CensorVMOutput()
+	for i := 1; i < numLogs; i++ {
+		err = outputContext.WriteLog(address, topics, []byte("child"))
+		require.Nil(t, err)
+	}
+	outputContext.PopMergeActiveState()
+
+	require.Equal(t, numLogs, len(outputContext.outputState.Logs))
+	err = outputContext.WriteLog(address, topics, []byte("parent"))
+	require.Equal(t, arwen.ErrTransactionLogsLimitExceeded, err)
+}
+
+func TestOutputContext_WriteLog_NoLimits(t *testing.T) {
+	t.Parallel()
+
+	host := &mock.VmHostMock{
+		RuntimeContext: &mock.RuntimeContextMock{},
+	}
+	outputContext, _ := NewOutputContext(host)
+	require.Equal(t, arwen.LogLimits{}, outputContext.LogLimits())
+
+	address := []byte("address")
+	limits := arwen.DefaultLogLimits
+	topics := make([][]byte, limits.MaxTopicsPerTransaction+1)
+	err := outputContext.WriteLog(address, topics, make([]byte, limits.MaxSizePerTransaction+1))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(outputContext.outputState.Logs))
+
+	// Only the limits which are set are enforced.
+	outputContext.SetLogLimits(arwen.LogLimits{MaxTopics: 1})
+	err = outputContext.WriteLog(address, topics[:2], nil)
+	require.Equal(t, arwen.ErrTooManyLogTopics, err)
+
+	err = outputContext.WriteLog(address, topics[:1], make([]byte, limits.MaxSizePerTransaction+1))
+	require.Nil(t, err)
+}
+
+func TestOutputContext_WriteLog_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	host := &mock.VmHostMock{
+		RuntimeContext: &mock.RuntimeContextMock{ReadOnlyFlag: true},
+	}
+	outputContext, _ := NewOutputContext(host)
+
+	outputContext.SetLogLimits(arwen.DefaultLogLimits)
+
+	err := outputContext.WriteLog([]byte("address"), make([][]byte, arwen.DefaultLogLimits.MaxTopics+1), nil)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(outputContext.outputState.Logs))
+}
@@ -1,7 +1,9 @@
 package contexts
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"unsafe"
 
 	vmcommon "github.com/kalyan3104/dme-vm-common"
@@ -11,6 +13,10 @@ import (
 
 var _ arwen.RuntimeContext = (*runtimeContext)(nil)
 
+// cachedModuleGasLimit is the gas limit the metering of cached modules is
+// compiled with, which bounds the gas limit of their instances
+const cachedModuleGasLimit = math.MaxUint64 / 2
+
 type runtimeContext struct {
 	host     arwen.VMHost
 	instance *wasmer.Instance
@@ -20,6 +26,7 @@ type runtimeContext struct {
 	vmInput                     *vmcommon.VMInput
 	scAddress                   []byte
 	callFunction                string
+	tokenTransfers              []*arwen.TokenTransfer
 	vmType                      []byte
 	readOnly                    bool
 
@@ -27,6 +34,9 @@ type runtimeContext struct {
 	instanceStack []*wasmer.Instance
 
 	maxWasmerInstances uint64
+	maxMemoryPages     uint32
+	moduleCache        *moduleCache
+	importObject       *wasmer.ImportObject
 
 	asyncCallInfo    *arwen.AsyncCallInfo
 	asyncContextInfo *arwen.AsyncContextInfo
@@ -39,13 +49,18 @@ func NewRuntimeContext(host arwen.VMHost, vmType []byte) (*runtimeContext, error
 	scAPINames := host.GetAPIMethods().Names()
 	protocolBuiltinFunctions := host.GetProtocolBuiltinFunctions()
 
+	validator := NewWASMValidator(scAPINames, protocolBuiltinFunctions)
+	validator.allowedImports = host.GetAPIMethods().NamespaceNames(contractImportsNamespace)
+
 	context := &runtimeContext{
 		host:                        host,
 		instanceContextDataPointers: make([]*int, 0),
 		vmType:                      vmType,
 		stateStack:                  make([]*runtimeContext, 0),
 		instanceStack:               make([]*wasmer.Instance, 0),
-		validator:                   NewWASMValidator(scAPINames, protocolBuiltinFunctions),
+		moduleCache:                 newModuleCache(0),
+		importObject:                wasmer.NewImportObject(host.GetAPIMethods()),
+		validator:                   validator,
 	}
 
 	context.InitState()
@@ -58,6 +73,7 @@ func (context *runtimeContext) InitState() {
 	context.vmInput = &vmcommon.VMInput{}
 	context.scAddress = make([]byte, 0)
 	context.callFunction = ""
+	context.tokenTransfers = nil
 	context.readOnly = false
 	context.asyncCallInfo = nil
 	context.asyncContextInfo = &arwen.AsyncContextInfo{
@@ -70,6 +86,12 @@ func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uin
 		context.instance = nil
 		return arwen.ErrMaxInstancesReached
 	}
+	contract, err := limitMemoryPages(contract, context.maxMemoryPages)
+	if err != nil {
+		context.instance = nil
+		return err
+	}
+
 	options := wasmer.CompilationOptions{
 		GasLimit:           gasLimit,
 		OpcodeTrace:        false,
@@ -87,14 +109,85 @@ func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uin
 	return nil
 }
 
+// StartCachedWasmerInstance instantiates the contract from its compiled
+// module, compiling the module only if it is not already in the module cache;
+// an empty code hash bypasses the cache
+func (context *runtimeContext) StartCachedWasmerInstance(contract []byte, codeHash []byte, gasLimit uint64) error {
+	if len(codeHash) == 0 || context.moduleCache.maxSize <= 0 || gasLimit > cachedModuleGasLimit {
+		return context.StartWasmerInstance(contract, gasLimit)
+	}
+
+	if context.RunningInstancesCount() >= context.maxWasmerInstances {
+		context.instance = nil
+		return arwen.ErrMaxInstancesReached
+	}
+
+	module, ok := context.moduleCache.get(codeHash)
+	if !ok {
+		limitedContract, err := limitMemoryPages(contract, context.maxMemoryPages)
+		if err != nil {
+			context.instance = nil
+			return err
+		}
+
+		module, err = wasmer.CompileModule(limitedContract, cachedModuleGasLimit)
+		if err != nil {
+			context.instance = nil
+			return err
+		}
+		context.moduleCache.put(codeHash, module)
+	}
+
+	newInstance, err := wasmer.NewInstanceFromModule(module, context.importObject, gasLimit)
+	if err != nil {
+		context.instance = nil
+		return err
+	}
+
+	context.instance = newInstance
+	context.SetRuntimeBreakpointValue(arwen.BreakpointNone)
+	return nil
+}
+
 func (context *runtimeContext) SetMaxInstanceCount(maxInstances uint64) {
 	context.maxWasmerInstances = maxInstances
 }
 
+// SetMaxMemoryPages sets how many pages of memory an instance may use, both
+// initially and after growing its memory, destroying the modules currently
+// cached, which were compiled with the previous limit; zero disables the limit
+func (context *runtimeContext) SetMaxMemoryPages(maxPages uint32) {
+	context.maxMemoryPages = maxPages
+	context.moduleCache.clear()
+}
+
+// GetMaxMemoryPages returns how many pages of memory an instance may use;
+// zero means the memory is not limited
+func (context *runtimeContext) GetMaxMemoryPages() uint32 {
+	return context.maxMemoryPages
+}
+
+// GetMemoryPages returns how many pages of memory the current instance uses;
+// since memory never shrinks, this is also the high-water mark of the instance
+func (context *runtimeContext) GetMemoryPages() uint32 {
+	if context.instance == nil || context.instance.Memory == nil {
+		return 0
+	}
+	return context.instance.Memory.Length() / wasmPageSize
+}
+
+// SetModuleCacheSize sets how many compiled modules are kept in the module
+// cache, destroying the modules currently cached; zero disables the cache
+func (context *runtimeContext) SetModuleCacheSize(size int) {
+	context.moduleCache.clear()
+	context.moduleCache = newModuleCache(size)
+}
+
 func (context *runtimeContext) InitStateFromContractCallInput(input *vmcommon.ContractCallInput) {
 	context.vmInput = &input.VMInput
 	context.scAddress = input.RecipientAddr
 	context.callFunction = input.Function
+	context.tokenTransfers = nil
 	// Reset async map for initial state
 	context.asyncContextInfo = &arwen.AsyncContextInfo{
 		CallerAddr:      input.CallerAddr,
@@ -111,6 +204,7 @@ func (context *runtimeContext) PushState() {
 		vmInput:          context.vmInput,
 		scAddress:        context.scAddress,
 		callFunction:     context.callFunction,
+		tokenTransfers:   context.tokenTransfers,
 		readOnly:         context.readOnly,
 		asyncCallInfo:    context.asyncCallInfo,
 		asyncContextInfo: context.asyncContextInfo,
@@ -127,6 +221,7 @@ func (context *runtimeContext) PopSetActiveState() {
 	context.vmInput = prevState.vmInput
 	context.scAddress = prevState.scAddress
 	context.callFunction = prevState.callFunction
+	context.tokenTransfers = prevState.tokenTransfers
 	context.readOnly = prevState.readOnly
 	context.asyncCallInfo = prevState.asyncCallInfo
 	context.asyncContextInfo = prevState.asyncContextInfo
@@ -137,6 +232,22 @@ func (context *runtimeContext) PopDiscard() {
 	context.stateStack = context.stateStack[:stateStackLen-1]
 }
 
+// CallDepth returns how many executions the current one is nested under
+func (context *runtimeContext) CallDepth() uint64 {
+	return uint64(len(context.stateStack))
+}
+
+// IsContractOnTheStack returns whether the contract is being executed by one
+// of the executions the current one is nested under
+func (context *runtimeContext) IsContractOnTheStack(address []byte) bool {
+	for _, state := range context.stateStack {
+		if bytes.Equal(state.scAddress, address) {
+			return true
+		}
+	}
+	return false
+}
+
 func (context *runtimeContext) ClearStateStack() {
 	context.stateStack = make([]*runtimeContext, 0)
 }
@@ -193,6 +304,16 @@ func (context *runtimeContext) GetOriginalTxHash() []byte {
 	return context.vmInput.OriginalTxHash
 }
 
+// TokenTransfers returns the tokens received by the current call
+func (context *runtimeContext) TokenTransfers() []*arwen.TokenTransfer {
+	return context.tokenTransfers
+}
+
+// SetTokenTransfers sets the tokens received by the current call
+func (context *runtimeContext) SetTokenTransfers(transfers []*arwen.TokenTransfer) {
+	context.tokenTransfers = transfers
+}
+
 func (context *runtimeContext) Function() string {
 	return context.callFunction
 }
@@ -243,18 +364,27 @@ func (context *runtimeContext) GetRuntimeBreakpointValue() arwen.BreakpointValue
 	return arwen.BreakpointValue(context.instance.GetBreakpointValue())
 }
 
-func (context *runtimeContext) VerifyContractCode() error {
-	err := context.validator.verifyMemoryDeclaration(context.instance)
-	if err != nil {
-		return err
-	}
+// VerifyContractCode checks the bytecode of a contract before it is
+// instantiated for deployment or upgrade
+func (context *runtimeContext) VerifyContractCode(code []byte) error {
+	_, err := context.validator.verifyContractCode(code)
+	return err
+}
 
-	err = context.validator.verifyFunctions(context.instance)
+// VerifyContractEndpoints checks the bytecode of a contract like
+// VerifyContractCode, and also that it exports exactly the given endpoints
+func (context *runtimeContext) VerifyContractEndpoints(code []byte, endpoints []string) error {
+	module, err := context.validator.verifyContractCode(code)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return context.validator.verifyEndpoints(module, endpoints)
+}
+
+// SetValidationPolicy sets the limits enforced by VerifyContractCode
+func (context *runtimeContext) SetValidationPolicy(policy arwen.WASMValidationPolicy) {
+	context.validator.policy = policy
 }
 
 func (context *runtimeContext) Kalyan3104APIErrorShouldFailExecution() bool {
@@ -265,6 +395,14 @@ func (context *runtimeContext) BigIntAPIErrorShouldFailExecution() bool {
 	return true
 }
 
+func (context *runtimeContext) BigFloatAPIErrorShouldFailExecution() bool {
+	return true
+}
+
+func (context *runtimeContext) ManagedBufferAPIErrorShouldFailExecution() bool {
+	return true
+}
+
 func (context *runtimeContext) CryptoAPIErrorShouldFailExecution() bool {
 	return true
 }
@@ -342,17 +480,7 @@ func (context *runtimeContext) SetAsyncCallInfo(asyncCallInfo *arwen.AsyncCallIn
 }
 
 func (context *runtimeContext) AddAsyncContextCall(contextIdentifier []byte, asyncCall *arwen.AsyncGeneratedCall) error {
-	_, ok := context.asyncContextInfo.AsyncContextMap[string(contextIdentifier)]
-	currentContextMap := context.asyncContextInfo.AsyncContextMap
-	if !ok {
-		currentContextMap[string(contextIdentifier)] = &arwen.AsyncContext{
-			AsyncCalls: make([]*arwen.AsyncGeneratedCall, 0),
-		}
-	}
-
-	currentContextMap[string(contextIdentifier)].AsyncCalls =
-		append(currentContextMap[string(contextIdentifier)].AsyncCalls, asyncCall)
-
+	context.asyncContextInfo.AddAsyncCall(string(contextIdentifier), "", asyncCall)
 	return nil
 }
 
@@ -421,6 +549,11 @@ func (context *runtimeContext) MemStore(offset int32, data []byte) error {
 		return arwen.ErrBadLowerBounds
 	}
 	if isNewPageNecessary {
+		isMemoryLimitReached := context.maxMemoryPages > 0 && memoryLength/wasmPageSize >= context.maxMemoryPages
+		if isMemoryLimitReached {
+			return arwen.ErrMaxMemoryPagesReached
+		}
+
 		err := memory.Grow(1)
 		if err != nil {
 			return err
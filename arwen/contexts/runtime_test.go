@@ -2,6 +2,7 @@ package contexts
 
 import (
 	"errors"
+	"math/big"
 	"testing"
 
 	vmcommon "github.com/kalyan3104/dme-vm-common"
@@ -238,6 +239,62 @@ func TestRuntimeContext_PushPopState(t *testing.T) {
 	require.Equal(t, 0, len(runtimeContext.stateStack))
 }
 
+func TestRuntimeContext_CallStack(t *testing.T) {
+	imports := MakeAPIImports()
+	host := &mock.VmHostMock{}
+	host.SCAPIMethods = imports
+
+	vmType := []byte("type")
+	runtimeContext, _ := NewRuntimeContext(host, vmType)
+
+	parentAddress := []byte("parent")
+	childAddress := []byte("child")
+
+	runtimeContext.SetSCAddress(parentAddress)
+	require.Equal(t, uint64(0), runtimeContext.CallDepth())
+	require.False(t, runtimeContext.IsContractOnTheStack(parentAddress))
+
+	runtimeContext.PushState()
+	runtimeContext.SetSCAddress(childAddress)
+	require.Equal(t, uint64(1), runtimeContext.CallDepth())
+	require.True(t, runtimeContext.IsContractOnTheStack(parentAddress))
+	require.False(t, runtimeContext.IsContractOnTheStack(childAddress))
+
+	runtimeContext.PushState()
+	runtimeContext.SetSCAddress(parentAddress)
+	require.Equal(t, uint64(2), runtimeContext.CallDepth())
+	require.True(t, runtimeContext.IsContractOnTheStack(childAddress))
+
+	runtimeContext.PopSetActiveState()
+	runtimeContext.PopSetActiveState()
+	require.Equal(t, uint64(0), runtimeContext.CallDepth())
+	require.False(t, runtimeContext.IsContractOnTheStack(parentAddress))
+}
+
+func TestRuntimeContext_TokenTransfers(t *testing.T) {
+	imports := MakeAPIImports()
+	host := &mock.VmHostMock{}
+	host.SCAPIMethods = imports
+
+	runtimeContext, _ := NewRuntimeContext(host, []byte("type"))
+	require.Len(t, runtimeContext.TokenTransfers(), 0)
+
+	transfers := []*arwen.TokenTransfer{{TokenIdentifier: []byte("TOKEN-1"), Value: big.NewInt(42)}}
+	runtimeContext.SetTokenTransfers(transfers)
+	require.Equal(t, transfers, runtimeContext.TokenTransfers())
+
+	// A nested call does not receive the tokens of its caller.
+	runtimeContext.PushState()
+	runtimeContext.InitStateFromContractCallInput(&vmcommon.ContractCallInput{})
+	require.Len(t, runtimeContext.TokenTransfers(), 0)
+
+	runtimeContext.PopSetActiveState()
+	require.Equal(t, transfers, runtimeContext.TokenTransfers())
+
+	runtimeContext.InitState()
+	require.Len(t, runtimeContext.TokenTransfers(), 0)
+}
+
 func TestRuntimeContext_Instance(t *testing.T) {
 	imports := InitializeWasmer()
 
@@ -514,3 +571,82 @@ func TestRuntimeContext_MemStoreCases(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, []byte("this is something"), memContents)
 }
+
+func TestRuntimeContext_MemStoreMaxMemoryPages(t *testing.T) {
+	imports := InitializeWasmer()
+
+	host := &mock.VmHostMock{}
+	host.SCAPIMethods = imports
+
+	vmType := []byte("type")
+	runtimeContext, _ := NewRuntimeContext(host, vmType)
+	runtimeContext.SetMaxInstanceCount(1)
+	runtimeContext.SetMaxMemoryPages(3)
+
+	gasLimit := uint64(100000000)
+	path := "./../../test/contracts/counter/output/counter.wasm"
+	contractCode := arwen.GetSCCode(path)
+	err := runtimeContext.StartWasmerInstance(contractCode, gasLimit)
+	require.Nil(t, err)
+
+	memory := runtimeContext.instance.Memory
+	runtimeContext.instanceContext = wasmer.NewInstanceContext(nil, *memory)
+	require.Equal(t, uint32(2), runtimeContext.GetMemoryPages())
+
+	memContents := []byte("test data")
+	err = runtimeContext.MemStore(int32(memory.Length()-4), memContents)
+	require.Nil(t, err)
+	require.Equal(t, uint32(3), runtimeContext.GetMemoryPages())
+
+	err = runtimeContext.MemStore(int32(memory.Length()-4), memContents)
+	require.Equal(t, arwen.ErrMaxMemoryPagesReached, err)
+	require.Equal(t, uint32(3), runtimeContext.GetMemoryPages())
+
+	runtimeContext.CleanInstance()
+	runtimeContext.SetMaxMemoryPages(1)
+	err = runtimeContext.StartWasmerInstance(contractCode, gasLimit)
+	require.True(t, errors.Is(err, arwen.ErrMemoryTooLarge))
+}
+
+func TestRuntimeContext_LimitMemoryPages(t *testing.T) {
+	memorySection := func(limits ...byte) []byte {
+		return wasmTestSection(wasmSectionMemory, limits)
+	}
+	module := func(memory []byte) []byte {
+		code := append([]byte{}, wasmMagicAndVersion...)
+		code = append(code, wasmTestSection(wasmSectionType, []byte{wasmFunctionType, 0, 0})...)
+		code = append(code, memory...)
+		return append(code, wasmTestSection(wasmSectionCustom, []byte{1, 'x'})...)
+	}
+
+	code := module(memorySection(0, 2))
+	limitedCode, err := limitMemoryPages(code, 0)
+	require.Nil(t, err)
+	require.Equal(t, code, limitedCode)
+
+	limitedCode, err = limitMemoryPages(code, 200)
+	require.Nil(t, err)
+	require.Equal(t, module(memorySection(1, 2, 0xC8, 0x01)), limitedCode)
+
+	parsed, err := parseWASMModule(limitedCode)
+	require.Nil(t, err)
+	require.Equal(t, []wasmLimits{{min: 2, max: 200, hasMax: true}}, parsed.memories)
+
+	limitedCode, err = limitMemoryPages(module(memorySection(1, 2, 5)), 10)
+	require.Nil(t, err)
+	require.Equal(t, module(memorySection(1, 2, 5)), limitedCode)
+
+	limitedCode, err = limitMemoryPages(module(memorySection(1, 2, 50)), 10)
+	require.Nil(t, err)
+	require.Equal(t, module(memorySection(1, 2, 10)), limitedCode)
+
+	_, err = limitMemoryPages(module(memorySection(0, 11)), 10)
+	require.True(t, errors.Is(err, arwen.ErrMemoryTooLarge))
+
+	_, err = limitMemoryPages([]byte("not WASM"), 10)
+	require.True(t, errors.Is(err, arwen.ErrMalformedContractCode))
+
+	code = arwen.GetSCCode("./../../test/contracts/memory-too-large/output/memory-too-large.wasm")
+	_, err = limitMemoryPages(code, 32)
+	require.True(t, errors.Is(err, arwen.ErrMemoryTooLarge))
+}
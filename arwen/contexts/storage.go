@@ -3,6 +3,7 @@ package contexts
 import (
 	"bytes"
 	"errors"
+	"sort"
 
 	vmcommon "github.com/kalyan3104/dme-vm-common"
 	"github.com/kalyan3104/dme-vm-go/arwen"
@@ -16,6 +17,20 @@ type storageContext struct {
 	address                      []byte
 	stateStack                   [][]byte
 	kalyan3104ProtectedKeyPrefix []byte
+
+	// the sorted iterable keys of each account in the state of the
+	// blockchain, loaded at most once per transaction
+	stateKeys  map[string][][]byte
+	keysCursor *storageKeysCursor
+}
+
+// storageKeysCursor holds the last page of keys returned by
+// GetStorageKeysWithPrefix(), from which the following page resumes
+type storageKeysCursor struct {
+	address    []byte
+	prefix     []byte
+	startIndex int
+	keys       [][]byte
 }
 
 // NewStorageContext creates a new storageContext
@@ -34,10 +49,14 @@ func NewStorageContext(
 		kalyan3104ProtectedKeyPrefix: kalyan3104ProtectedKeyPrefix,
 	}
 
+	context.InitState()
+
 	return context, nil
 }
 
 func (context *storageContext) InitState() {
+	context.stateKeys = make(map[string][][]byte)
+	context.keysCursor = nil
 }
 
 func (context *storageContext) PushState() {
@@ -71,6 +90,22 @@ func (context *storageContext) GetStorageUpdates(address []byte) map[string]*vmc
 }
 
 func (context *storageContext) GetStorage(key []byte) []byte {
+	value := context.getStorage(key)
+
+	tracer := context.host.Tracer()
+	if tracer != nil {
+		tracer.Trace(&arwen.TraceEvent{
+			Type:    arwen.TraceEventStorageLoad,
+			Address: context.address,
+			Key:     key,
+			Value:   value,
+		})
+	}
+
+	return value
+}
+
+func (context *storageContext) getStorage(key []byte) []byte {
 	storageUpdates := context.GetStorageUpdates(context.address)
 	if storageUpdate, ok := storageUpdates[string(key)]; ok {
 		return storageUpdate.Data
@@ -87,6 +122,286 @@ func (context *storageContext) GetStorage(key []byte) []byte {
 	return value
 }
 
+// GetStorageFromAddress reads a key from the storage of any account in the
+// same shard, taking into account its pending storage updates; protected keys
+// of another account may be read unless its owner made it not readable
+func (context *storageContext) GetStorageFromAddress(address []byte, key []byte) ([]byte, error) {
+	if bytes.Equal(address, context.address) {
+		return context.GetStorage(key), nil
+	}
+
+	blockchain := context.host.Blockchain()
+	if blockchain.GetShardOfAddress(address) != blockchain.GetShardOfAddress(context.address) {
+		return nil, arwen.ErrStorageReadFromOtherShard
+	}
+
+	if context.isKalyan3104ReservedKey(key) {
+		metadata, err := blockchain.GetCodeMetadata(address)
+		if err != nil {
+			return nil, err
+		}
+		if metadata.NotReadable {
+			return nil, arwen.ErrStorageKeyNotReadable
+		}
+	}
+
+	value, err := context.getStorageFromAddress(address, key)
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := context.host.Tracer()
+	if tracer != nil {
+		tracer.Trace(&arwen.TraceEvent{
+			Type:    arwen.TraceEventStorageLoad,
+			Address: address,
+			Key:     key,
+			Value:   value,
+		})
+	}
+
+	return value, nil
+}
+
+func (context *storageContext) getStorageFromAddress(address []byte, key []byte) ([]byte, error) {
+	storageUpdates := context.GetStorageUpdates(address)
+	if storageUpdate, ok := storageUpdates[string(key)]; ok {
+		return storageUpdate.Data, nil
+	}
+
+	return context.blockChainHook.GetStorageData(address, key)
+}
+
+// CountStorageKeysWithPrefix returns how many keys of the current account
+// start with the given prefix and hold a non-empty value; pending storage
+// updates take precedence over the state held by the blockchain.
+func (context *storageContext) CountStorageKeysWithPrefix(prefix []byte) (int, error) {
+	stateKeys, err := context.getStateKeysWithPrefix(prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	count := len(stateKeys)
+	storageUpdates := context.GetStorageUpdates(context.address)
+	for _, key := range context.getUpdatedKeysWithPrefix(prefix) {
+		isInState := containsSortedKey(stateKeys, key)
+		isIterable := len(storageUpdates[string(key)].Data) > 0
+		if isInState && !isIterable {
+			count--
+		}
+		if !isInState && isIterable {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// GetStorageKeysWithPrefix returns at most maxKeys keys of the current account
+// which start with the given prefix and hold a non-empty value, in ascending
+// order, skipping the first startIndex of them; pending storage updates take
+// precedence over the state held by the blockchain. A page starting within the
+// previous page returned resumes after its keys instead of skipping the first
+// keys again, even if keys were updated meanwhile. Gas is used for each key
+// skipped or returned.
+func (context *storageContext) GetStorageKeysWithPrefix(prefix []byte, startIndex int, maxKeys int) ([][]byte, error) {
+	stateKeys, err := context.getStateKeysWithPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	iterator := &storageKeysIterator{
+		stateKeys:      stateKeys,
+		updatedKeys:    context.getUpdatedKeysWithPrefix(prefix),
+		storageUpdates: context.GetStorageUpdates(context.address),
+	}
+
+	numSkipped := 0
+	cursor := context.keysCursor
+	if cursor != nil && cursor.resumesAt(context.address, prefix, startIndex) {
+		iterator.seekAfter(cursor.keys[startIndex-cursor.startIndex-1])
+		numSkipped = startIndex
+	}
+
+	numScanned := uint64(0)
+	for ; numSkipped < startIndex; numSkipped++ {
+		if iterator.next() == nil {
+			break
+		}
+		numScanned++
+	}
+
+	keys := make([][]byte, 0)
+	for numSkipped == startIndex && len(keys) < maxKeys {
+		key := iterator.next()
+		if key == nil {
+			break
+		}
+		keys = append(keys, key)
+		numScanned++
+	}
+
+	metering := context.host.Metering()
+	metering.UseGas(metering.GasSchedule().Kalyan3104APICost.StorageKeyIteration * numScanned)
+
+	context.keysCursor = &storageKeysCursor{
+		address:    context.address,
+		prefix:     prefix,
+		startIndex: startIndex,
+		keys:       keys,
+	}
+
+	return keys, nil
+}
+
+// getStateKeysWithPrefix returns the sorted keys of the current account which
+// start with the given prefix and hold a non-empty value in the state of the
+// blockchain, regardless of the pending storage updates.
+func (context *storageContext) getStateKeysWithPrefix(prefix []byte) ([][]byte, error) {
+	stateKeys, err := context.getStateKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	first := sort.Search(len(stateKeys), func(i int) bool {
+		return bytes.Compare(stateKeys[i], prefix) >= 0
+	})
+	stateKeys = stateKeys[first:]
+	last := sort.Search(len(stateKeys), func(i int) bool {
+		return !bytes.HasPrefix(stateKeys[i], prefix)
+	})
+
+	return stateKeys[:last], nil
+}
+
+// getStateKeys returns the sorted keys of the current account which hold a
+// non-empty value in the state of the blockchain. They are loaded only once
+// per transaction, using gas for each key loaded and for its bytes and those
+// of its value, whether it is iterable or not; the loading stops as soon as
+// the gas left does not cover it.
+func (context *storageContext) getStateKeys() ([][]byte, error) {
+	stateKeys, ok := context.stateKeys[string(context.address)]
+	if ok {
+		return stateKeys, nil
+	}
+
+	state, err := context.blockChainHook.GetAllState(context.address)
+	if err != nil {
+		return nil, err
+	}
+
+	metering := context.host.Metering()
+	gasPerKey := metering.GasSchedule().Kalyan3104APICost.StorageKeyIteration
+	gasPerByte := metering.GasSchedule().BaseOperationCost.DataCopyPerByte
+	gasLeft := metering.GasLeft()
+	gasToUse := uint64(0)
+	stateKeys = make([][]byte, 0)
+	for key, value := range state {
+		gasToUse += gasPerKey + gasPerByte*uint64(len(key)+len(value))
+		if gasToUse > gasLeft {
+			return nil, arwen.ErrNotEnoughGas
+		}
+		if context.isIterableKey([]byte(key), value, nil) {
+			stateKeys = append(stateKeys, []byte(key))
+		}
+	}
+	metering.UseGas(gasToUse)
+
+	sortKeys(stateKeys)
+	context.stateKeys[string(context.address)] = stateKeys
+	return stateKeys, nil
+}
+
+// getUpdatedKeysWithPrefix returns the sorted keys of the current account
+// which start with the given prefix and have pending storage updates, whether
+// their values are empty or not, using gas for each of them.
+func (context *storageContext) getUpdatedKeysWithPrefix(prefix []byte) [][]byte {
+	updatedKeys := make([][]byte, 0)
+	for key := range context.GetStorageUpdates(context.address) {
+		if bytes.HasPrefix([]byte(key), prefix) && !context.isKalyan3104ReservedKey([]byte(key)) {
+			updatedKeys = append(updatedKeys, []byte(key))
+		}
+	}
+
+	metering := context.host.Metering()
+	metering.UseGas(metering.GasSchedule().Kalyan3104APICost.StorageKeyIteration * uint64(len(updatedKeys)))
+
+	sortKeys(updatedKeys)
+	return updatedKeys
+}
+
+func (cursor *storageKeysCursor) resumesAt(address []byte, prefix []byte, startIndex int) bool {
+	return bytes.Equal(cursor.address, address) &&
+		bytes.Equal(cursor.prefix, prefix) &&
+		startIndex > cursor.startIndex &&
+		startIndex <= cursor.startIndex+len(cursor.keys)
+}
+
+// storageKeysIterator walks in ascending order over the keys held in the
+// state of the blockchain merged with the keys having pending storage updates,
+// skipping the keys whose pending updates are empty
+type storageKeysIterator struct {
+	stateKeys      [][]byte
+	updatedKeys    [][]byte
+	storageUpdates map[string]*vmcommon.StorageUpdate
+}
+
+// next returns the following key, or nil if there are no more keys
+func (iterator *storageKeysIterator) next() []byte {
+	for len(iterator.updatedKeys) > 0 {
+		if len(iterator.stateKeys) > 0 && bytes.Compare(iterator.stateKeys[0], iterator.updatedKeys[0]) < 0 {
+			break
+		}
+
+		key := iterator.updatedKeys[0]
+		iterator.updatedKeys = iterator.updatedKeys[1:]
+		if len(iterator.stateKeys) > 0 && bytes.Equal(iterator.stateKeys[0], key) {
+			iterator.stateKeys = iterator.stateKeys[1:]
+		}
+		if len(iterator.storageUpdates[string(key)].Data) > 0 {
+			return key
+		}
+	}
+
+	if len(iterator.stateKeys) == 0 {
+		return nil
+	}
+
+	key := iterator.stateKeys[0]
+	iterator.stateKeys = iterator.stateKeys[1:]
+	return key
+}
+
+// seekAfter skips the keys up to and including the given key
+func (iterator *storageKeysIterator) seekAfter(key []byte) {
+	iterator.stateKeys = keysAfter(iterator.stateKeys, key)
+	iterator.updatedKeys = keysAfter(iterator.updatedKeys, key)
+}
+
+func keysAfter(sortedKeys [][]byte, key []byte) [][]byte {
+	first := sort.Search(len(sortedKeys), func(i int) bool {
+		return bytes.Compare(sortedKeys[i], key) > 0
+	})
+	return sortedKeys[first:]
+}
+
+func containsSortedKey(sortedKeys [][]byte, key []byte) bool {
+	i := sort.Search(len(sortedKeys), func(i int) bool {
+		return bytes.Compare(sortedKeys[i], key) >= 0
+	})
+	return i < len(sortedKeys) && bytes.Equal(sortedKeys[i], key)
+}
+
+func sortKeys(keys [][]byte) {
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+}
+
+func (context *storageContext) isIterableKey(key []byte, value []byte, prefix []byte) bool {
+	return len(value) > 0 && bytes.HasPrefix(key, prefix) && !context.isKalyan3104ReservedKey(key)
+}
+
 func (context *storageContext) isKalyan3104ReservedKey(key []byte) bool {
 	return bytes.HasPrefix(key, []byte(context.kalyan3104ProtectedKeyPrefix))
 }
@@ -101,6 +416,9 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (arwen.Stora
 	}
 
 	metering := context.host.Metering()
+	previousGasCategory := metering.SetGasCategory(arwen.GasCategoryStorage)
+	defer metering.SetGasCategory(previousGasCategory)
+
 	var zero []byte
 	strKey := string(key)
 	length := len(value)
@@ -108,7 +426,7 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (arwen.Stora
 	var oldValue []byte
 	storageUpdates := context.GetStorageUpdates(context.address)
 	if update, ok := storageUpdates[strKey]; !ok {
-		oldValue = context.GetStorage(key)
+		oldValue = context.getStorage(key)
 		storageUpdates[strKey] = &vmcommon.StorageUpdate{
 			Offset: key,
 			Data:   oldValue,
@@ -117,6 +435,16 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (arwen.Stora
 		oldValue = update.Data
 	}
 
+	tracer := context.host.Tracer()
+	if tracer != nil {
+		tracer.Trace(&arwen.TraceEvent{
+			Type:    arwen.TraceEventStorageStore,
+			Address: context.address,
+			Key:     key,
+			Value:   value,
+		})
+	}
+
 	lengthOldValue := len(oldValue)
 	if bytes.Equal(oldValue, value) {
 		useGas := metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(length)
@@ -2,6 +2,8 @@ package contexts
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -203,3 +205,242 @@ func TestStorageContext_SetStorage(t *testing.T) {
 	storageStatus, err = storageContext.SetStorage(key, value)
 	require.Equal(t, arwen.ErrStoreKalyan3104ReservedKey, err)
 }
+
+func TestStorageContext_GetStorageFromAddress(t *testing.T) {
+	t.Parallel()
+
+	scAddress := []byte("account")
+	readableAddress := []byte("readable")
+	nonReadableAddress := []byte("nonReadable")
+	otherShardAddress := []byte("otherShard")
+
+	mockOutput := &mock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(scAddress)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	bcHook := &mock.BlockchainHookStub{}
+	bcHook.GetStorageDataCalled = func(address []byte, key []byte) ([]byte, error) {
+		return append(append([]byte{}, address...), key...), nil
+	}
+	bcHook.GetShardOfAddressCalled = func(address []byte) uint32 {
+		if bytes.Equal(address, otherShardAddress) {
+			return 1
+		}
+		return 0
+	}
+	bcHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		metadata := &arwen.CodeMetadata{NotReadable: bytes.Equal(address, nonReadableAddress)}
+		return &mock.AccountMock{CodeMetadata: metadata.ToBytes()}, nil
+	}
+
+	host := &mock.VmHostMock{
+		OutputContext: mockOutput,
+	}
+	host.BlockchainContext, _ = NewBlockchainContext(host, bcHook)
+
+	storageContext, _ := NewStorageContext(host, bcHook, kalyan3104ReservedTestPrefix)
+	storageContext.SetAddress(scAddress)
+
+	value, err := storageContext.GetStorageFromAddress(nonReadableAddress, []byte("key"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("nonReadablekey"), value)
+
+	// Reading its own storage also returns the pending updates.
+	account.StorageUpdates["key"] = &vmcommon.StorageUpdate{Offset: []byte("key"), Data: []byte("updated")}
+	value, err = storageContext.GetStorageFromAddress(scAddress, []byte("key"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("updated"), value)
+
+	// Reading from another account returns its pending updates as well.
+	account.StorageUpdates["otherKey"] = &vmcommon.StorageUpdate{Offset: []byte("otherKey"), Data: []byte("pending")}
+	value, err = storageContext.GetStorageFromAddress(readableAddress, []byte("otherKey"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("pending"), value)
+
+	value, err = storageContext.GetStorageFromAddress(otherShardAddress, []byte("key"))
+	require.Equal(t, arwen.ErrStorageReadFromOtherShard, err)
+	require.Nil(t, value)
+
+	value, err = storageContext.GetStorageFromAddress(readableAddress, []byte("RESERVEDkey"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("readableRESERVEDkey"), value)
+
+	value, err = storageContext.GetStorageFromAddress(nonReadableAddress, []byte("RESERVEDkey"))
+	require.Equal(t, arwen.ErrStorageKeyNotReadable, err)
+	require.Nil(t, value)
+}
+
+func newStorageContextForKeysWithPrefix(state map[string][]byte) (*storageContext, *mock.MeteringContextMock, *mock.BlockchainHookStub) {
+	address := []byte("account")
+	mockOutput := &mock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(address)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	mockRuntime := &mock.RuntimeContextMock{}
+	mockMetering := &mock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+	mockMetering.BlockGasLimitMock = uint64(15000)
+	mockMetering.GasLeftMock = uint64(15000)
+
+	host := &mock.VmHostMock{
+		OutputContext:   mockOutput,
+		MeteringContext: mockMetering,
+		RuntimeContext:  mockRuntime,
+	}
+	bcHook := &mock.BlockchainHookStub{}
+	bcHook.GetAllStateCalled = func(address []byte) (map[string][]byte, error) {
+		return state, nil
+	}
+
+	storageContext, _ := NewStorageContext(host, bcHook, kalyan3104ReservedTestPrefix)
+	storageContext.SetAddress(address)
+	return storageContext, mockMetering, bcHook
+}
+
+func TestStorageContext_GetStorageKeysWithPrefix(t *testing.T) {
+	t.Parallel()
+
+	storageContext, _, bcHook := newStorageContextForKeysWithPrefix(map[string][]byte{
+		"item.b":      []byte("b"),
+		"item.a":      []byte("a"),
+		"item.c":      []byte("c"),
+		"item.empty":  {},
+		"other":       []byte("other"),
+		"RESERVEDkey": []byte("reserved"),
+	})
+
+	keys, err := storageContext.GetStorageKeysWithPrefix([]byte("item."), 0, 10)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.a"), []byte("item.b"), []byte("item.c")}, keys)
+
+	keys, err = storageContext.GetStorageKeysWithPrefix(nil, 0, 10)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.a"), []byte("item.b"), []byte("item.c"), []byte("other")}, keys)
+
+	count, err := storageContext.CountStorageKeysWithPrefix([]byte("item."))
+	require.Nil(t, err)
+	require.Equal(t, 3, count)
+
+	// Pending storage updates take precedence over the committed state.
+	_, _ = storageContext.SetStorage([]byte("item.0"), []byte("new"))
+	_, _ = storageContext.SetStorage([]byte("item.b"), nil)
+	_, _ = storageContext.SetStorage([]byte("item.empty"), []byte("filled"))
+	keys, err = storageContext.GetStorageKeysWithPrefix([]byte("item."), 0, 10)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.0"), []byte("item.a"), []byte("item.c"), []byte("item.empty")}, keys)
+
+	count, err = storageContext.CountStorageKeysWithPrefix([]byte("item."))
+	require.Nil(t, err)
+	require.Equal(t, 4, count)
+
+	keys, err = storageContext.GetStorageKeysWithPrefix([]byte("item."), 1, 2)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.a"), []byte("item.c")}, keys)
+
+	// The state of the blockchain is loaded only once per transaction.
+	expectedErr := errors.New("state not available")
+	bcHook.GetAllStateCalled = func(address []byte) (map[string][]byte, error) {
+		return nil, expectedErr
+	}
+	count, err = storageContext.CountStorageKeysWithPrefix([]byte("item."))
+	require.Nil(t, err)
+	require.Equal(t, 4, count)
+
+	storageContext.InitState()
+	keys, err = storageContext.GetStorageKeysWithPrefix([]byte("item."), 0, 10)
+	require.Equal(t, expectedErr, err)
+	require.Nil(t, keys)
+}
+
+func TestStorageContext_GetStorageKeysWithPrefix_ResumesFromPreviousPage(t *testing.T) {
+	t.Parallel()
+
+	storageContext, mockMetering, _ := newStorageContextForKeysWithPrefix(map[string][]byte{
+		"item.a": []byte("a"),
+		"item.b": []byte("b"),
+		"item.c": []byte("c"),
+		"item.d": []byte("d"),
+	})
+	gasPerKey := mockMetering.GasSchedule().Kalyan3104APICost.StorageKeyIteration
+
+	keys, err := storageContext.GetStorageKeysWithPrefix([]byte("item."), 0, 3)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.a"), []byte("item.b"), []byte("item.c")}, keys)
+
+	// Resuming within the previous page scans only the keys returned.
+	gasUsedBefore := mockMetering.GasUsedMock
+	keys, err = storageContext.GetStorageKeysWithPrefix([]byte("item."), 2, 2)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.c"), []byte("item.d")}, keys)
+	require.Equal(t, 2*gasPerKey, mockMetering.GasUsedMock-gasUsedBefore)
+
+	// Keys added before the cursor do not shift the following page.
+	_, _ = storageContext.SetStorage([]byte("item.0"), []byte("new"))
+	keys, err = storageContext.GetStorageKeysWithPrefix([]byte("item."), 3, 2)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.d")}, keys)
+
+	// Otherwise, the first keys are skipped again, using gas for each.
+	gasUsedBefore = mockMetering.GasUsedMock
+	keys, err = storageContext.GetStorageKeysWithPrefix([]byte("item."), 3, 2)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.c"), []byte("item.d")}, keys)
+	require.Equal(t, 6*gasPerKey, mockMetering.GasUsedMock-gasUsedBefore)
+}
+
+func TestStorageContext_GetStorageKeysWithPrefix_NotEnoughGas(t *testing.T) {
+	t.Parallel()
+
+	state := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		state[fmt.Sprintf("item.%03d", i)] = []byte("value")
+	}
+	storageContext, mockMetering, _ := newStorageContextForKeysWithPrefix(state)
+	mockMetering.GasLeftMock = mockMetering.GasSchedule().Kalyan3104APICost.StorageKeyIteration * 10
+
+	keys, err := storageContext.GetStorageKeysWithPrefix([]byte("item."), 0, 10)
+	require.Equal(t, arwen.ErrNotEnoughGas, err)
+	require.Nil(t, keys)
+	require.Equal(t, uint64(0), mockMetering.GasUsedMock)
+}
+
+func TestStorageContext_Tracing(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	mockOutput := &mock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(address)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	mockMetering := &mock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+	mockMetering.BlockGasLimitMock = uint64(15000)
+
+	tracer := &mock.ExecutionTracerMock{}
+	host := &mock.VmHostMock{
+		OutputContext:   mockOutput,
+		MeteringContext: mockMetering,
+		RuntimeContext:  &mock.RuntimeContextMock{},
+		ExecutionTracer: tracer,
+	}
+	bcHook := &mock.BlockchainHookStub{}
+
+	storageContext, _ := NewStorageContext(host, bcHook, kalyan3104ReservedTestPrefix)
+	storageContext.SetAddress(address)
+
+	key := []byte("key")
+	value := []byte("value")
+
+	_, err := storageContext.SetStorage(key, value)
+	require.Nil(t, err)
+	require.Equal(t, value, storageContext.GetStorage(key))
+
+	expectedEvents := []*arwen.TraceEvent{
+		{Type: arwen.TraceEventStorageStore, Address: address, Key: key, Value: value},
+		{Type: arwen.TraceEventStorageLoad, Address: address, Key: key, Value: value},
+	}
+	require.Equal(t, expectedEvents, tracer.Events)
+}
@@ -2,43 +2,174 @@ package contexts
 
 import (
 	"fmt"
-	"unicode"
+	"strings"
 
 	vmcommon "github.com/kalyan3104/dme-vm-common"
 	"github.com/kalyan3104/dme-vm-go/arwen"
-	"github.com/kalyan3104/dme-vm-go/wasmer"
 )
 
 const NoArity = -1
 
-// WASMValidator is a validator for WASM SmartContracts
+// contractImportsNamespace is the only namespace contracts may import from
+// when the validation policy restricts imports
+const contractImportsNamespace = "env"
+
+// WASMValidator is a validator for WASM SmartContracts, which checks their
+// bytecode before it is instantiated
 type WASMValidator struct {
-	reserved *ReservedFunctions
+	reserved       *ReservedFunctions
+	allowedImports vmcommon.FunctionNames
+	policy         arwen.WASMValidationPolicy
 }
 
 // NewWASMValidator creates a new WASMValidator
 func NewWASMValidator(scAPINames vmcommon.FunctionNames, protocolBuiltinFunctions vmcommon.FunctionNames) *WASMValidator {
 	return &WASMValidator{
-		reserved: NewReservedFunctions(scAPINames, protocolBuiltinFunctions),
+		reserved:       NewReservedFunctions(scAPINames, protocolBuiltinFunctions),
+		allowedImports: scAPINames,
+	}
+}
+
+// verifyContractCode parses the given bytecode and checks it against the
+// validation policy, returning the parsed module
+func (validator *WASMValidator) verifyContractCode(code []byte) (*wasmModule, error) {
+	module, err := parseWASMModule(code)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validator.verifyMemoryDeclaration(module)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validator.verifyImports(module)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validator.verifyLimits(module)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validator.verifyFunctions(module)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validator.verifyInstructions(module)
+	if err != nil {
+		return nil, err
+	}
+
+	return module, nil
+}
+
+func (validator *WASMValidator) verifyMemoryDeclaration(module *wasmModule) error {
+	for _, entry := range module.exports {
+		if entry.kind == wasmExternalMemory {
+			return nil
+		}
+	}
+
+	return arwen.ErrMemoryDeclarationMissing
+}
+
+func (validator *WASMValidator) verifyImports(module *wasmModule) error {
+	if !validator.policy.RestrictImports {
+		return nil
 	}
+
+	for _, entry := range module.imports {
+		_, isAllowed := validator.allowedImports[entry.name]
+		isAllowed = isAllowed && entry.module == contractImportsNamespace && entry.kind == wasmExternalFunction
+		if !isAllowed {
+			return fmt.Errorf("%w: %s.%s", arwen.ErrImportNotAllowed, entry.module, entry.name)
+		}
+	}
+
+	return nil
 }
 
-func (validator *WASMValidator) verifyMemoryDeclaration(instance *wasmer.Instance) error {
-	if !instance.HasMemory() {
-		return arwen.ErrMemoryDeclarationMissing
+func (validator *WASMValidator) verifyLimits(module *wasmModule) error {
+	policy := validator.policy
+
+	if exceedsLimit(uint64(len(module.functions)), policy.MaxFunctions) {
+		return arwen.ErrTooManyFunctions
+	}
+	if exceedsLimit(uint64(module.numGlobals), policy.MaxGlobals) {
+		return arwen.ErrTooManyGlobals
+	}
+	if exceedsLimit(uint64(module.numDataSegments), policy.MaxDataSegments) {
+		return arwen.ErrTooManyDataSegments
+	}
+
+	for _, body := range module.bodies {
+		if exceedsLimit(body.numLocals, policy.MaxLocals) {
+			return arwen.ErrTooManyLocals
+		}
+	}
+
+	for _, table := range module.tables {
+		if exceedsLimit(uint64(table.min), policy.MaxTableSize) {
+			return arwen.ErrTableTooLarge
+		}
+	}
+
+	for _, memory := range module.memories {
+		if exceedsLimit(uint64(memory.min), policy.MaxMemoryPages) {
+			return arwen.ErrMemoryTooLarge
+		}
 	}
 
 	return nil
 }
 
-func (validator *WASMValidator) verifyFunctions(instance *wasmer.Instance) error {
-	for functionName := range instance.Exports {
-		err := validator.verifyValidFunctionName(functionName)
+func exceedsLimit(value uint64, limit uint32) bool {
+	return limit > 0 && value > uint64(limit)
+}
+
+func (validator *WASMValidator) verifyInstructions(module *wasmModule) error {
+	if !validator.policy.DisallowFloatingPoint {
+		return nil
+	}
+
+	for i, body := range module.bodies {
+		functionIndex := module.numImportedFunctions() + uint32(i)
+		err := forEachOpcode(body.code, func(opcode int) error {
+			if isFloatingPointOpcode(opcode) {
+				return fmt.Errorf("%w: in function %d", arwen.ErrFloatingPointNotAllowed, functionIndex)
+			}
+			return nil
+		})
 		if err != nil {
 			return err
 		}
+	}
+
+	return nil
+}
+
+func (validator *WASMValidator) verifyFunctions(module *wasmModule) error {
+	namesByLowercase := make(map[string]string)
+	for _, entry := range module.exports {
+		if entry.kind != wasmExternalFunction {
+			continue
+		}
 
-		err = validator.verifyVoidFunction(instance, functionName)
+		err := validator.verifyValidFunctionName(entry.name)
+		if err != nil {
+			return err
+		}
+
+		lowercaseName := strings.ToLower(entry.name)
+		if otherName, exists := namesByLowercase[lowercaseName]; exists {
+			return fmt.Errorf("%w: %s and %s", arwen.ErrDuplicateExportName, otherName, entry.name)
+		}
+		namesByLowercase[lowercaseName] = entry.name
+
+		err = validator.verifyVoidFunction(module, entry.name)
 		if err != nil {
 			return err
 		}
@@ -47,13 +178,42 @@ func (validator *WASMValidator) verifyFunctions(instance *wasmer.Instance) error
 	return nil
 }
 
-func (validator *WASMValidator) verifyVoidFunction(instance *wasmer.Instance, functionName string) error {
-	inArity, err := validator.getInputArity(instance, functionName)
+// verifyEndpoints checks that the exported functions of the module, apart
+// from init and callBack, are exactly the endpoints declared by the ABI
+func (validator *WASMValidator) verifyEndpoints(module *wasmModule, endpoints []string) error {
+	declared := make(map[string]struct{}, len(endpoints))
+	for _, endpoint := range endpoints {
+		declared[endpoint] = struct{}{}
+	}
+
+	exported := make(map[string]struct{})
+	for _, entry := range module.exports {
+		if entry.kind != wasmExternalFunction || isEntrypointName(entry.name) {
+			continue
+		}
+
+		exported[entry.name] = struct{}{}
+		if _, ok := declared[entry.name]; !ok {
+			return fmt.Errorf("%w: %s", arwen.ErrEndpointNotDeclared, entry.name)
+		}
+	}
+
+	for _, endpoint := range endpoints {
+		if _, ok := exported[endpoint]; !ok {
+			return fmt.Errorf("%w: %s", arwen.ErrEndpointNotExported, endpoint)
+		}
+	}
+
+	return nil
+}
+
+func (validator *WASMValidator) verifyVoidFunction(module *wasmModule, functionName string) error {
+	inArity, err := validator.getInputArity(module, functionName)
 	if err != nil {
 		return err
 	}
 
-	outArity, err := validator.getOutputArity(instance, functionName)
+	outArity, err := validator.getOutputArity(module, functionName)
 	if err != nil {
 		return err
 	}
@@ -65,20 +225,29 @@ func (validator *WASMValidator) verifyVoidFunction(instance *wasmer.Instance, fu
 	return nil
 }
 
-func (validator *WASMValidator) getInputArity(instance *wasmer.Instance, functionName string) (int, error) {
-	signature, ok := instance.Signatures[functionName]
-	if !ok {
-		return NoArity, fmt.Errorf("%w: %s", arwen.ErrFuncNotFound, functionName)
+func (validator *WASMValidator) getInputArity(module *wasmModule, functionName string) (int, error) {
+	signature, err := validator.getSignature(module, functionName)
+	if err != nil {
+		return NoArity, err
+	}
+	return len(signature.params), nil
+}
+
+func (validator *WASMValidator) getOutputArity(module *wasmModule, functionName string) (int, error) {
+	signature, err := validator.getSignature(module, functionName)
+	if err != nil {
+		return NoArity, err
 	}
-	return signature.InputArity, nil
+	return len(signature.results), nil
 }
 
-func (validator *WASMValidator) getOutputArity(instance *wasmer.Instance, functionName string) (int, error) {
-	signature, ok := instance.Signatures[functionName]
-	if !ok {
-		return NoArity, fmt.Errorf("%w: %s", arwen.ErrFuncNotFound, functionName)
+func (validator *WASMValidator) getSignature(module *wasmModule, functionName string) (*wasmFunctionSignature, error) {
+	for _, entry := range module.exports {
+		if entry.kind == wasmExternalFunction && entry.name == functionName {
+			return module.functionSignature(entry.index)
+		}
 	}
-	return signature.OutputArity, nil
+	return nil, fmt.Errorf("%w: %s", arwen.ErrFuncNotFound, functionName)
 }
 
 func (validator *WASMValidator) verifyValidFunctionName(functionName string) error {
@@ -92,23 +261,55 @@ func (validator *WASMValidator) verifyValidFunctionName(functionName string) err
 	if len(functionName) >= maxLengthOfFunctionName {
 		return errInvalidName
 	}
-	if !isASCIIString(functionName) {
+	if !isIdentifier(functionName) && functionName != arwen.InitFunctionNameEth {
 		return errInvalidName
 	}
 	if validator.reserved.IsReserved(functionName) {
 		return errInvalidName
 	}
+	if isMisspelledEntrypointName(functionName) {
+		return fmt.Errorf("%w: %s", arwen.ErrMisspelledEntrypoint, functionName)
+	}
 
 	return nil
 }
 
-// TODO: Add more constraints (too loose currently)
-func isASCIIString(input string) bool {
+// isIdentifier returns whether the input is made of ASCII letters, digits and
+// underscores, not starting with a digit
+func isIdentifier(input string) bool {
 	for i := 0; i < len(input); i++ {
-		if input[i] > unicode.MaxASCII {
+		character := input[i]
+		isLetter := (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')
+		isDigit := character >= '0' && character <= '9'
+		if isDigit && i == 0 {
+			return false
+		}
+		if !isLetter && !isDigit && character != '_' {
 			return false
 		}
 	}
 
 	return true
 }
+
+// isEntrypointName returns whether the function is called by the VM itself
+// rather than through a transaction
+func isEntrypointName(functionName string) bool {
+	return functionName == arwen.InitFunctionName ||
+		functionName == arwen.InitFunctionNameEth ||
+		functionName == arwen.CallBackFunctionName
+}
+
+// isMisspelledEntrypointName returns whether the function name differs only by
+// case from one of the names the VM calls by itself, which means the function
+// would never be called as intended
+func isMisspelledEntrypointName(functionName string) bool {
+	entrypointNames := []string{arwen.InitFunctionName, arwen.CallBackFunctionName, arwen.UpgradeFunctionName}
+	for _, entrypointName := range entrypointNames {
+		if functionName != entrypointName && strings.EqualFold(functionName, entrypointName) {
+			return true
+		}
+	}
+
+	return false
+}
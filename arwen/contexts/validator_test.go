@@ -1,6 +1,7 @@
 package contexts
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -39,57 +40,443 @@ func TestFunctionsGuard_isValidFunctionName(t *testing.T) {
 	require.NotNil(t, validator.verifyValidFunctionName("getArgument"))
 	require.NotNil(t, validator.verifyValidFunctionName("asyncCall"))
 	require.Nil(t, validator.verifyValidFunctionName("getArgument55"))
+
+	require.Nil(t, validator.verifyValidFunctionName("foo_Bar2"))
+	require.NotNil(t, validator.verifyValidFunctionName("2foo"))
+	require.NotNil(t, validator.verifyValidFunctionName("foo-bar"))
+	require.NotNil(t, validator.verifyValidFunctionName("foo bar"))
+	require.NotNil(t, validator.verifyValidFunctionName("foo.bar"))
+	require.NotNil(t, validator.verifyValidFunctionName("foo\x00"))
+}
+
+func TestFunctionsGuard_EntrypointNames(t *testing.T) {
+	validator := NewWASMValidator(MakeAPIImports().Names(), make(vmcommon.FunctionNames))
+
+	require.Nil(t, validator.verifyValidFunctionName("init"))
+	require.Nil(t, validator.verifyValidFunctionName("callBack"))
+	require.Nil(t, validator.verifyValidFunctionName("solidity.ctor"))
+	require.True(t, errors.Is(validator.verifyValidFunctionName("upgradeContract"), arwen.ErrInvalidFunctionName))
+
+	for _, name := range []string{"Init", "INIT", "callback", "CallBack", "upgradecontract", "UpgradeContract"} {
+		err := validator.verifyValidFunctionName(name)
+		require.True(t, errors.Is(err, arwen.ErrMisspelledEntrypoint), name)
+		require.True(t, errors.Is(err, arwen.ErrInvalidFunctionName), name)
+	}
 }
 
 func TestFunctionsGuard_Arity(t *testing.T) {
 	imports := InitializeWasmer()
 	validator := NewWASMValidator(imports.Names(), make(vmcommon.FunctionNames))
 
-	gasLimit := uint64(100000000)
 	path := "./../../test/contracts/signatures/signatures.wasm"
 	contractCode := arwen.GetSCCode(path)
-	options := wasmer.CompilationOptions{
-		GasLimit:           gasLimit,
-		OpcodeTrace:        false,
-		Metering:           true,
-		RuntimeBreakpoints: true,
-	}
-	instance, err := wasmer.NewInstanceWithOptions(contractCode, options)
+	module, err := parseWASMModule(contractCode)
 	require.Nil(t, err)
 
-	inArity, _ := validator.getInputArity(instance, "goodFunction")
+	inArity, _ := validator.getInputArity(module, "goodFunction")
 	require.Equal(t, 0, inArity)
 
-	outArity, _ := validator.getOutputArity(instance, "goodFunction")
+	outArity, _ := validator.getOutputArity(module, "goodFunction")
 	require.Equal(t, 0, outArity)
 
-	inArity, _ = validator.getInputArity(instance, "wrongReturn")
+	inArity, _ = validator.getInputArity(module, "wrongReturn")
 	require.Equal(t, 0, inArity)
 
-	outArity, _ = validator.getOutputArity(instance, "wrongReturn")
+	outArity, _ = validator.getOutputArity(module, "wrongReturn")
 	require.Equal(t, 1, outArity)
 
-	inArity, _ = validator.getInputArity(instance, "wrongParams")
+	inArity, _ = validator.getInputArity(module, "wrongParams")
 	require.Equal(t, 1, inArity)
 
-	outArity, _ = validator.getOutputArity(instance, "wrongParams")
+	outArity, _ = validator.getOutputArity(module, "wrongParams")
 	require.Equal(t, 0, outArity)
 
-	inArity, _ = validator.getInputArity(instance, "wrongParamsAndReturn")
+	inArity, _ = validator.getInputArity(module, "wrongParamsAndReturn")
 	require.Equal(t, 2, inArity)
 
-	outArity, _ = validator.getOutputArity(instance, "wrongParamsAndReturn")
+	outArity, _ = validator.getOutputArity(module, "wrongParamsAndReturn")
 	require.Equal(t, 1, outArity)
 
-	err = validator.verifyVoidFunction(instance, "goodFunction")
+	err = validator.verifyVoidFunction(module, "goodFunction")
 	require.Nil(t, err)
 
-	err = validator.verifyVoidFunction(instance, "wrongReturn")
+	err = validator.verifyVoidFunction(module, "wrongReturn")
 	require.NotNil(t, err)
 
-	err = validator.verifyVoidFunction(instance, "wrongParams")
+	err = validator.verifyVoidFunction(module, "wrongParams")
 	require.NotNil(t, err)
 
-	err = validator.verifyVoidFunction(instance, "wrongParamsAndReturn")
+	err = validator.verifyVoidFunction(module, "wrongParamsAndReturn")
 	require.NotNil(t, err)
 }
+
+func wasmTestSection(id byte, entries ...[]byte) []byte {
+	content := []byte{byte(len(entries))}
+	for _, entry := range entries {
+		content = append(content, entry...)
+	}
+	return append([]byte{id, byte(len(content))}, content...)
+}
+
+func wasmTestName(name string) []byte {
+	return append([]byte{byte(len(name))}, name...)
+}
+
+// makeTestWASMModule builds a module with a single exported void function,
+// f, and an exported memory, followed by the given extra sections
+func makeTestWASMModule(imports [][]byte, extraSections ...[]byte) []byte {
+	code := append([]byte{}, wasmMagicAndVersion...)
+	code = append(code, wasmTestSection(wasmSectionType, []byte{wasmFunctionType, 0, 0})...)
+	if len(imports) > 0 {
+		code = append(code, wasmTestSection(wasmSectionImport, imports...)...)
+	}
+	code = append(code, wasmTestSection(wasmSectionFunction, []byte{0})...)
+	code = append(code, wasmTestSection(wasmSectionMemory, []byte{0, 2})...)
+	code = append(code, wasmTestSection(wasmSectionExport,
+		append(wasmTestName("f"), wasmExternalFunction, byte(len(imports))),
+		append(wasmTestName("memory"), wasmExternalMemory, 0),
+	)...)
+	for _, section := range extraSections {
+		code = append(code, section...)
+	}
+	code = append(code, wasmTestSection(wasmSectionCode, []byte{4, 1, 3, 0x7F, 0x0B})...)
+	return code
+}
+
+func TestWASMValidator_ParseModule(t *testing.T) {
+	functionImport := append(append(wasmTestName("env"), wasmTestName("getArgument")...), wasmExternalFunction, 0)
+	module, err := parseWASMModule(makeTestWASMModule([][]byte{functionImport}))
+	require.Nil(t, err)
+
+	require.Len(t, module.types, 1)
+	require.Equal(t, []wasmImport{{module: "env", name: "getArgument", kind: wasmExternalFunction}}, module.imports)
+	require.Equal(t, uint32(1), module.numImportedFunctions())
+	require.Equal(t, []uint32{0}, module.functions)
+	require.Equal(t, []wasmLimits{{min: 2}}, module.memories)
+	require.Len(t, module.exports, 2)
+	require.Len(t, module.bodies, 1)
+	require.Equal(t, uint64(3), module.bodies[0].numLocals)
+	require.Equal(t, []byte{0x0B}, module.bodies[0].code)
+
+	signature, err := module.functionSignature(1)
+	require.Nil(t, err)
+	require.Len(t, signature.params, 0)
+
+	_, err = module.functionSignature(2)
+	require.True(t, errors.Is(err, arwen.ErrMalformedContractCode))
+}
+
+func TestWASMValidator_MalformedModule(t *testing.T) {
+	validator := NewWASMValidator(MakeAPIImports().Names(), make(vmcommon.FunctionNames))
+
+	_, err := validator.verifyContractCode([]byte("not WASM"))
+	require.True(t, errors.Is(err, arwen.ErrMalformedContractCode))
+
+	// Truncated at a section boundary, the module is well-formed, but lacks
+	// the memory declaration.
+	code := makeTestWASMModule(nil)
+	for length := len(wasmMagicAndVersion) + 1; length < len(code); length++ {
+		_, err = validator.verifyContractCode(code[:length])
+		require.True(t, errors.Is(err, arwen.ErrContractInvalid), "length %d", length)
+	}
+
+	_, err = validator.verifyContractCode(code[:len(code)-1])
+	require.True(t, errors.Is(err, arwen.ErrMalformedContractCode))
+
+	_, err = validator.verifyContractCode(append(code, wasmTestSection(13)...))
+	require.True(t, errors.Is(err, arwen.ErrMalformedContractCode))
+
+	_, err = validator.verifyContractCode(code)
+	require.Nil(t, err)
+}
+
+func TestWASMValidator_Limits(t *testing.T) {
+	validator := NewWASMValidator(MakeAPIImports().Names(), make(vmcommon.FunctionNames))
+	code := makeTestWASMModule(nil,
+		wasmTestSection(wasmSectionTable, []byte{0x70, 0, 5}),
+		wasmTestSection(wasmSectionGlobal, []byte{0x7F, 0, 0x41, 0, 0x0B}),
+	)
+	code = append(code, wasmTestSection(wasmSectionData, []byte{0, 0x41, 0, 0x0B, 0}, []byte{0, 0x41, 0, 0x0B, 0})...)
+
+	_, err := validator.verifyContractCode(code)
+	require.Nil(t, err)
+
+	policy := arwen.WASMValidationPolicy{
+		MaxFunctions:    1,
+		MaxLocals:       3,
+		MaxGlobals:      1,
+		MaxTableSize:    5,
+		MaxDataSegments: 2,
+		MaxMemoryPages:  2,
+	}
+	validator.policy = policy
+	_, err = validator.verifyContractCode(code)
+	require.Nil(t, err)
+
+	testCases := []struct {
+		setLimit    func(policy *arwen.WASMValidationPolicy)
+		expectedErr error
+	}{
+		{func(policy *arwen.WASMValidationPolicy) { policy.MaxLocals = 2 }, arwen.ErrTooManyLocals},
+		{func(policy *arwen.WASMValidationPolicy) { policy.MaxTableSize = 4 }, arwen.ErrTableTooLarge},
+		{func(policy *arwen.WASMValidationPolicy) { policy.MaxDataSegments = 1 }, arwen.ErrTooManyDataSegments},
+		{func(policy *arwen.WASMValidationPolicy) { policy.MaxMemoryPages = 1 }, arwen.ErrMemoryTooLarge},
+	}
+	for _, testCase := range testCases {
+		validator.policy = policy
+		testCase.setLimit(&validator.policy)
+		_, err = validator.verifyContractCode(code)
+		require.Equal(t, testCase.expectedErr, err)
+		require.True(t, errors.Is(err, arwen.ErrContractInvalid))
+	}
+
+	validator.policy = arwen.WASMValidationPolicy{MaxGlobals: 1}
+	code = makeTestWASMModule(nil, wasmTestSection(wasmSectionGlobal,
+		[]byte{0x7F, 0, 0x41, 0, 0x0B},
+		[]byte{0x7F, 0, 0x41, 0, 0x0B},
+	))
+	_, err = validator.verifyContractCode(code)
+	require.Equal(t, arwen.ErrTooManyGlobals, err)
+}
+
+func TestWASMValidator_ImportedLimits(t *testing.T) {
+	validator := NewWASMValidator(MakeAPIImports().Names(), make(vmcommon.FunctionNames))
+	validator.policy = arwen.WASMValidationPolicy{MaxTableSize: 5, MaxMemoryPages: 2}
+
+	tableImport := append(append(wasmTestName("env"), wasmTestName("table")...), wasmExternalTable, 0x70, 0, 6)
+	module, err := parseWASMModule(makeTestWASMModule([][]byte{tableImport}))
+	require.Nil(t, err)
+	require.Equal(t, []wasmLimits{{min: 6}}, module.tables)
+
+	_, err = validator.verifyContractCode(makeTestWASMModule([][]byte{tableImport}))
+	require.Equal(t, arwen.ErrTableTooLarge, err)
+
+	memoryImport := append(append(wasmTestName("env"), wasmTestName("memory")...), wasmExternalMemory, 0, 3)
+	module, err = parseWASMModule(makeTestWASMModule([][]byte{memoryImport}))
+	require.Nil(t, err)
+	require.Equal(t, []wasmLimits{{min: 3}, {min: 2}}, module.memories)
+
+	_, err = validator.verifyContractCode(makeTestWASMModule([][]byte{memoryImport}))
+	require.Equal(t, arwen.ErrMemoryTooLarge, err)
+}
+
+func TestWASMValidator_ReadU32(t *testing.T) {
+	testCases := []struct {
+		data     []byte
+		expected uint32
+		valid    bool
+	}{
+		{[]byte{0x05}, 5, true},
+		{[]byte{0xE5, 0x8E, 0x26}, 624485, true},
+		{[]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F}, 0xFFFFFFFF, true},
+		{[]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x1F}, 0, false},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x00}, 0, false},
+		{[]byte{0x80, 0x80}, 0, false},
+	}
+	for _, testCase := range testCases {
+		reader := &wasmReader{data: testCase.data}
+		value, err := reader.readU32()
+		if !testCase.valid {
+			require.True(t, errors.Is(err, arwen.ErrMalformedContractCode), "%x", testCase.data)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, testCase.expected, value)
+	}
+}
+
+func TestWASMValidator_TooManyFunctions(t *testing.T) {
+	validator := NewWASMValidator(MakeAPIImports().Names(), make(vmcommon.FunctionNames))
+	validator.policy = arwen.WASMValidationPolicy{MaxFunctions: 1}
+
+	code := append([]byte{}, wasmMagicAndVersion...)
+	code = append(code, wasmTestSection(wasmSectionType, []byte{wasmFunctionType, 0, 0})...)
+	code = append(code, wasmTestSection(wasmSectionFunction, []byte{0}, []byte{0})...)
+	code = append(code, wasmTestSection(wasmSectionMemory, []byte{0, 1})...)
+	code = append(code, wasmTestSection(wasmSectionExport, append(wasmTestName("memory"), wasmExternalMemory, 0))...)
+	code = append(code, wasmTestSection(wasmSectionCode, []byte{2, 0, 0x0B}, []byte{2, 0, 0x0B})...)
+
+	_, err := validator.verifyContractCode(code)
+	require.Equal(t, arwen.ErrTooManyFunctions, err)
+
+	validator.policy.MaxFunctions = 2
+	_, err = validator.verifyContractCode(code)
+	require.Nil(t, err)
+}
+
+func TestWASMValidator_Imports(t *testing.T) {
+	imports := MakeAPIImports()
+	validator := NewWASMValidator(imports.Names(), make(vmcommon.FunctionNames))
+	validator.allowedImports = imports.NamespaceNames(contractImportsNamespace)
+
+	importFrom := func(module string, name string) [][]byte {
+		return [][]byte{append(append(wasmTestName(module), wasmTestName(name)...), wasmExternalFunction, 0)}
+	}
+
+	code := makeTestWASMModule(importFrom("env", "notAnAPIFunction"))
+	_, err := validator.verifyContractCode(code)
+	require.Nil(t, err)
+
+	validator.policy.RestrictImports = true
+	_, err = validator.verifyContractCode(code)
+	require.True(t, errors.Is(err, arwen.ErrImportNotAllowed))
+	require.Contains(t, err.Error(), "env.notAnAPIFunction")
+
+	// Functions of the Ethereum API are only allowed from their own namespace.
+	_, err = validator.verifyContractCode(makeTestWASMModule(importFrom("env", "useGas")))
+	require.True(t, errors.Is(err, arwen.ErrImportNotAllowed))
+
+	_, err = validator.verifyContractCode(makeTestWASMModule(importFrom("ethereum", "useGas")))
+	require.True(t, errors.Is(err, arwen.ErrImportNotAllowed))
+
+	_, err = validator.verifyContractCode(makeTestWASMModule(importFrom("env", "getArgument")))
+	require.Nil(t, err)
+
+	memoryImport := append(append(wasmTestName("env"), wasmTestName("memory")...), wasmExternalMemory, 0, 1)
+	_, err = validator.verifyContractCode(makeTestWASMModule([][]byte{memoryImport}))
+	require.True(t, errors.Is(err, arwen.ErrImportNotAllowed))
+}
+
+func TestWASMValidator_VerifyContractCode(t *testing.T) {
+	validator := NewWASMValidator(MakeAPIImports().Names(), make(vmcommon.FunctionNames))
+
+	_, err := validator.verifyContractCode(arwen.GetSCCode("./../../test/contracts/memoryless/output/memoryless.wasm"))
+	require.Equal(t, arwen.ErrMemoryDeclarationMissing, err)
+
+	_, err = validator.verifyContractCode(arwen.GetSCCode("./../../test/contracts/signatures/signatures.wasm"))
+	require.True(t, errors.Is(err, arwen.ErrFunctionNonvoidSignature))
+
+	_, err = validator.verifyContractCode(arwen.GetSCCode("./../../test/contracts/counter/output/counter.wasm"))
+	require.Nil(t, err)
+}
+
+// makeTestWASMModuleWithExports builds a module with a single void function,
+// exported under each of the given names, and an exported memory
+func makeTestWASMModuleWithExports(names ...string) []byte {
+	exports := [][]byte{append(wasmTestName("memory"), wasmExternalMemory, 0)}
+	for _, name := range names {
+		exports = append(exports, append(wasmTestName(name), wasmExternalFunction, 0))
+	}
+
+	code := append([]byte{}, wasmMagicAndVersion...)
+	code = append(code, wasmTestSection(wasmSectionType, []byte{wasmFunctionType, 0, 0})...)
+	code = append(code, wasmTestSection(wasmSectionFunction, []byte{0})...)
+	code = append(code, wasmTestSection(wasmSectionMemory, []byte{0, 1})...)
+	code = append(code, wasmTestSection(wasmSectionExport, exports...)...)
+	code = append(code, wasmTestSection(wasmSectionCode, []byte{2, 0, 0x0B})...)
+	return code
+}
+
+func TestWASMValidator_DuplicateExports(t *testing.T) {
+	validator := NewWASMValidator(MakeAPIImports().Names(), make(vmcommon.FunctionNames))
+
+	_, err := validator.verifyContractCode(makeTestWASMModuleWithExports("foo", "bar", "init"))
+	require.Nil(t, err)
+
+	_, err = validator.verifyContractCode(makeTestWASMModuleWithExports("foo", "bar", "Foo"))
+	require.True(t, errors.Is(err, arwen.ErrDuplicateExportName))
+	require.Contains(t, err.Error(), "foo and Foo")
+
+	_, err = validator.verifyContractCode(makeTestWASMModuleWithExports("init", "callback"))
+	require.True(t, errors.Is(err, arwen.ErrMisspelledEntrypoint))
+}
+
+func TestWASMValidator_VerifyEndpoints(t *testing.T) {
+	validator := NewWASMValidator(MakeAPIImports().Names(), make(vmcommon.FunctionNames))
+	module, err := validator.verifyContractCode(arwen.GetSCCode("./../../test/contracts/counter/output/counter.wasm"))
+	require.Nil(t, err)
+
+	err = validator.verifyEndpoints(module, []string{"get", "increment", "decrement"})
+	require.Nil(t, err)
+
+	err = validator.verifyEndpoints(module, []string{"get", "increment"})
+	require.True(t, errors.Is(err, arwen.ErrEndpointNotDeclared))
+	require.Contains(t, err.Error(), "decrement")
+
+	err = validator.verifyEndpoints(module, []string{"get", "increment", "decrement", "reset"})
+	require.True(t, errors.Is(err, arwen.ErrEndpointNotExported))
+	require.Contains(t, err.Error(), "reset")
+
+	err = validator.verifyEndpoints(module, []string{"get", "increment", "decrement", "init"})
+	require.True(t, errors.Is(err, arwen.ErrEndpointNotExported))
+	require.True(t, errors.Is(err, arwen.ErrContractInvalid))
+}
+
+func TestWASMValidator_ForEachOpcode(t *testing.T) {
+	code := []byte{
+		0x02, 0x40, // block
+		0x42, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01, // i64.const math.MinInt64
+		0x1A,       // drop
+		0x41, 0x7F, // i32.const -1
+		0x0E, 0x02, 0, 0, 0, // br_table 0 0 0
+		0x0B,       // end
+		0x41, 0x00, // i32.const 0
+		0x28, 0x02, 0x80, 0x01, // i32.load align=2 offset=128
+		0x43, 0, 0, 0x80, 0x3F, // f32.const 1
+		0x8C,       // f32.neg
+		0xFC, 0x00, // i32.trunc_sat_f32_s
+		0x6A, // i32.add
+		0x1A, // drop
+		0x0B, // end
+	}
+
+	opcodes := make([]int, 0)
+	err := forEachOpcode(code, func(opcode int) error {
+		opcodes = append(opcodes, opcode)
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, []int{
+		wasmer.OpcodeBlock,
+		wasmer.OpcodeI64Const,
+		wasmer.OpcodeDrop,
+		wasmer.OpcodeI32Const,
+		wasmer.OpcodeBrTable,
+		wasmer.OpcodeEnd,
+		wasmer.OpcodeI32Const,
+		wasmer.OpcodeI32Load,
+		wasmer.OpcodeF32Const,
+		wasmer.OpcodeF32Neg,
+		wasmer.OpcodeI32TruncSatF32S,
+		wasmer.OpcodeI32Add,
+		wasmer.OpcodeDrop,
+		wasmer.OpcodeEnd,
+	}, opcodes)
+
+	err = forEachOpcode([]byte{0x06}, func(opcode int) error { return nil })
+	require.True(t, errors.Is(err, arwen.ErrMalformedContractCode))
+
+	err = forEachOpcode([]byte{0x41}, func(opcode int) error { return nil })
+	require.True(t, errors.Is(err, arwen.ErrMalformedContractCode))
+}
+
+func TestWASMValidator_FloatingPointOpcodes(t *testing.T) {
+	require.True(t, isFloatingPointOpcode(wasmer.OpcodeF32Add))
+	require.True(t, isFloatingPointOpcode(wasmer.OpcodeF64Sqrt))
+	require.True(t, isFloatingPointOpcode(wasmer.OpcodeI32TruncF64U))
+	require.True(t, isFloatingPointOpcode(wasmer.OpcodeF64ConvertI64S))
+	require.True(t, isFloatingPointOpcode(wasmer.OpcodeI64ReinterpretF64))
+	require.True(t, isFloatingPointOpcode(wasmer.OpcodeI64TruncSatF64U))
+
+	require.False(t, isFloatingPointOpcode(wasmer.OpcodeF32Const))
+	require.False(t, isFloatingPointOpcode(wasmer.OpcodeF64Load))
+	require.False(t, isFloatingPointOpcode(wasmer.OpcodeF32Eq))
+	require.False(t, isFloatingPointOpcode(wasmer.OpcodeI64ExtendI32U))
+	require.False(t, isFloatingPointOpcode(wasmer.OpcodeI32WrapI64))
+	require.False(t, isFloatingPointOpcode(wasmer.OpcodeI32Extend8S))
+}
+
+func TestWASMValidator_DisallowFloatingPoint(t *testing.T) {
+	validator := NewWASMValidator(MakeAPIImports().Names(), make(vmcommon.FunctionNames))
+	contractCode := arwen.GetSCCode("./../../test/contracts/num-with-fp/output/num-with-fp.wasm")
+
+	_, err := validator.verifyContractCode(contractCode)
+	require.Nil(t, err)
+
+	validator.policy.DisallowFloatingPoint = true
+	_, err = validator.verifyContractCode(contractCode)
+	require.True(t, errors.Is(err, arwen.ErrFloatingPointNotAllowed))
+
+	_, err = validator.verifyContractCode(arwen.GetSCCode("./../../test/contracts/counter/output/counter.wasm"))
+	require.Nil(t, err)
+}
@@ -0,0 +1,95 @@
+package contexts
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kalyan3104/dme-vm-go/arwen"
+)
+
+// wasmPageSize is the size of a page of WASM memory, in bytes
+const wasmPageSize = 65536
+
+// limitMemoryPages returns a copy of the bytecode in which the memories
+// declare at most maxPages as their maximum size, so that growing them beyond
+// maxPages fails; it fails if a memory requires more than maxPages initially.
+// Imported memories are left unchanged. A zero maxPages is not enforced.
+func limitMemoryPages(code []byte, maxPages uint32) ([]byte, error) {
+	if maxPages == 0 {
+		return code, nil
+	}
+	if !bytes.HasPrefix(code, wasmMagicAndVersion) {
+		return nil, fmt.Errorf("%w: not a WASM module", arwen.ErrMalformedContractCode)
+	}
+
+	result := make([]byte, 0, len(code)+16)
+	result = append(result, wasmMagicAndVersion...)
+
+	reader := &wasmReader{data: code, offset: len(wasmMagicAndVersion)}
+	for !reader.done() {
+		sectionID, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		sectionSize, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+
+		sectionData, err := reader.readBytes(sectionSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if sectionID == wasmSectionMemory {
+			sectionData, err = limitMemorySection(sectionData, maxPages)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, sectionID)
+		result = appendU32(result, uint32(len(sectionData)))
+		result = append(result, sectionData...)
+	}
+
+	return result, nil
+}
+
+func limitMemorySection(section []byte, maxPages uint32) ([]byte, error) {
+	reader := &wasmReader{data: section}
+	count, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+
+	result := appendU32(nil, count)
+	for i := uint32(0); i < count; i++ {
+		limits, err := reader.readLimits()
+		if err != nil {
+			return nil, err
+		}
+		if limits.min > maxPages {
+			return nil, fmt.Errorf("%w: %d pages", arwen.ErrMemoryTooLarge, limits.min)
+		}
+		if !limits.hasMax || limits.max > maxPages {
+			limits.max = maxPages
+		}
+
+		result = append(result, 1)
+		result = appendU32(result, limits.min)
+		result = appendU32(result, limits.max)
+	}
+
+	return append(result, reader.remaining()...), nil
+}
+
+// appendU32 appends the unsigned LEB128 encoding of value to data
+func appendU32(data []byte, value uint32) []byte {
+	for value >= 0x80 {
+		data = append(data, byte(value&0x7F)|0x80)
+		value >>= 7
+	}
+	return append(data, byte(value))
+}
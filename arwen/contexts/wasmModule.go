@@ -0,0 +1,546 @@
+package contexts
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kalyan3104/dme-vm-go/arwen"
+)
+
+const (
+	wasmSectionCustom    = 0
+	wasmSectionType      = 1
+	wasmSectionImport    = 2
+	wasmSectionFunction  = 3
+	wasmSectionTable     = 4
+	wasmSectionMemory    = 5
+	wasmSectionGlobal    = 6
+	wasmSectionExport    = 7
+	wasmSectionStart     = 8
+	wasmSectionElement   = 9
+	wasmSectionCode      = 10
+	wasmSectionData      = 11
+	wasmSectionDataCount = 12
+)
+
+const (
+	wasmExternalFunction = 0
+	wasmExternalTable    = 1
+	wasmExternalMemory   = 2
+	wasmExternalGlobal   = 3
+)
+
+const wasmFunctionType = 0x60
+
+var wasmMagicAndVersion = []byte{0x00, 0x61, 0x73, 0x6D, 0x01, 0x00, 0x00, 0x00}
+
+// wasmFunctionSignature holds the parameter and result types of a function
+type wasmFunctionSignature struct {
+	params  []byte
+	results []byte
+}
+
+// wasmLimits holds the minimum and optional maximum size of a table or memory
+type wasmLimits struct {
+	min    uint32
+	max    uint32
+	hasMax bool
+}
+
+// wasmImport is an entry of the import section; typeIndex is only meaningful
+// for imported functions
+type wasmImport struct {
+	module    string
+	name      string
+	kind      byte
+	typeIndex uint32
+}
+
+// wasmExport is an entry of the export section
+type wasmExport struct {
+	name  string
+	kind  byte
+	index uint32
+}
+
+// wasmLocals declares count locals of the same type
+type wasmLocals struct {
+	count     uint32
+	valueType byte
+}
+
+// wasmFunctionBody holds the local declarations of a function and its
+// instructions
+type wasmFunctionBody struct {
+	locals    []wasmLocals
+	numLocals uint64
+	code      []byte
+}
+
+// wasmModule is the result of statically parsing the sections of a WASM
+// module; it only decodes what the WASMValidator needs to check, leaving the
+// full validation of the module to wasmer. Its tables and memories include
+// the imported ones.
+type wasmModule struct {
+	types           []wasmFunctionSignature
+	imports         []wasmImport
+	functions       []uint32
+	tables          []wasmLimits
+	memories        []wasmLimits
+	numGlobals      uint32
+	exports         []wasmExport
+	bodies          []wasmFunctionBody
+	numDataSegments uint32
+}
+
+// parseWASMModule decodes the sections of the given WASM bytecode
+func parseWASMModule(code []byte) (*wasmModule, error) {
+	if !bytes.HasPrefix(code, wasmMagicAndVersion) {
+		return nil, fmt.Errorf("%w: not a WASM module", arwen.ErrMalformedContractCode)
+	}
+
+	module := &wasmModule{}
+	reader := &wasmReader{data: code, offset: len(wasmMagicAndVersion)}
+	for !reader.done() {
+		sectionID, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		sectionSize, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+
+		sectionData, err := reader.readBytes(sectionSize)
+		if err != nil {
+			return nil, err
+		}
+
+		err = module.parseSection(sectionID, &wasmReader{data: sectionData})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(module.functions) != len(module.bodies) {
+		return nil, fmt.Errorf("%w: function and code sections differ", arwen.ErrMalformedContractCode)
+	}
+
+	return module, nil
+}
+
+func (module *wasmModule) parseSection(sectionID byte, reader *wasmReader) error {
+	var err error
+	switch sectionID {
+	case wasmSectionCustom, wasmSectionStart, wasmSectionElement, wasmSectionDataCount:
+		return nil
+	case wasmSectionType:
+		err = module.parseTypes(reader)
+	case wasmSectionImport:
+		err = module.parseImports(reader)
+	case wasmSectionFunction:
+		module.functions, err = reader.readU32Vector()
+	case wasmSectionTable:
+		err = module.parseTables(reader)
+	case wasmSectionMemory:
+		err = module.parseMemories(reader)
+	case wasmSectionGlobal:
+		module.numGlobals, err = reader.readU32()
+	case wasmSectionExport:
+		err = module.parseExports(reader)
+	case wasmSectionCode:
+		err = module.parseCode(reader)
+	case wasmSectionData:
+		module.numDataSegments, err = reader.readU32()
+	default:
+		return fmt.Errorf("%w: unknown section %d", arwen.ErrMalformedContractCode, sectionID)
+	}
+
+	return err
+}
+
+func (module *wasmModule) parseTypes(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	module.types = make([]wasmFunctionSignature, 0)
+	for i := uint32(0); i < count; i++ {
+		form, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		if form != wasmFunctionType {
+			return fmt.Errorf("%w: invalid function type", arwen.ErrMalformedContractCode)
+		}
+
+		params, err := reader.readByteVector()
+		if err != nil {
+			return err
+		}
+
+		results, err := reader.readByteVector()
+		if err != nil {
+			return err
+		}
+
+		module.types = append(module.types, wasmFunctionSignature{params: params, results: results})
+	}
+
+	return nil
+}
+
+func (module *wasmModule) parseImports(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	module.imports = make([]wasmImport, 0)
+	for i := uint32(0); i < count; i++ {
+		entry := wasmImport{}
+		entry.module, err = reader.readName()
+		if err != nil {
+			return err
+		}
+
+		entry.name, err = reader.readName()
+		if err != nil {
+			return err
+		}
+
+		entry.kind, err = reader.readByte()
+		if err != nil {
+			return err
+		}
+
+		switch entry.kind {
+		case wasmExternalFunction:
+			entry.typeIndex, err = reader.readU32()
+		case wasmExternalTable:
+			err = module.parseTable(reader)
+		case wasmExternalMemory:
+			err = module.parseMemory(reader)
+		case wasmExternalGlobal:
+			_, err = reader.readBytes(2)
+		default:
+			err = fmt.Errorf("%w: invalid import kind", arwen.ErrMalformedContractCode)
+		}
+		if err != nil {
+			return err
+		}
+
+		module.imports = append(module.imports, entry)
+	}
+
+	return nil
+}
+
+func (module *wasmModule) parseTables(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		err = module.parseTable(reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseTable decodes the type of a table, either imported or defined by the
+// module, which follow the imported ones
+func (module *wasmModule) parseTable(reader *wasmReader) error {
+	_, err := reader.readByte()
+	if err != nil {
+		return err
+	}
+
+	limits, err := reader.readLimits()
+	if err != nil {
+		return err
+	}
+
+	module.tables = append(module.tables, limits)
+	return nil
+}
+
+func (module *wasmModule) parseMemories(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		err = module.parseMemory(reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseMemory decodes the type of a memory, either imported or defined by
+// the module, which follow the imported ones
+func (module *wasmModule) parseMemory(reader *wasmReader) error {
+	limits, err := reader.readLimits()
+	if err != nil {
+		return err
+	}
+
+	module.memories = append(module.memories, limits)
+	return nil
+}
+
+func (module *wasmModule) parseExports(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	module.exports = make([]wasmExport, 0)
+	for i := uint32(0); i < count; i++ {
+		entry := wasmExport{}
+		entry.name, err = reader.readName()
+		if err != nil {
+			return err
+		}
+
+		entry.kind, err = reader.readByte()
+		if err != nil {
+			return err
+		}
+
+		entry.index, err = reader.readU32()
+		if err != nil {
+			return err
+		}
+
+		module.exports = append(module.exports, entry)
+	}
+
+	return nil
+}
+
+func (module *wasmModule) parseCode(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	module.bodies = make([]wasmFunctionBody, 0)
+	for i := uint32(0); i < count; i++ {
+		bodySize, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+
+		bodyData, err := reader.readBytes(bodySize)
+		if err != nil {
+			return err
+		}
+
+		body, err := parseFunctionBody(&wasmReader{data: bodyData})
+		if err != nil {
+			return err
+		}
+
+		module.bodies = append(module.bodies, body)
+	}
+
+	return nil
+}
+
+func parseFunctionBody(reader *wasmReader) (wasmFunctionBody, error) {
+	body := wasmFunctionBody{locals: make([]wasmLocals, 0)}
+
+	numDeclarations, err := reader.readU32()
+	if err != nil {
+		return body, err
+	}
+
+	for i := uint32(0); i < numDeclarations; i++ {
+		numLocals, err := reader.readU32()
+		if err != nil {
+			return body, err
+		}
+
+		valueType, err := reader.readByte()
+		if err != nil {
+			return body, err
+		}
+
+		body.locals = append(body.locals, wasmLocals{count: numLocals, valueType: valueType})
+		body.numLocals += uint64(numLocals)
+	}
+
+	body.code = reader.remaining()
+	return body, nil
+}
+
+// numImportedFunctions returns the number of functions imported by the module,
+// which precede the functions it defines in the function index space
+func (module *wasmModule) numImportedFunctions() uint32 {
+	count := uint32(0)
+	for _, entry := range module.imports {
+		if entry.kind == wasmExternalFunction {
+			count++
+		}
+	}
+	return count
+}
+
+// functionSignature returns the signature of the function with the given
+// index, counting imported functions first
+func (module *wasmModule) functionSignature(functionIndex uint32) (*wasmFunctionSignature, error) {
+	typeIndex, err := module.functionTypeIndex(functionIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	if typeIndex >= uint32(len(module.types)) {
+		return nil, fmt.Errorf("%w: type index out of range", arwen.ErrMalformedContractCode)
+	}
+
+	return &module.types[typeIndex], nil
+}
+
+func (module *wasmModule) functionTypeIndex(functionIndex uint32) (uint32, error) {
+	for _, entry := range module.imports {
+		if entry.kind != wasmExternalFunction {
+			continue
+		}
+		if functionIndex == 0 {
+			return entry.typeIndex, nil
+		}
+		functionIndex--
+	}
+
+	if functionIndex >= uint32(len(module.functions)) {
+		return 0, fmt.Errorf("%w: function index out of range", arwen.ErrMalformedContractCode)
+	}
+
+	return module.functions[functionIndex], nil
+}
+
+// wasmReader decodes the primitive values of the WASM binary format
+type wasmReader struct {
+	data   []byte
+	offset int
+}
+
+func (reader *wasmReader) done() bool {
+	return reader.offset >= len(reader.data)
+}
+
+func (reader *wasmReader) remaining() []byte {
+	return reader.data[reader.offset:]
+}
+
+func (reader *wasmReader) readByte() (byte, error) {
+	if reader.done() {
+		return 0, fmt.Errorf("%w: unexpected end", arwen.ErrMalformedContractCode)
+	}
+
+	value := reader.data[reader.offset]
+	reader.offset++
+	return value, nil
+}
+
+func (reader *wasmReader) readBytes(length uint32) ([]byte, error) {
+	if uint64(length) > uint64(len(reader.data)-reader.offset) {
+		return nil, fmt.Errorf("%w: unexpected end", arwen.ErrMalformedContractCode)
+	}
+
+	value := reader.data[reader.offset : reader.offset+int(length)]
+	reader.offset += int(length)
+	return value, nil
+}
+
+// readU32 decodes an unsigned LEB128 integer of at most 32 bits; its fifth
+// byte, if any, may only hold the 4 most significant bits
+func (reader *wasmReader) readU32() (uint32, error) {
+	result := uint32(0)
+	for shift := uint(0); shift < 35; shift += 7 {
+		value, err := reader.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if shift == 28 && value&0xF0 != 0 {
+			break
+		}
+
+		result |= uint32(value&0x7F) << shift
+		if value&0x80 == 0 {
+			return result, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: integer too large", arwen.ErrMalformedContractCode)
+}
+
+func (reader *wasmReader) readU32Vector() ([]uint32, error) {
+	count, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+	if int(count) > len(reader.data)-reader.offset {
+		return nil, fmt.Errorf("%w: unexpected end", arwen.ErrMalformedContractCode)
+	}
+
+	values := make([]uint32, 0, count)
+	for i := uint32(0); i < count; i++ {
+		value, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func (reader *wasmReader) readByteVector() ([]byte, error) {
+	length, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+
+	return reader.readBytes(length)
+}
+
+func (reader *wasmReader) readName() (string, error) {
+	name, err := reader.readByteVector()
+	if err != nil {
+		return "", err
+	}
+
+	return string(name), nil
+}
+
+func (reader *wasmReader) readLimits() (wasmLimits, error) {
+	limits := wasmLimits{}
+	flags, err := reader.readByte()
+	if err != nil {
+		return limits, err
+	}
+
+	limits.min, err = reader.readU32()
+	if err != nil {
+		return limits, err
+	}
+
+	if flags&1 != 0 {
+		limits.hasMax = true
+		limits.max, err = reader.readU32()
+	}
+
+	return limits, err
+}
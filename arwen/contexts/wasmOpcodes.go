@@ -0,0 +1,231 @@
+package contexts
+
+import (
+	"fmt"
+
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/kalyan3104/dme-vm-go/wasmer"
+)
+
+// wasmImmediate describes the immediate arguments following an opcode in the
+// WASM binary format
+type wasmImmediate int
+
+const (
+	immediateNone wasmImmediate = iota
+	immediateBlockType
+	immediateU32
+	immediateTwoU32
+	immediateBrTable
+	immediateMemArg
+	immediateByte
+	immediateTwoBytes
+	immediateI32
+	immediateI64
+	immediateF32
+	immediateF64
+	immediateValueTypes
+	immediateDataIndexAndByte
+)
+
+// wasmInstruction associates an encoded opcode with its wasmer opcode and the
+// immediates which follow it
+type wasmInstruction struct {
+	opcode    int
+	immediate wasmImmediate
+}
+
+// wasmMiscPrefix introduces the saturating truncation and bulk memory
+// instructions, encoded as a prefix byte followed by a u32 opcode
+const wasmMiscPrefix = 0xFC
+
+var wasmInstructions = makeWASMInstructionTable()
+
+var wasmMiscInstructions = map[uint32]wasmInstruction{
+	0:  {wasmer.OpcodeI32TruncSatF32S, immediateNone},
+	1:  {wasmer.OpcodeI32TruncSatF32U, immediateNone},
+	2:  {wasmer.OpcodeI32TruncSatF64S, immediateNone},
+	3:  {wasmer.OpcodeI32TruncSatF64U, immediateNone},
+	4:  {wasmer.OpcodeI64TruncSatF32S, immediateNone},
+	5:  {wasmer.OpcodeI64TruncSatF32U, immediateNone},
+	6:  {wasmer.OpcodeI64TruncSatF64S, immediateNone},
+	7:  {wasmer.OpcodeI64TruncSatF64U, immediateNone},
+	8:  {wasmer.OpcodeMemoryInit, immediateDataIndexAndByte},
+	9:  {wasmer.OpcodeDataDrop, immediateU32},
+	10: {wasmer.OpcodeMemoryCopy, immediateTwoBytes},
+	11: {wasmer.OpcodeMemoryFill, immediateByte},
+	12: {wasmer.OpcodeTableInit, immediateTwoU32},
+	13: {wasmer.OpcodeElemDrop, immediateU32},
+	14: {wasmer.OpcodeTableCopy, immediateTwoU32},
+	15: {wasmer.OpcodeTableGrow, immediateU32},
+	16: {wasmer.OpcodeTableSize, immediateU32},
+	17: {wasmer.OpcodeTableFill, immediateU32},
+}
+
+// makeWASMInstructionTable maps the single-byte encodings of the instructions
+// to the wasmer opcodes; the numeric instructions are encoded in the same
+// order as the wasmer opcodes are declared
+func makeWASMInstructionTable() map[byte]wasmInstruction {
+	table := map[byte]wasmInstruction{
+		0x00: {wasmer.OpcodeUnreachable, immediateNone},
+		0x01: {wasmer.OpcodeNop, immediateNone},
+		0x02: {wasmer.OpcodeBlock, immediateBlockType},
+		0x03: {wasmer.OpcodeLoop, immediateBlockType},
+		0x04: {wasmer.OpcodeIf, immediateBlockType},
+		0x05: {wasmer.OpcodeElse, immediateNone},
+		0x0B: {wasmer.OpcodeEnd, immediateNone},
+		0x0C: {wasmer.OpcodeBr, immediateU32},
+		0x0D: {wasmer.OpcodeBrIf, immediateU32},
+		0x0E: {wasmer.OpcodeBrTable, immediateBrTable},
+		0x0F: {wasmer.OpcodeReturn, immediateNone},
+		0x10: {wasmer.OpcodeCall, immediateU32},
+		0x11: {wasmer.OpcodeCallIndirect, immediateTwoU32},
+		0x1A: {wasmer.OpcodeDrop, immediateNone},
+		0x1B: {wasmer.OpcodeSelect, immediateNone},
+		0x1C: {wasmer.OpcodeTypedSelect, immediateValueTypes},
+		0x20: {wasmer.OpcodeLocalGet, immediateU32},
+		0x21: {wasmer.OpcodeLocalSet, immediateU32},
+		0x22: {wasmer.OpcodeLocalTee, immediateU32},
+		0x23: {wasmer.OpcodeGlobalGet, immediateU32},
+		0x24: {wasmer.OpcodeGlobalSet, immediateU32},
+		0x25: {wasmer.OpcodeTableGet, immediateU32},
+		0x26: {wasmer.OpcodeTableSet, immediateU32},
+		0x3F: {wasmer.OpcodeMemorySize, immediateByte},
+		0x40: {wasmer.OpcodeMemoryGrow, immediateByte},
+		0x41: {wasmer.OpcodeI32Const, immediateI32},
+		0x42: {wasmer.OpcodeI64Const, immediateI64},
+		0x43: {wasmer.OpcodeF32Const, immediateF32},
+		0x44: {wasmer.OpcodeF64Const, immediateF64},
+		0xD0: {wasmer.OpcodeRefNull, immediateByte},
+		0xD1: {wasmer.OpcodeRefIsNull, immediateNone},
+		0xD2: {wasmer.OpcodeRefFunc, immediateU32},
+	}
+
+	for encoding := 0x28; encoding <= 0x3E; encoding++ {
+		table[byte(encoding)] = wasmInstruction{wasmer.OpcodeI32Load + encoding - 0x28, immediateMemArg}
+	}
+	for encoding := 0x45; encoding <= 0xC4; encoding++ {
+		table[byte(encoding)] = wasmInstruction{wasmer.OpcodeI32Eqz + encoding - 0x45, immediateNone}
+	}
+
+	return table
+}
+
+// isFloatingPointOpcode returns whether the opcode performs floating-point
+// arithmetic, converts from or to a floating-point value or reinterprets its
+// bits; the results of these operations may differ across platforms, unlike
+// those of the floating-point constants, loads, stores and comparisons
+func isFloatingPointOpcode(opcode int) bool {
+	switch {
+	case opcode >= wasmer.OpcodeF32Abs && opcode <= wasmer.OpcodeF64Copysign:
+		return true
+	case opcode >= wasmer.OpcodeI32TruncF32S && opcode <= wasmer.OpcodeI32TruncF64U:
+		return true
+	case opcode >= wasmer.OpcodeI64TruncF32S && opcode <= wasmer.OpcodeF64ReinterpretI64:
+		return true
+	case opcode >= wasmer.OpcodeI32TruncSatF32S && opcode <= wasmer.OpcodeI64TruncSatF64U:
+		return true
+	}
+	return false
+}
+
+// forEachOpcode decodes the instructions of a function body, calling visit
+// with the wasmer opcode of each of them
+func forEachOpcode(code []byte, visit func(opcode int) error) error {
+	reader := &wasmReader{data: code}
+	for !reader.done() {
+		encoding, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+
+		instruction, ok := wasmInstructions[encoding]
+		if encoding == wasmMiscPrefix {
+			var miscEncoding uint32
+			miscEncoding, err = reader.readU32()
+			if err != nil {
+				return err
+			}
+			instruction, ok = wasmMiscInstructions[miscEncoding]
+		}
+		if !ok {
+			return fmt.Errorf("%w: unknown opcode 0x%02X", arwen.ErrMalformedContractCode, encoding)
+		}
+
+		err = reader.skipImmediate(instruction.immediate)
+		if err != nil {
+			return err
+		}
+
+		err = visit(instruction.opcode)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (reader *wasmReader) skipImmediate(immediate wasmImmediate) error {
+	var err error
+	switch immediate {
+	case immediateNone:
+	case immediateBlockType:
+		_, err = reader.readSignedLEB(33)
+	case immediateU32:
+		_, err = reader.readU32()
+	case immediateTwoU32, immediateMemArg:
+		_, err = reader.readU32()
+		if err == nil {
+			_, err = reader.readU32()
+		}
+	case immediateBrTable:
+		_, err = reader.readU32Vector()
+		if err == nil {
+			_, err = reader.readU32()
+		}
+	case immediateByte:
+		_, err = reader.readByte()
+	case immediateTwoBytes:
+		_, err = reader.readBytes(2)
+	case immediateI32:
+		_, err = reader.readSignedLEB(32)
+	case immediateI64:
+		_, err = reader.readSignedLEB(64)
+	case immediateF32:
+		_, err = reader.readBytes(4)
+	case immediateF64:
+		_, err = reader.readBytes(8)
+	case immediateValueTypes:
+		_, err = reader.readByteVector()
+	case immediateDataIndexAndByte:
+		_, err = reader.readU32()
+		if err == nil {
+			_, err = reader.readByte()
+		}
+	}
+
+	return err
+}
+
+// readSignedLEB decodes a signed LEB128 integer of at most the given number
+// of bits
+func (reader *wasmReader) readSignedLEB(bits uint) (int64, error) {
+	result := int64(0)
+	for shift := uint(0); shift < bits+7; shift += 7 {
+		value, err := reader.readByte()
+		if err != nil {
+			return 0, err
+		}
+
+		result |= int64(value&0x7F) << shift
+		if value&0x80 == 0 {
+			if shift+7 < 64 && value&0x40 != 0 {
+				result |= -1 << (shift + 7)
+			}
+			return result, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: integer too large", arwen.ErrMalformedContractCode)
+}
@@ -8,6 +8,10 @@ package crypto
 //
 // extern int32_t sha256(void* context, int32_t dataOffset, int32_t length, int32_t resultOffset);
 // extern int32_t keccak256(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
+// extern int32_t ripemd160(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
+// extern int32_t hashData(void *context, int32_t algorithmId, int32_t dataOffset, int32_t length, int32_t resultOffset);
+// extern int32_t verifyEd25519(void *context, int32_t keyOffset, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
+// extern int32_t verifyBLS(void *context, int32_t keyOffset, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
 import "C"
 
 import (
@@ -17,6 +21,14 @@ import (
 	"github.com/kalyan3104/dme-vm-go/wasmer"
 )
 
+// Hash algorithms selectable by the algorithmId argument of hashData, which
+// returns the length of the hash it writes, or -1 on failure
+const (
+	HashAlgorithmSHA256    = 1
+	HashAlgorithmKeccak256 = 2
+	HashAlgorithmRipemd160 = 3
+)
+
 func CryptoImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 	imports = imports.Namespace("env")
 	imports, err := imports.Append("sha256", sha256, C.sha256)
@@ -29,19 +41,45 @@ func CryptoImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("ripemd160", ripemd160, C.ripemd160)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("hashData", hashData, C.hashData)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("verifyEd25519", verifyEd25519, C.verifyEd25519)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("verifyBLS", verifyBLS, C.verifyBLS)
+	if err != nil {
+		return nil, err
+	}
+
 	return imports, nil
 }
 
 //export sha256
 func sha256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "sha256", int64(dataOffset), int64(length), int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
 
 	data, err := runtime.MemLoad(dataOffset, length)
 	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
 		return 1
 	}
 
+	gasToUse := metering.GasSchedule().CryptoAPICost.SHA256
+	metering.UseGas(gasToUse)
+
 	result, err := crypto.Sha256(data)
 	if err != nil {
 		return 1
@@ -52,24 +90,57 @@ func sha256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset
 		return 1
 	}
 
+	return 0
+}
+
+//export keccak256
+func keccak256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "keccak256", int64(dataOffset), int64(length), int64(resultOffset))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
 	metering := arwen.GetMeteringContext(context)
+
+	data, err := runtime.MemLoad(dataOffset, length)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	// charged as SHA256, as deployed contracts have always been
 	gasToUse := metering.GasSchedule().CryptoAPICost.SHA256
 	metering.UseGas(gasToUse)
 
+	result, err := crypto.Keccak256(data)
+	if err != nil {
+		return 1
+	}
+
+	err = runtime.MemStore(resultOffset, result)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
 	return 0
 }
 
-//export keccak256
-func keccak256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+//export ripemd160
+func ripemd160(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "ripemd160", int64(dataOffset), int64(length), int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
 
 	data, err := runtime.MemLoad(dataOffset, length)
 	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
 		return 1
 	}
 
-	result, err := crypto.Keccak256(data)
+	gasSchedule := metering.GasSchedule().CryptoAPICost
+	gasToUse := gasSchedule.Ripemd160 + gasSchedule.HashPerByte*uint64(length)
+	metering.UseGas(gasToUse)
+
+	result, err := crypto.Ripemd160(data)
 	if err != nil {
 		return 1
 	}
@@ -79,9 +150,122 @@ func keccak256(context unsafe.Pointer, dataOffset int32, length int32, resultOff
 		return 1
 	}
 
+	return 0
+}
+
+//export hashData
+func hashData(context unsafe.Pointer, algorithmId int32, dataOffset int32, length int32, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "hashData", int64(algorithmId), int64(dataOffset), int64(length), int64(resultOffset))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
 	metering := arwen.GetMeteringContext(context)
-	gasToUse := metering.GasSchedule().CryptoAPICost.SHA256
+	gasSchedule := metering.GasSchedule().CryptoAPICost
+
+	var hash func([]byte) ([]byte, error)
+	var algorithmCost uint64
+	switch algorithmId {
+	case HashAlgorithmSHA256:
+		hash = crypto.Sha256
+		algorithmCost = gasSchedule.SHA256
+	case HashAlgorithmKeccak256:
+		hash = crypto.Keccak256
+		algorithmCost = gasSchedule.Keccak256
+	case HashAlgorithmRipemd160:
+		hash = crypto.Ripemd160
+		algorithmCost = gasSchedule.Ripemd160
+	default:
+		arwen.WithFault(arwen.ErrUnknownHashAlgorithm, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return -1
+	}
+
+	data, err := runtime.MemLoad(dataOffset, length)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := gasSchedule.HashData + algorithmCost + gasSchedule.HashPerByte*uint64(length)
+	metering.UseGas(gasToUse)
+
+	result, err := hash(data)
+	if err != nil {
+		return -1
+	}
+
+	err = runtime.MemStore(resultOffset, result)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(result))
+}
+
+//export verifyEd25519
+func verifyEd25519(context unsafe.Pointer, keyOffset int32, messageOffset int32, messageLength int32, sigOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "verifyEd25519", int64(keyOffset), int64(messageOffset), int64(messageLength), int64(sigOffset))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	key, err := runtime.MemLoad(keyOffset, Ed25519PublicKeyLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	message, err := runtime.MemLoad(messageOffset, messageLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	signature, err := runtime.MemLoad(sigOffset, Ed25519SignatureLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasSchedule := metering.GasSchedule().CryptoAPICost
+	gasToUse := gasSchedule.VerifyEd25519 + gasSchedule.HashPerByte*uint64(messageLength)
 	metering.UseGas(gasToUse)
 
+	verifier := arwen.GetSignatureVerifier(context)
+	err = verifier.VerifyEd25519(key, message, signature)
+	if err != nil {
+		return 1
+	}
+
+	return 0
+}
+
+//export verifyBLS
+func verifyBLS(context unsafe.Pointer, keyOffset int32, messageOffset int32, messageLength int32, sigOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "verifyBLS", int64(keyOffset), int64(messageOffset), int64(messageLength), int64(sigOffset))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	key, err := runtime.MemLoad(keyOffset, BLSPublicKeyLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	message, err := runtime.MemLoad(messageOffset, messageLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	signature, err := runtime.MemLoad(sigOffset, BLSSignatureLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasSchedule := metering.GasSchedule().CryptoAPICost
+	gasToUse := gasSchedule.VerifyBLS + gasSchedule.HashPerByte*uint64(messageLength)
+	metering.UseGas(gasToUse)
+
+	verifier := arwen.GetSignatureVerifier(context)
+	err = verifier.VerifyBLS(key, message, signature)
+	if err != nil {
+		return 1
+	}
+
 	return 0
 }
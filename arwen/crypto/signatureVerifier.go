@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"errors"
+
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+// The verifyEd25519 and verifyBLS EI functions load keys and signatures of
+// the lengths below from the contract memory, so any arwen.SignatureVerifier
+// given through the host parameters must verify these same schemes:
+//  - Ed25519, as specified by RFC 8032
+//  - BLS12-381 with public keys in G1 and signatures in G2, both compressed,
+//    hashing messages to G2 with the BLSDomain separation tag
+
+// Ed25519PublicKeyLength is the length of the Ed25519 public keys accepted by verifyEd25519
+const Ed25519PublicKeyLength = ed25519.PublicKeySize
+
+// Ed25519SignatureLength is the length of the Ed25519 signatures accepted by verifyEd25519
+const Ed25519SignatureLength = ed25519.SignatureSize
+
+// BLSPublicKeyLength is the length of the compressed G1 public keys accepted by verifyBLS
+const BLSPublicKeyLength = 48
+
+// BLSSignatureLength is the length of the compressed G2 signatures accepted by verifyBLS
+const BLSSignatureLength = 96
+
+// BLSDomain is the domain separation tag with which messages are hashed to
+// G2, that of the basic BLS12-381 scheme with public keys in G1
+var BLSDomain = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
+
+// ErrInvalidPublicKey signals that a public key could not be decoded
+var ErrInvalidPublicKey = errors.New("invalid public key")
+
+// ErrInvalidSignature signals that a signature could not be decoded, or that
+// it is not a signature of the message with the public key
+var ErrInvalidSignature = errors.New("invalid signature")
+
+var _ arwen.SignatureVerifier = (*signatureVerifier)(nil)
+
+type signatureVerifier struct {
+}
+
+// NewSignatureVerifier creates a new arwen.SignatureVerifier
+func NewSignatureVerifier() *signatureVerifier {
+	return &signatureVerifier{}
+}
+
+// VerifyEd25519 verifies an Ed25519 signature of the message
+func (verifier *signatureVerifier) VerifyEd25519(key []byte, message []byte, signature []byte) error {
+	if len(key) != Ed25519PublicKeyLength {
+		return ErrInvalidPublicKey
+	}
+	if len(signature) != Ed25519SignatureLength {
+		return ErrInvalidSignature
+	}
+
+	if !ed25519.Verify(key, message, signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// VerifyBLS verifies a BLS12-381 signature of the message, given a compressed
+// G1 public key and a compressed G2 signature
+func (verifier *signatureVerifier) VerifyBLS(key []byte, message []byte, signature []byte) error {
+	if len(key) != BLSPublicKeyLength {
+		return ErrInvalidPublicKey
+	}
+	if len(signature) != BLSSignatureLength {
+		return ErrInvalidSignature
+	}
+
+	g1 := bls12381.NewG1()
+	publicKey, err := g1.FromCompressed(key)
+	if err != nil || g1.IsZero(publicKey) {
+		return ErrInvalidPublicKey
+	}
+
+	g2 := bls12381.NewG2()
+	signaturePoint, err := g2.FromCompressed(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	messagePoint, err := g2.HashToCurve(message, BLSDomain)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	engine := bls12381.NewEngine()
+	engine.AddPair(publicKey, messagePoint)
+	engine.AddPairInv(g1.One(), signaturePoint)
+	if !engine.Check() {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.Nil(t, err)
+	return b
+}
+
+// test 2 of RFC 8032, section 7.1
+const ed25519PublicKeyHex = "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c"
+const ed25519SignatureHex = "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00"
+
+var ed25519Message = []byte{0x72}
+
+const blsPublicKeyHex = "95690ac4d432da7b8f2793f9146ffc3a531b11ad4938d147b4b428bd565f597f04063ddab04362704221a2ccddde8cbd"
+const blsSignatureHex = "b41835ad61af1592bc396041f51d045e2516b7cc9dd616ad61b5499b267e27d5d82f040c729dc2af6f14d0155e2c9c2b1881785c99643f13a1e26e928040adb1fbf230b53364e7b2eb94e536e6b04bc7458878e788ee03e7c0a04a34f09fdafd"
+
+var blsMessage = []byte("oracle price feed: EGLD/USD 1024")
+
+func TestSignatureVerifier_Ed25519(t *testing.T) {
+	verifier := NewSignatureVerifier()
+	key := decodeHex(t, ed25519PublicKeyHex)
+	signature := decodeHex(t, ed25519SignatureHex)
+
+	require.Nil(t, verifier.VerifyEd25519(key, ed25519Message, signature))
+
+	require.Equal(t, ErrInvalidSignature, verifier.VerifyEd25519(key, []byte{0x73}, signature))
+
+	tamperedSignature := append([]byte{}, signature...)
+	tamperedSignature[0] ^= 1
+	require.Equal(t, ErrInvalidSignature, verifier.VerifyEd25519(key, ed25519Message, tamperedSignature))
+
+	require.Equal(t, ErrInvalidPublicKey, verifier.VerifyEd25519(key[1:], ed25519Message, signature))
+	require.Equal(t, ErrInvalidSignature, verifier.VerifyEd25519(key, ed25519Message, signature[1:]))
+}
+
+func TestSignatureVerifier_BLS(t *testing.T) {
+	verifier := NewSignatureVerifier()
+	key := decodeHex(t, blsPublicKeyHex)
+	signature := decodeHex(t, blsSignatureHex)
+
+	require.Nil(t, verifier.VerifyBLS(key, blsMessage, signature))
+
+	require.Equal(t, ErrInvalidSignature, verifier.VerifyBLS(key, []byte("oracle price feed: EGLD/USD 1025"), signature))
+
+	tamperedSignature := append([]byte{}, signature...)
+	tamperedSignature[95] ^= 1
+	require.Equal(t, ErrInvalidSignature, verifier.VerifyBLS(key, blsMessage, tamperedSignature))
+
+	tamperedKey := append([]byte{}, key...)
+	tamperedKey[47] ^= 1
+	require.Equal(t, ErrInvalidPublicKey, verifier.VerifyBLS(tamperedKey, blsMessage, signature))
+
+	require.Equal(t, ErrInvalidPublicKey, verifier.VerifyBLS(key[1:], blsMessage, signature))
+	require.Equal(t, ErrInvalidSignature, verifier.VerifyBLS(key, blsMessage, signature[1:]))
+}
+
+func TestSignatureVerifier_BLS_RejectsPointAtInfinity(t *testing.T) {
+	verifier := NewSignatureVerifier()
+	infinityKey := make([]byte, BLSPublicKeyLength)
+	infinityKey[0] = 0xc0
+	infinitySignature := make([]byte, BLSSignatureLength)
+	infinitySignature[0] = 0xc0
+
+	require.Equal(t, ErrInvalidPublicKey, verifier.VerifyBLS(infinityKey, blsMessage, infinitySignature))
+}
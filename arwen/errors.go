@@ -37,10 +37,16 @@ var ErrFailedTransferDuringAsyncCall = fmt.Errorf("%w (failed during async call)
 
 var ErrTransferNegativeValue = fmt.Errorf("%w (negative value)", ErrFailedTransfer)
 
+var ErrNonPayableContract = fmt.Errorf("%w (contract is not payable)", ErrFailedTransfer)
+
 var ErrUpgradeFailed = errors.New("upgrade failed")
 
 var ErrInvalidUpgradeArguments = fmt.Errorf("%w (invalid arguments)", ErrUpgradeFailed)
 
+var ErrUpgradeNotAllowed = fmt.Errorf("%w (caller is not the owner)", ErrUpgradeFailed)
+
+var ErrContractNotUpgradeable = fmt.Errorf("%w (contract is not upgradeable)", ErrUpgradeFailed)
+
 var ErrInvalidFunction = errors.New("invalid function")
 
 var ErrInitFuncCalledInRun = fmt.Errorf("%w (calling init() directly is forbidden)", ErrInvalidFunction)
@@ -55,14 +61,58 @@ var ErrInvalidFunctionName = fmt.Errorf("%w (invalid name)", ErrInvalidFunction)
 
 var ErrFunctionNonvoidSignature = fmt.Errorf("%w (nonvoid signature)", ErrInvalidFunction)
 
+var ErrMisspelledEntrypoint = fmt.Errorf("%w (misspelled entrypoint)", ErrInvalidFunctionName)
+
+var ErrDuplicateExportName = fmt.Errorf("%w (duplicate export)", ErrInvalidFunctionName)
+
 var ErrContractInvalid = fmt.Errorf("invalid contract code")
 
 var ErrContractNotFound = fmt.Errorf("%w (not found)", ErrContractInvalid)
 
 var ErrMemoryDeclarationMissing = fmt.Errorf("%w (missing memory declaration)", ErrContractInvalid)
 
+var ErrInvalidCodeMetadata = fmt.Errorf("%w (invalid code metadata)", ErrContractInvalid)
+
+var ErrMalformedContractCode = fmt.Errorf("%w (malformed bytecode)", ErrContractInvalid)
+
+var ErrTooManyFunctions = fmt.Errorf("%w (too many functions)", ErrContractInvalid)
+
+var ErrTooManyLocals = fmt.Errorf("%w (too many locals)", ErrContractInvalid)
+
+var ErrTooManyGlobals = fmt.Errorf("%w (too many globals)", ErrContractInvalid)
+
+var ErrTableTooLarge = fmt.Errorf("%w (table too large)", ErrContractInvalid)
+
+var ErrTooManyDataSegments = fmt.Errorf("%w (too many data segments)", ErrContractInvalid)
+
+var ErrMemoryTooLarge = fmt.Errorf("%w (too many memory pages)", ErrContractInvalid)
+
+var ErrImportNotAllowed = fmt.Errorf("%w (import not allowed)", ErrContractInvalid)
+
+var ErrFloatingPointNotAllowed = fmt.Errorf("%w (floating-point instruction)", ErrContractInvalid)
+
+var ErrEndpointNotExported = fmt.Errorf("%w (endpoint not exported)", ErrContractInvalid)
+
+var ErrEndpointNotDeclared = fmt.Errorf("%w (endpoint not declared)", ErrContractInvalid)
+
 var ErrMaxInstancesReached = fmt.Errorf("%w (max instances reached)", ErrExecutionFailed)
 
+var ErrMaxMemoryPagesReached = fmt.Errorf("%w (max memory pages reached)", ErrExecutionFailed)
+
+var ErrMaxCallDepthReached = fmt.Errorf("%w (max call depth reached)", ErrExecutionFailed)
+
+var ErrReentrantCall = fmt.Errorf("%w (reentrant call to non-reentrant contract)", ErrExecutionFailed)
+
+var ErrInvalidAsyncContextInfo = errors.New("invalid async context info")
+
+var ErrUnknownAsyncContextInfoVersion = fmt.Errorf("%w (unknown version)", ErrInvalidAsyncContextInfo)
+
+var ErrInvalidAsyncContextDeadline = errors.New("invalid async context deadline")
+
+var ErrAsyncCallExpired = errors.New("async call expired")
+
+var ErrMaxAsyncCallbackDepthReached = fmt.Errorf("%w (max async callback depth reached)", ErrExecutionFailed)
+
 var ErrStoreKalyan3104ReservedKey = errors.New("cannot write to storage under Kalyan3104 reserved key")
 
 var ErrArgIndexOutOfRange = errors.New("argument index out of range")
@@ -80,3 +130,35 @@ var ErrAsyncContextDoesNotExist = errors.New("async context was not created yet"
 var ErrAsync = errors.New("invalid gas percentage provided for async call")
 
 var ErrInvalidAccount = errors.New("account does not exist")
+
+var ErrUnknownHashAlgorithm = errors.New("unknown hash algorithm")
+
+var ErrNoManagedBufferUnderThisHandle = errors.New("no managed buffer under the given handle")
+
+var ErrNegativeExponent = errors.New("exponentiation only allowed with a positive exponent")
+
+var ErrSqrtNegative = errors.New("square root only allowed on positive integers")
+
+var ErrLog2NonPositive = errors.New("logarithm only allowed on strictly positive integers")
+
+var ErrNonPositiveModulus = errors.New("modular operations only allowed with a strictly positive modulus")
+
+var ErrNoModInverse = errors.New("modular inverse does not exist")
+
+var ErrBigFloatDecimalsOutOfRange = errors.New("number of decimals out of range")
+
+var ErrTooManyLogTopics = errors.New("too many log topics")
+
+var ErrLogTooLarge = errors.New("log too large")
+
+var ErrTransactionLogsLimitExceeded = errors.New("logs limit of the transaction exceeded")
+
+var ErrStorageReadFromOtherShard = errors.New("cannot read the storage of an account from another shard")
+
+var ErrStorageKeyNotReadable = errors.New("protected storage key of an account which is not readable")
+
+var ErrStorageKeyTooLargeForBuffer = errors.New("storage key does not fit in the result buffer")
+
+var ErrInvalidTokenTransfer = errors.New("invalid token transfer")
+
+var ErrTokenTransferNotAvailable = errors.New("token transfer built-in function not available")
@@ -223,6 +223,8 @@ func EthereumImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 
 //export ethuseGas
 func ethuseGas(context unsafe.Pointer, useGas int64) {
+	defer arwen.TraceAPICall(context, "ethuseGas", useGas)()
+
 	metering := arwen.GetMeteringContext(context)
 
 	gasToUse := metering.GasSchedule().EthAPICost.UseGas + uint64(useGas)
@@ -231,6 +233,8 @@ func ethuseGas(context unsafe.Pointer, useGas int64) {
 
 //export ethgetAddress
 func ethgetAddress(context unsafe.Pointer, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "ethgetAddress", int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -245,6 +249,8 @@ func ethgetAddress(context unsafe.Pointer, resultOffset int32) {
 
 //export ethgetExternalBalance
 func ethgetExternalBalance(context unsafe.Pointer, addressOffset int32, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "ethgetExternalBalance", int64(addressOffset), int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -267,6 +273,8 @@ func ethgetExternalBalance(context unsafe.Pointer, addressOffset int32, resultOf
 
 //export ethgetBlockHash
 func ethgetBlockHash(context unsafe.Pointer, number int64, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "ethgetBlockHash", number, int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -289,6 +297,8 @@ func ethgetBlockHash(context unsafe.Pointer, number int64, resultOffset int32) i
 
 //export ethcallDataCopy
 func ethcallDataCopy(context unsafe.Pointer, resultOffset int32, dataOffset int32, length int32) {
+	defer arwen.TraceAPICall(context, "ethcallDataCopy", int64(resultOffset), int64(dataOffset), int64(length))()
+
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -311,6 +321,8 @@ func ethcallDataCopy(context unsafe.Pointer, resultOffset int32, dataOffset int3
 
 //export ethgetCallDataSize
 func ethgetCallDataSize(context unsafe.Pointer) int32 {
+	defer arwen.TraceAPICall(context, "ethgetCallDataSize")()
+
 	host := arwen.GetVmContext(context)
 	metering := host.Metering()
 
@@ -323,6 +335,8 @@ func ethgetCallDataSize(context unsafe.Pointer) int32 {
 
 //export ethstorageStore
 func ethstorageStore(context unsafe.Pointer, pathOffset int32, valueOffset int32) {
+	defer arwen.TraceAPICall(context, "ethstorageStore", int64(pathOffset), int64(valueOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 	storage := arwen.GetStorageContext(context)
@@ -348,6 +362,8 @@ func ethstorageStore(context unsafe.Pointer, pathOffset int32, valueOffset int32
 
 //export ethstorageLoad
 func ethstorageLoad(context unsafe.Pointer, pathOffset int32, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "ethstorageLoad", int64(pathOffset), int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 	storage := arwen.GetStorageContext(context)
@@ -375,6 +391,8 @@ func ethstorageLoad(context unsafe.Pointer, pathOffset int32, resultOffset int32
 
 //export ethgetCaller
 func ethgetCaller(context unsafe.Pointer, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "ethgetCaller", int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -390,6 +408,8 @@ func ethgetCaller(context unsafe.Pointer, resultOffset int32) {
 
 //export ethgetCallValue
 func ethgetCallValue(context unsafe.Pointer, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "ethgetCallValue", int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -412,6 +432,8 @@ func ethgetCallValue(context unsafe.Pointer, resultOffset int32) {
 
 //export ethcodeCopy
 func ethcodeCopy(context unsafe.Pointer, resultOffset int32, codeOffset int32, length int32) {
+	defer arwen.TraceAPICall(context, "ethcodeCopy", int64(resultOffset), int64(codeOffset), int64(length))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -439,6 +461,8 @@ func ethcodeCopy(context unsafe.Pointer, resultOffset int32, codeOffset int32, l
 
 //export ethgetCodeSize
 func ethgetCodeSize(context unsafe.Pointer) int32 {
+	defer arwen.TraceAPICall(context, "ethgetCodeSize")()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -456,6 +480,8 @@ func ethgetCodeSize(context unsafe.Pointer) int32 {
 
 //export ethexternalCodeCopy
 func ethexternalCodeCopy(context unsafe.Pointer, addressOffset int32, resultOffset int32, codeOffset int32, length int32) {
+	defer arwen.TraceAPICall(context, "ethexternalCodeCopy", int64(addressOffset), int64(resultOffset), int64(codeOffset), int64(length))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -487,6 +513,8 @@ func ethexternalCodeCopy(context unsafe.Pointer, addressOffset int32, resultOffs
 
 //export ethgetExternalCodeSize
 func ethgetExternalCodeSize(context unsafe.Pointer, addressOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "ethgetExternalCodeSize", int64(addressOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -509,6 +537,8 @@ func ethgetExternalCodeSize(context unsafe.Pointer, addressOffset int32) int32 {
 
 //export ethgetGasLeft
 func ethgetGasLeft(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "ethgetGasLeft")()
+
 	metering := arwen.GetMeteringContext(context)
 
 	gasToUse := metering.GasSchedule().EthAPICost.GetGasLeft
@@ -519,6 +549,8 @@ func ethgetGasLeft(context unsafe.Pointer) int64 {
 
 //export ethgetBlockGasLimit
 func ethgetBlockGasLimit(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "ethgetBlockGasLimit")()
+
 	metering := arwen.GetMeteringContext(context)
 
 	gasToUse := metering.GasSchedule().EthAPICost.GetBlockGasLimit
@@ -529,6 +561,8 @@ func ethgetBlockGasLimit(context unsafe.Pointer) int64 {
 
 //export ethgetTxGasPrice
 func ethgetTxGasPrice(context unsafe.Pointer, valueOffset int32) {
+	defer arwen.TraceAPICall(context, "ethgetTxGasPrice", int64(valueOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -549,6 +583,8 @@ func ethgetTxGasPrice(context unsafe.Pointer, valueOffset int32) {
 
 //export ethlogTopics
 func ethlogTopics(context unsafe.Pointer, dataOffset int32, length int32, numberOfTopics int32, topic1 int32, topic2 int32, topic3 int32, topic4 int32) {
+	defer arwen.TraceAPICall(context, "ethlogTopics", int64(dataOffset), int64(length), int64(numberOfTopics), int64(topic1), int64(topic2), int64(topic3), int64(topic4))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -580,11 +616,14 @@ func ethlogTopics(context unsafe.Pointer, dataOffset int32, length int32, number
 		}
 	}
 
-	output.WriteLog(runtime.GetSCAddress(), topicsData, data)
+	err = output.WriteLog(runtime.GetSCAddress(), topicsData, data)
+	arwen.WithFault(err, context, true)
 }
 
 //export ethgetTxOrigin
 func ethgetTxOrigin(context unsafe.Pointer, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "ethgetTxOrigin", int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -600,6 +639,8 @@ func ethgetTxOrigin(context unsafe.Pointer, resultOffset int32) {
 
 //export ethfinish
 func ethfinish(context unsafe.Pointer, resultOffset int32, length int32) {
+	defer arwen.TraceAPICall(context, "ethfinish", int64(resultOffset), int64(length))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -619,6 +660,8 @@ func ethfinish(context unsafe.Pointer, resultOffset int32, length int32) {
 
 //export ethrevert
 func ethrevert(context unsafe.Pointer, dataOffset int32, length int32) {
+	defer arwen.TraceAPICall(context, "ethrevert", int64(dataOffset), int64(length))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -640,6 +683,8 @@ func ethrevert(context unsafe.Pointer, dataOffset int32, length int32) {
 
 //export ethselfDestruct
 func ethselfDestruct(context unsafe.Pointer, addressOffset int32) {
+	defer arwen.TraceAPICall(context, "ethselfDestruct", int64(addressOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -658,6 +703,8 @@ func ethselfDestruct(context unsafe.Pointer, addressOffset int32) {
 
 //export ethgetBlockNumber
 func ethgetBlockNumber(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "ethgetBlockNumber")()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -669,6 +716,8 @@ func ethgetBlockNumber(context unsafe.Pointer) int64 {
 
 //export ethgetBlockTimestamp
 func ethgetBlockTimestamp(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "ethgetBlockTimestamp")()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -680,6 +729,8 @@ func ethgetBlockTimestamp(context unsafe.Pointer) int64 {
 
 //export ethgetReturnDataSize
 func ethgetReturnDataSize(context unsafe.Pointer) int32 {
+	defer arwen.TraceAPICall(context, "ethgetReturnDataSize")()
+
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -697,6 +748,8 @@ func ethgetReturnDataSize(context unsafe.Pointer) int32 {
 
 //export ethreturnDataCopy
 func ethreturnDataCopy(context unsafe.Pointer, resultOffset int32, dataOffset int32, length int32) {
+	defer arwen.TraceAPICall(context, "ethreturnDataCopy", int64(resultOffset), int64(dataOffset), int64(length))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -726,6 +779,8 @@ func ethreturnDataCopy(context unsafe.Pointer, resultOffset int32, dataOffset in
 
 //export ethgetBlockCoinbase
 func ethgetBlockCoinbase(context unsafe.Pointer, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "ethgetBlockCoinbase", int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -740,6 +795,8 @@ func ethgetBlockCoinbase(context unsafe.Pointer, resultOffset int32) {
 
 //export ethgetBlockDifficulty
 func ethgetBlockDifficulty(context unsafe.Pointer, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "ethgetBlockDifficulty", int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -754,6 +811,8 @@ func ethgetBlockDifficulty(context unsafe.Pointer, resultOffset int32) {
 
 //export ethcall
 func ethcall(context unsafe.Pointer, gasLimit int64, addressOffset int32, valueOffset int32, dataOffset int32, dataLength int32) int32 {
+	defer arwen.TraceAPICall(context, "ethcall", gasLimit, int64(addressOffset), int64(valueOffset), int64(dataOffset), int64(dataLength))()
+
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
 	output := host.Output()
@@ -810,6 +869,8 @@ func ethcall(context unsafe.Pointer, gasLimit int64, addressOffset int32, valueO
 
 //export ethcallCode
 func ethcallCode(context unsafe.Pointer, gasLimit int64, addressOffset int32, valueOffset int32, dataOffset int32, dataLength int32) int32 {
+	defer arwen.TraceAPICall(context, "ethcallCode", gasLimit, int64(addressOffset), int64(valueOffset), int64(dataOffset), int64(dataLength))()
+
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
 	output := host.Output()
@@ -865,6 +926,8 @@ func ethcallCode(context unsafe.Pointer, gasLimit int64, addressOffset int32, va
 
 //export ethcallDelegate
 func ethcallDelegate(context unsafe.Pointer, gasLimit int64, addressOffset int32, dataOffset int32, dataLength int32) int32 {
+	defer arwen.TraceAPICall(context, "ethcallDelegate", gasLimit, int64(addressOffset), int64(dataOffset), int64(dataLength))()
+
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
 	output := host.Output()
@@ -916,6 +979,8 @@ func ethcallDelegate(context unsafe.Pointer, gasLimit int64, addressOffset int32
 
 //export ethcallStatic
 func ethcallStatic(context unsafe.Pointer, gasLimit int64, addressOffset int32, dataOffset int32, dataLength int32) int32 {
+	defer arwen.TraceAPICall(context, "ethcallStatic", gasLimit, int64(addressOffset), int64(dataOffset), int64(dataLength))()
+
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
 	output := host.Output()
@@ -980,6 +1045,8 @@ func ethcallStatic(context unsafe.Pointer, gasLimit int64, addressOffset int32,
 
 //export ethcreate
 func ethcreate(context unsafe.Pointer, valueOffset int32, dataOffset int32, length int32, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "ethcreate", int64(valueOffset), int64(dataOffset), int64(length), int64(resultOffset))()
+
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
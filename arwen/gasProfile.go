@@ -0,0 +1,108 @@
+package arwen
+
+// Categories of the gas attributed by a GasProfile; the gas used by each EI
+// function has its own category, given by GasCategoryForAPI()
+const (
+	// GasCategoryOpcodes is the gas used by executing the opcodes of a contract
+	GasCategoryOpcodes = "opcodes"
+
+	// GasCategoryCompilation is the gas used by compiling the code of a contract
+	GasCategoryCompilation = "compilation"
+
+	// GasCategoryDeployment is the base cost of deploying a contract
+	GasCategoryDeployment = "deployment"
+
+	// GasCategoryStorage is the gas used (or refunded) per byte of storage
+	GasCategoryStorage = "storagePerByte"
+
+	// GasCategoryAsyncLock is the gas deducted and locked for an async step
+	GasCategoryAsyncLock = "asyncLock"
+
+	// GasCategoryNestedCalls is the gas used by the executions started by a
+	// contract, which is also attributed in detail to the called contracts
+	GasCategoryNestedCalls = "nestedCalls"
+
+	// GasCategoryBuiltinFunction is the gas used by protocol built-in functions
+	GasCategoryBuiltinFunction = "builtinFunction"
+
+	// GasCategoryMemory is the gas used per page of memory grown by a contract
+	GasCategoryMemory = "memoryPages"
+
+	// GasCategoryHost is the gas used by the host outside of EI functions
+	GasCategoryHost = "host"
+)
+
+const gasCategoryAPIPrefix = "api."
+
+// GasCategoryForAPI returns the category of the gas used by the EI function with the given name
+func GasCategoryForAPI(name string) string {
+	return gasCategoryAPIPrefix + name
+}
+
+// ContractGasProfile holds the gas used and refunded by a contract, per category
+type ContractGasProfile struct {
+	Address     []byte
+	GasUsed     map[string]uint64
+	GasRefunded map[string]uint64 `json:",omitempty"`
+}
+
+// GasProfile holds the gas used by each contract during a transaction, in the
+// order in which the contracts were first executed
+type GasProfile struct {
+	Contracts []*ContractGasProfile
+}
+
+// NewGasProfile creates an empty GasProfile
+func NewGasProfile() *GasProfile {
+	return &GasProfile{
+		Contracts: make([]*ContractGasProfile, 0),
+	}
+}
+
+// GetContract returns the profile of the contract with the given address, creating it if missing
+func (profile *GasProfile) GetContract(address []byte) *ContractGasProfile {
+	for _, contract := range profile.Contracts {
+		if string(contract.Address) == string(address) {
+			return contract
+		}
+	}
+
+	contract := &ContractGasProfile{
+		Address: address,
+		GasUsed: make(map[string]uint64),
+	}
+	profile.Contracts = append(profile.Contracts, contract)
+	return contract
+}
+
+// AddGasUsed attributes gas used by the contract to the category
+func (contract *ContractGasProfile) AddGasUsed(category string, gas uint64) {
+	contract.GasUsed[category] += gas
+}
+
+// SubtractGasUsed removes gas previously attributed to the category, as when locked gas is unlocked
+func (contract *ContractGasProfile) SubtractGasUsed(category string, gas uint64) {
+	if contract.GasUsed[category] <= gas {
+		delete(contract.GasUsed, category)
+		return
+	}
+	contract.GasUsed[category] -= gas
+}
+
+// AddGasRefunded attributes gas refunded to the contract to the category
+func (contract *ContractGasProfile) AddGasRefunded(category string, gas uint64) {
+	if contract.GasRefunded == nil {
+		contract.GasRefunded = make(map[string]uint64)
+	}
+	contract.GasRefunded[category] += gas
+}
+
+// TotalGasUsed returns the gas used by the contract, including the gas used
+// by the executions it started
+func (contract *ContractGasProfile) TotalGasUsed() uint64 {
+	total := uint64(0)
+	for _, gas := range contract.GasUsed {
+		total += gas
+	}
+	return total
+}
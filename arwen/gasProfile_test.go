@@ -0,0 +1,40 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasProfile_GetContract(t *testing.T) {
+	t.Parallel()
+
+	profile := NewGasProfile()
+	contractB := profile.GetContract([]byte("b"))
+	contractA := profile.GetContract([]byte("a"))
+	require.Equal(t, contractB, profile.GetContract([]byte("b")))
+	require.Equal(t, []*ContractGasProfile{contractB, contractA}, profile.Contracts)
+}
+
+func TestContractGasProfile_GasUsed(t *testing.T) {
+	t.Parallel()
+
+	contract := NewGasProfile().GetContract([]byte("contract"))
+	contract.AddGasUsed(GasCategoryOpcodes, 10)
+	contract.AddGasUsed(GasCategoryForAPI("storageStore"), 5)
+	contract.AddGasUsed(GasCategoryOpcodes, 2)
+	require.Equal(t, uint64(17), contract.TotalGasUsed())
+	require.Equal(t, uint64(5), contract.GasUsed["api.storageStore"])
+
+	contract.SubtractGasUsed(GasCategoryOpcodes, 4)
+	require.Equal(t, uint64(8), contract.GasUsed[GasCategoryOpcodes])
+
+	contract.SubtractGasUsed(GasCategoryOpcodes, 20)
+	_, ok := contract.GasUsed[GasCategoryOpcodes]
+	require.False(t, ok)
+	require.Equal(t, uint64(5), contract.TotalGasUsed())
+
+	require.Nil(t, contract.GasRefunded)
+	contract.AddGasRefunded(GasCategoryStorage, 3)
+	require.Equal(t, map[string]uint64{GasCategoryStorage: 3}, contract.GasRefunded)
+}
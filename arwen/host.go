@@ -1,6 +1,7 @@
 package arwen
 
 import (
+	"runtime/cgo"
 	"unsafe"
 
 	vmcommon "github.com/kalyan3104/dme-vm-common"
@@ -12,42 +13,39 @@ const AddressLenEth = 20
 const HashLen = 32
 const ArgumentLenEth = 32
 const BalanceLen = 32
+const CodeMetadataLen = 2
 const InitFunctionName = "init"
 const InitFunctionNameEth = "solidity.ctor"
 const CallBackFunctionName = "callBack"
 const UpgradeFunctionName = "upgradeContract"
 
-var (
-	vmContextCounter uint8
-	vmContextMap     map[uint8]VMHost
-)
-
-func AddHostContext(ctx VMHost) int {
-	id := vmContextCounter
-	vmContextCounter++
-	if vmContextMap == nil {
-		vmContextMap = make(map[uint8]VMHost)
-	}
-	vmContextMap[id] = ctx
-	return int(id)
+// AddHostContext registers the host, returning the ID by which the instances
+// it runs resolve it through GetVmContext(); each registration must be undone
+// with RemoveHostContext() once the instance no longer runs. Registration is
+// safe for concurrent use, and the IDs of hosts do not collide.
+func AddHostContext(host VMHost) int {
+	return int(cgo.NewHandle(host))
 }
 
-func RemoveAllHostContexts() {
-	vmContextMap = make(map[uint8]VMHost)
+// RemoveHostContext undoes the registration of a host by AddHostContext()
+func RemoveHostContext(id int) {
+	cgo.Handle(id).Delete()
 }
 
-func RemoveHostContext(idx int) {
-	delete(vmContextMap, uint8(idx))
+// getHostContext returns the host registered with the given ID
+func getHostContext(id int) VMHost {
+	return cgo.Handle(id).Value().(VMHost)
 }
 
+// GetVmContext returns the host running the instance with the given context
 func GetVmContext(context unsafe.Pointer) VMHost {
 	instCtx := wasmer.IntoInstanceContext(context)
-	var idx = *(*int)(instCtx.Data())
+	var id = *(*int)(instCtx.Data())
 
-	ctx := vmContextMap[uint8(idx)]
-	ctx.Runtime().SetInstanceContext(&instCtx)
+	host := getHostContext(id)
+	host.Runtime().SetInstanceContext(&instCtx)
 
-	return ctx
+	return host
 }
 
 func GetBlockchainContext(context unsafe.Pointer) BlockchainContext {
@@ -62,10 +60,22 @@ func GetCryptoContext(context unsafe.Pointer) vmcommon.CryptoHook {
 	return GetVmContext(context).Crypto()
 }
 
+func GetSignatureVerifier(context unsafe.Pointer) SignatureVerifier {
+	return GetVmContext(context).SignatureVerifier()
+}
+
 func GetBigIntContext(context unsafe.Pointer) BigIntContext {
 	return GetVmContext(context).BigInt()
 }
 
+func GetBigFloatContext(context unsafe.Pointer) BigFloatContext {
+	return GetVmContext(context).BigFloat()
+}
+
+func GetManagedBufferContext(context unsafe.Pointer) ManagedBufferContext {
+	return GetVmContext(context).ManagedBuffer()
+}
+
 func GetOutputContext(context unsafe.Pointer) OutputContext {
 	return GetVmContext(context).Output()
 }
@@ -18,6 +18,15 @@ var log = logger.GetOrCreate("arwen/host")
 
 var MaximumWasmerInstanceCount = uint64(10)
 
+// WasmerModuleCacheSize is the number of compiled contract modules kept by a
+// host, so that frequently called contracts are not compiled on each call
+var WasmerModuleCacheSize = 100
+
+// MaximumAsyncCallbackDepth limits how many callbacks executed on this host can
+// be nested, each of them issuing new async calls which are resolved on this
+// host as well.
+var MaximumAsyncCallbackDepth = uint64(5)
+
 // TryFunction corresponds to the try() part of a try / catch block
 type TryFunction func()
 
@@ -26,20 +35,31 @@ type CatchFunction func(error)
 
 // vmHost implements HostContext interface.
 type vmHost struct {
-	blockChainHook vmcommon.BlockchainHook
-	cryptoHook     vmcommon.CryptoHook
+	blockChainHook    vmcommon.BlockchainHook
+	cryptoHook        vmcommon.CryptoHook
+	signatureVerifier arwen.SignatureVerifier
 
 	ethInput []byte
 
-	blockchainContext arwen.BlockchainContext
-	runtimeContext    arwen.RuntimeContext
-	outputContext     arwen.OutputContext
-	meteringContext   arwen.MeteringContext
-	storageContext    arwen.StorageContext
-	bigIntContext     arwen.BigIntContext
+	blockchainContext    arwen.BlockchainContext
+	runtimeContext       arwen.RuntimeContext
+	outputContext        arwen.OutputContext
+	meteringContext      arwen.MeteringContext
+	storageContext       arwen.StorageContext
+	bigIntContext        arwen.BigIntContext
+	bigFloatContext      arwen.BigFloatContext
+	managedBufferContext arwen.ManagedBufferContext
 
 	scAPIMethods             *wasmer.Imports
 	protocolBuiltinFunctions vmcommon.FunctionNames
+
+	asyncCallbackDepth  uint64
+	maxCallDepth        uint64
+	payableCheckEnabled bool
+
+	memoryGrowGasEnabled bool
+
+	tracer arwen.ExecutionTracer
 }
 
 // NewArwenVM creates a new Arwen vmHost
@@ -52,13 +72,19 @@ func NewArwenVM(
 	host := &vmHost{
 		blockChainHook:           blockChainHook,
 		cryptoHook:               cryptoHook,
+		signatureVerifier:        newSignatureVerifier(hostParameters.SignatureVerifier),
 		meteringContext:          nil,
 		runtimeContext:           nil,
 		blockchainContext:        nil,
 		storageContext:           nil,
 		bigIntContext:            nil,
+		bigFloatContext:          nil,
+		managedBufferContext:     nil,
 		scAPIMethods:             nil,
 		protocolBuiltinFunctions: hostParameters.ProtocolBuiltinFunctions,
+		maxCallDepth:             hostParameters.MaxCallDepth,
+		payableCheckEnabled:      hostParameters.PayableCheckEnabled,
+		memoryGrowGasEnabled:     hostParameters.MemoryGrowGasEnabled,
 	}
 
 	var err error
@@ -73,6 +99,16 @@ func NewArwenVM(
 		return nil, err
 	}
 
+	imports, err = kalyan3104api.BigFloatImports(imports)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = kalyan3104api.ManagedBufferImports(imports)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = ethapi.EthereumImports(imports)
 	if err != nil {
 		return nil, err
@@ -120,12 +156,42 @@ func NewArwenVM(
 		return nil, err
 	}
 
+	host.bigFloatContext, err = contexts.NewBigFloatContext()
+	if err != nil {
+		return nil, err
+	}
+
+	host.managedBufferContext, err = contexts.NewManagedBufferContext()
+	if err != nil {
+		return nil, err
+	}
+
 	gasCostConfig, err := config.CreateGasConfig(hostParameters.GasSchedule)
 	if err != nil {
 		return nil, err
 	}
 
+	logLimits := hostParameters.LogLimits
+	if logLimits == (arwen.LogLimits{}) {
+		logLimits = arwen.DefaultLogLimits
+	}
+	if hostParameters.DisableLogLimits {
+		logLimits = arwen.LogLimits{}
+	}
+	host.outputContext.SetLogLimits(logLimits)
+
 	host.runtimeContext.SetMaxInstanceCount(MaximumWasmerInstanceCount)
+	host.runtimeContext.SetModuleCacheSize(WasmerModuleCacheSize)
+
+	validationPolicy := hostParameters.WASMValidationPolicy
+	if hostParameters.DisallowFloatingPoint {
+		validationPolicy.DisallowFloatingPoint = true
+	}
+	if validationPolicy.MaxMemoryPages == 0 {
+		validationPolicy.MaxMemoryPages = hostParameters.MaxMemoryPages
+	}
+	host.runtimeContext.SetValidationPolicy(validationPolicy)
+	host.runtimeContext.SetMaxMemoryPages(hostParameters.MaxMemoryPages)
 
 	opcodeCosts := gasCostConfig.WASMOpcodeCost.ToOpcodeCostsArray()
 	wasmer.SetOpcodeCosts(&opcodeCosts)
@@ -139,6 +205,21 @@ func (host *vmHost) Crypto() vmcommon.CryptoHook {
 	return host.cryptoHook
 }
 
+// SignatureVerifier returns the verifier used by verifyEd25519 and verifyBLS
+func (host *vmHost) SignatureVerifier() arwen.SignatureVerifier {
+	return host.signatureVerifier
+}
+
+// newSignatureVerifier returns the verifier given through the host
+// parameters, or the verifier of the crypto package if none was given
+func newSignatureVerifier(verifier arwen.SignatureVerifier) arwen.SignatureVerifier {
+	if verifier != nil {
+		return verifier
+	}
+
+	return crypto.NewSignatureVerifier()
+}
+
 func (host *vmHost) Blockchain() arwen.BlockchainContext {
 	return host.blockchainContext
 }
@@ -163,6 +244,14 @@ func (host *vmHost) BigInt() arwen.BigIntContext {
 	return host.bigIntContext
 }
 
+func (host *vmHost) BigFloat() arwen.BigFloatContext {
+	return host.bigFloatContext
+}
+
+func (host *vmHost) ManagedBuffer() arwen.ManagedBufferContext {
+	return host.managedBufferContext
+}
+
 func (host *vmHost) GetContexts() (
 	arwen.BigIntContext,
 	arwen.BlockchainContext,
@@ -182,14 +271,19 @@ func (host *vmHost) GetContexts() (
 func (host *vmHost) InitState() {
 	host.ClearContextStateStack()
 	host.bigIntContext.InitState()
+	host.bigFloatContext.InitState()
+	host.managedBufferContext.InitState()
 	host.outputContext.InitState()
 	host.runtimeContext.InitState()
 	host.storageContext.InitState()
 	host.ethInput = nil
+	host.asyncCallbackDepth = 0
 }
 
 func (host *vmHost) ClearContextStateStack() {
 	host.bigIntContext.ClearStateStack()
+	host.bigFloatContext.ClearStateStack()
+	host.managedBufferContext.ClearStateStack()
 	host.outputContext.ClearStateStack()
 	host.runtimeContext.ClearStateStack()
 	host.storageContext.ClearStateStack()
@@ -197,7 +291,6 @@ func (host *vmHost) ClearContextStateStack() {
 
 func (host *vmHost) Clean() {
 	host.runtimeContext.CleanInstance()
-	arwen.RemoveAllHostContexts()
 }
 
 func (host *vmHost) GetAPIMethods() *wasmer.Imports {
@@ -208,6 +301,26 @@ func (host *vmHost) GetProtocolBuiltinFunctions() vmcommon.FunctionNames {
 	return host.protocolBuiltinFunctions
 }
 
+// VerifyContractCode checks the given bytecode against the validation rules
+// applied at deployment, without deploying it; if endpoints is not nil, the
+// contract must also export exactly the endpoints declared by its ABI
+func (host *vmHost) VerifyContractCode(code []byte, endpoints []string) error {
+	if endpoints == nil {
+		return host.Runtime().VerifyContractCode(code)
+	}
+	return host.Runtime().VerifyContractEndpoints(code, endpoints)
+}
+
+// Tracer returns the ExecutionTracer which receives the events of the executions, if any
+func (host *vmHost) Tracer() arwen.ExecutionTracer {
+	return host.tracer
+}
+
+// SetTracer sets the ExecutionTracer which receives the events of the executions; nil disables tracing
+func (host *vmHost) SetTracer(tracer arwen.ExecutionTracer) {
+	host.tracer = tracer
+}
+
 func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error) {
 	log.Trace("RunSmartContractCreate begin", "len(code)", len(input.ContractCode), "metadata", input.ContractCodeMetadata)
 
@@ -220,7 +333,11 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 		log.Error("RunSmartContractCreate", "error", err)
 	}
 
+	gasProvided := input.GasProvided
+	host.enterTransaction(&input.VMInput, nil, arwen.InitFunctionName)
 	TryCatch(try, catch, "arwen.RunSmartContractCreate")
+	host.exitTransaction(host.Runtime().GetSCAddress(), gasProvided, vmOutput, err)
+
 	if vmOutput != nil {
 		log.Trace("RunSmartContractCreate end", "returnCode", vmOutput.ReturnCode, "returnMessage", vmOutput.ReturnMessage)
 	}
@@ -244,6 +361,9 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 		log.Error("RunSmartContractCall", "error", err)
 	}
 
+	gasProvided := input.GasProvided
+	host.enterTransaction(&input.VMInput, input.RecipientAddr, input.Function)
+
 	isUpgrade := input.Function == arwen.UpgradeFunctionName
 	if isUpgrade {
 		TryCatch(tryUpgrade, catch, "arwen.RunSmartContractUpgrade")
@@ -251,6 +371,8 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 		TryCatch(tryCall, catch, "arwen.RunSmartContractCall")
 	}
 
+	host.exitTransaction(input.RecipientAddr, gasProvided, vmOutput, err)
+
 	if vmOutput != nil {
 		log.Trace("RunSmartContractCall end", "returnCode", vmOutput.ReturnCode, "returnMessage", vmOutput.ReturnMessage)
 	}
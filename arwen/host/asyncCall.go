@@ -3,7 +3,6 @@ package host
 import (
 	"bytes"
 	"encoding/hex"
-	"encoding/json"
 	"math/big"
 
 	vmcommon "github.com/kalyan3104/dme-vm-common"
@@ -24,6 +23,8 @@ func (host *vmHost) handleAsyncCallBreakpoint() error {
 		return err
 	}
 
+	host.traceAsyncCall(asyncCallInfo.Destination, asyncCallInfo.Data, execMode)
+
 	if execMode == arwen.AsyncUnknown {
 		return host.sendAsyncCallToDestination(asyncCallInfo)
 	}
@@ -326,6 +327,7 @@ func (host *vmHost) computeDataLengthFromArguments(function string, arguments []
 /**
  * processAsyncInfo takes a list of async calls and for each of them, if the code exists and can be processed on this
  *  host it will. For all others, a vm output account is generated for an actual async call.
+ *  The callback of each async context whose async calls were all resolved on this host is executed right away.
  *  Given the fact that the generated async calls that remain pending will be saved on storage, the processing is
  *  done in two steps in order to correctly use all remaining gas. We first split the gas as specified by the developer,
  *  then we save the storage, then we split again the gas to calls that leave this shard.
@@ -342,19 +344,33 @@ func (host *vmHost) processAsyncInfo(asyncInfo *arwen.AsyncContextInfo) (*arwen.
 		return nil, err
 	}
 
-	for _, asyncContext := range asyncInfo.AsyncContextMap {
+	for _, contextIdentifier := range asyncInfo.GetContextIdentifiers() {
+		asyncContext := asyncInfo.AsyncContextMap[contextIdentifier]
 		for _, asyncCall := range asyncContext.AsyncCalls {
 			if !host.canExecuteSynchronously(asyncCall.Destination, asyncCall.Data) {
 				continue
 			}
 
-			procErr := host.processAsyncCall(asyncCall)
+			host.traceAsyncCall(asyncCall.Destination, asyncCall.Data, arwen.SyncCall)
+			procErr := host.processAsyncCall(asyncContext, asyncCall)
 			if procErr != nil {
 				return nil, procErr
 			}
 		}
 	}
 
+	for _, contextIdentifier := range asyncInfo.GetContextIdentifiers() {
+		asyncContext := asyncInfo.AsyncContextMap[contextIdentifier]
+		if !asyncContext.IsResolved() {
+			continue
+		}
+
+		err = host.executeAsyncContextCallback(contextIdentifier, asyncContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	pendingMapInfo := host.getPendingAsyncCalls(asyncInfo)
 	if len(pendingMapInfo.AsyncContextMap) == 0 {
 		return pendingMapInfo, nil
@@ -370,9 +386,11 @@ func (host *vmHost) processAsyncInfo(asyncInfo *arwen.AsyncContextInfo) (*arwen.
 		return nil, err
 	}
 
-	for _, asyncContext := range pendingMapInfo.AsyncContextMap {
+	for _, contextIdentifier := range pendingMapInfo.GetContextIdentifiers() {
+		asyncContext := pendingMapInfo.AsyncContextMap[contextIdentifier]
 		for _, asyncCall := range asyncContext.AsyncCalls {
 			if !host.canExecuteSynchronously(asyncCall.Destination, asyncCall.Data) {
+				host.traceAsyncCall(asyncCall.Destination, asyncCall.Data, arwen.AsyncUnknown)
 				sendErr := host.sendAsyncCallToDestination(asyncCall)
 				if sendErr != nil {
 					return nil, sendErr
@@ -387,22 +405,40 @@ func (host *vmHost) processAsyncInfo(asyncInfo *arwen.AsyncContextInfo) (*arwen.
 /**
  * processAsyncCall executes an async call and processes the callback if no extra calls are pending
  */
-func (host *vmHost) processAsyncCall(asyncCall *arwen.AsyncGeneratedCall) error {
+func (host *vmHost) processAsyncCall(asyncContext *arwen.AsyncContext, asyncCall *arwen.AsyncGeneratedCall) error {
 	input, _ := host.createDestinationContractCallInput(asyncCall)
 	output, asyncMap, executionError := host.ExecuteOnDestContext(input)
 
 	pendingMap := host.getPendingAsyncCalls(asyncMap)
 	if len(pendingMap.AsyncContextMap) == 0 {
-		return host.callbackAsync(asyncCall, output, executionError)
+		return host.callbackAsync(asyncContext, asyncCall, output, executionError)
 	}
 
 	return executionError
 }
 
 /**
- * callbackAsync will execute a callback from an async call that was ran on this host and set it's status to resolved or rejected
+ * callbackAsync will execute a callback from an async call that was ran on this host and set it's status to resolved or rejected.
+ *  The callback may generate async calls of its own, which are processed like the ones of any other execution on the
+ *  destination context: the ones that can be executed on this host are executed, the others are saved as pending
+ *  and sent to their destinations. Callbacks nested this way are limited by MaximumAsyncCallbackDepth.
+ *  If the deadline of the async context has passed in the meantime, the async call is rejected as expired instead.
  */
-func (host *vmHost) callbackAsync(asyncCall *arwen.AsyncGeneratedCall, vmOutput *vmcommon.VMOutput, executionError error) error {
+func (host *vmHost) callbackAsync(
+	asyncContext *arwen.AsyncContext,
+	asyncCall *arwen.AsyncGeneratedCall,
+	vmOutput *vmcommon.VMOutput,
+	executionError error,
+) error {
+	if host.asyncCallbackDepth >= MaximumAsyncCallbackDepth {
+		return arwen.ErrMaxAsyncCallbackDepthReached
+	}
+
+	if asyncContext.IsExpired(host.Blockchain().CurrentTimeStamp()) {
+		asyncCall.Status = arwen.AsyncCallRejected
+		return host.executeOwnCallback(asyncCall.Destination, asyncCall.ErrorCallback, asyncCallExpiredArguments())
+	}
+
 	asyncCall.Status = arwen.AsyncCallResolved
 	callbackFunction := asyncCall.SuccessCallback
 	if vmOutput.ReturnCode != vmcommon.Ok {
@@ -420,8 +456,10 @@ func (host *vmHost) callbackAsync(asyncCall *arwen.AsyncGeneratedCall, vmOutput
 		return err
 	}
 
-	// Callback omits for now any async call - TODO: take into consideration async calls generated from callbacks
+	host.asyncCallbackDepth++
 	callbackVMOutput, _, callBackErr := host.ExecuteOnDestContext(callbackCallInput)
+	host.asyncCallbackDepth--
+
 	err = host.processCallbackVMOutput(callbackVMOutput, callBackErr)
 	if err != nil {
 		return err
@@ -431,23 +469,48 @@ func (host *vmHost) callbackAsync(asyncCall *arwen.AsyncGeneratedCall, vmOutput
 }
 
 /**
- * savePendingAsyncCalls takes a list of pending async calls and save them to storage so the info will be available on callback
+ * savePendingAsyncCalls takes a list of pending async calls and save them to storage so the info will be available on callback.
+ *  The calls are added to the ones already pending for the current contract, which may have been saved earlier
+ *  in the same transaction, e.g. by a callback of this contract which generated async calls of its own.
  */
 func (host *vmHost) savePendingAsyncCalls(pendingAsyncMap *arwen.AsyncContextInfo) error {
 	if len(pendingAsyncMap.AsyncContextMap) == 0 {
 		return nil
 	}
 
-	storage := host.Storage()
-	runtime := host.Runtime()
-
-	asyncCallStorageKey := arwen.CustomStorageKey(arwen.AsyncDataPrefix, runtime.GetOriginalTxHash())
-	data, err := json.Marshal(pendingAsyncMap)
+	storedAsyncInfo, err := host.getCurrentAsyncInfo()
 	if err != nil {
 		return err
 	}
 
-	_, err = storage.SetStorage(asyncCallStorageKey, data)
+	if len(storedAsyncInfo.AsyncContextMap) == 0 && len(storedAsyncInfo.CallerAddr) == 0 {
+		return host.saveAsyncContextInfo(pendingAsyncMap)
+	}
+
+	for _, contextIdentifier := range pendingAsyncMap.GetContextIdentifiers() {
+		asyncContext := pendingAsyncMap.AsyncContextMap[contextIdentifier]
+		storedContext, ok := storedAsyncInfo.AsyncContextMap[contextIdentifier]
+		if !ok {
+			storedAsyncInfo.AddAsyncContext(contextIdentifier, asyncContext)
+			continue
+		}
+
+		storedContext.AsyncCalls = append(storedContext.AsyncCalls, asyncContext.AsyncCalls...)
+	}
+
+	return host.saveAsyncContextInfo(storedAsyncInfo)
+}
+
+/**
+ * saveAsyncContextInfo replaces the async calls saved to storage for the current contract
+ */
+func (host *vmHost) saveAsyncContextInfo(asyncInfo *arwen.AsyncContextInfo) error {
+	storage := host.Storage()
+	runtime := host.Runtime()
+
+	asyncCallStorageKey := arwen.CustomStorageKey(arwen.AsyncDataPrefix, runtime.GetOriginalTxHash())
+	data := arwen.SerializeAsyncContextInfo(asyncInfo)
+	_, err := storage.SetStorage(asyncCallStorageKey, data)
 	if err != nil {
 		return err
 	}
@@ -465,20 +528,11 @@ func (host *vmHost) saveCrossShardCalls(asyncInfo *arwen.AsyncContextInfo) error
 		AsyncContextMap: make(map[string]*arwen.AsyncContext),
 	}
 
-	for contextIdentifier, asyncContext := range asyncInfo.AsyncContextMap {
+	for _, contextIdentifier := range asyncInfo.GetContextIdentifiers() {
+		asyncContext := asyncInfo.AsyncContextMap[contextIdentifier]
 		for _, asyncCall := range asyncContext.AsyncCalls {
 			if !host.canExecuteSynchronously(asyncCall.Destination, asyncCall.Data) {
-				_, ok := crossMap.AsyncContextMap[contextIdentifier]
-				if !ok {
-					crossMap.AsyncContextMap[contextIdentifier] = &arwen.AsyncContext{
-						Callback:   asyncContext.Callback,
-						AsyncCalls: make([]*arwen.AsyncGeneratedCall, 0),
-					}
-				}
-				crossMap.AsyncContextMap[contextIdentifier].AsyncCalls = append(
-					crossMap.AsyncContextMap[contextIdentifier].AsyncCalls,
-					asyncCall,
-				)
+				crossMap.AddAsyncCall(contextIdentifier, asyncContext.Callback, asyncCall)
 			}
 		}
 	}
@@ -496,23 +550,14 @@ func (host *vmHost) getPendingAsyncCalls(asyncInfo *arwen.AsyncContextInfo) *arw
 		AsyncContextMap: make(map[string]*arwen.AsyncContext),
 	}
 
-	for contextIdentifier, asyncContext := range asyncInfo.AsyncContextMap {
+	for _, contextIdentifier := range asyncInfo.GetContextIdentifiers() {
+		asyncContext := asyncInfo.AsyncContextMap[contextIdentifier]
 		for _, asyncCall := range asyncContext.AsyncCalls {
 			if asyncCall.Status != arwen.AsyncCallPending {
 				continue
 			}
 
-			_, ok := pendingMap.AsyncContextMap[contextIdentifier]
-			if !ok {
-				pendingMap.AsyncContextMap[contextIdentifier] = &arwen.AsyncContext{
-					Callback:   asyncContext.Callback,
-					AsyncCalls: make([]*arwen.AsyncGeneratedCall, 0),
-				}
-			}
-			pendingMap.AsyncContextMap[contextIdentifier].AsyncCalls = append(
-				pendingMap.AsyncContextMap[contextIdentifier].AsyncCalls,
-				asyncCall,
-			)
+			pendingMap.AddAsyncCall(contextIdentifier, asyncContext.Callback, asyncCall)
 		}
 	}
 
@@ -523,7 +568,10 @@ func (host *vmHost) getPendingAsyncCalls(asyncInfo *arwen.AsyncContextInfo) *arw
  * processCallbackStack is triggered when a callback was received from another host through a transaction.
  *  It will return an error if we receive a callback and we don't have it's associated data in the storage.
  *  If the associated callback was found in the pending set, it will be removed - It should not be executed
- *   again since it was executed in the callSCMethod step
+ *   again since it was executed in the callSCMethod step. If this was the last pending call of its async context,
+ *   the callback of the async context is executed as well. Then, the async contexts which expired in the meantime
+ *   are rejected, see rejectExpiredAsyncContexts. Finally, the async calls made by the callback itself are processed
+ *   and the pending ones are saved along with the remaining ones.
  */
 func (host *vmHost) processCallbackStack() error {
 	runtime := host.Runtime()
@@ -532,11 +580,11 @@ func (host *vmHost) processCallbackStack() error {
 	storageKey := arwen.CustomStorageKey(arwen.AsyncDataPrefix, runtime.GetOriginalTxHash())
 	buff := storage.GetStorage(storageKey)
 	if len(buff) == 0 {
-		return nil
+		_, err := host.processAsyncInfo(runtime.GetAsyncContextInfo())
+		return err
 	}
 
-	asyncInfo := &arwen.AsyncContextInfo{}
-	err := json.Unmarshal(buff, &asyncInfo)
+	asyncInfo, err := arwen.DeserializeAsyncContextInfo(buff)
 	if err != nil {
 		return err
 	}
@@ -544,7 +592,8 @@ func (host *vmHost) processCallbackStack() error {
 	vmInput := runtime.GetVMInput()
 	var asyncCallPosition int
 	var currentContextIdentifier string
-	for contextIdentifier, asyncContext := range asyncInfo.AsyncContextMap {
+	for _, contextIdentifier := range asyncInfo.GetContextIdentifiers() {
+		asyncContext := asyncInfo.AsyncContextMap[contextIdentifier]
 		for position, asyncCall := range asyncContext.AsyncCalls {
 			if bytes.Equal(vmInput.CallerAddr, asyncCall.Destination) {
 				asyncCallPosition = position
@@ -562,20 +611,52 @@ func (host *vmHost) processCallbackStack() error {
 		return arwen.ErrCallBackFuncNotExpected
 	}
 
-	// Remove current async call from the pending list
+	// Remove current async call from the pending list, keeping the order of the others
 	currentContextCalls := asyncInfo.AsyncContextMap[currentContextIdentifier].AsyncCalls
-	currentContextCalls[asyncCallPosition] = currentContextCalls[len(currentContextCalls)-1]
-	currentContextCalls[len(currentContextCalls)-1] = nil
-	currentContextCalls = currentContextCalls[:len(currentContextCalls)-1]
+	currentContextCalls = append(currentContextCalls[:asyncCallPosition], currentContextCalls[asyncCallPosition+1:]...)
+	asyncInfo.AsyncContextMap[currentContextIdentifier].AsyncCalls = currentContextCalls
 
 	if len(currentContextCalls) == 0 {
-		// call OUR callback for resolving a full context
-		delete(asyncInfo.AsyncContextMap, currentContextIdentifier)
+		asyncContext := asyncInfo.AsyncContextMap[currentContextIdentifier]
+		asyncInfo.DeleteAsyncContext(currentContextIdentifier)
+
+		err = host.executeAsyncContextCallback(currentContextIdentifier, asyncContext)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = host.rejectExpiredAsyncContexts(asyncInfo)
+	if err != nil {
+		return err
 	}
 
-	// If we are still waiting for callbacks we return
+	// The async calls made by the callback itself are added to the remaining
+	// ones, so that the caller is notified only once they are resolved as well
+	ownAsyncInfo := runtime.GetAsyncContextInfo()
+	if len(ownAsyncInfo.AsyncContextMap) > 0 {
+		err = host.saveAsyncContextInfo(asyncInfo)
+		if err != nil {
+			return err
+		}
+
+		_, err = host.processAsyncInfo(ownAsyncInfo)
+		if err != nil {
+			return err
+		}
+
+		asyncInfo, err = host.getCurrentAsyncInfo()
+		if err != nil {
+			return err
+		}
+		if len(asyncInfo.AsyncContextMap) > 0 {
+			return nil
+		}
+	}
+
+	// If we are still waiting for callbacks, we keep the remaining ones and return
 	if len(asyncInfo.AsyncContextMap) > 0 {
-		return nil
+		return host.saveAsyncContextInfo(asyncInfo)
 	}
 
 	_, err = storage.SetStorage(storageKey, nil)
@@ -623,7 +704,8 @@ func (host *vmHost) setupAsyncCallsGas(asyncInfo *arwen.AsyncContextInfo) error
 	gasNeeded := uint64(0)
 	callsWithZeroGas := uint64(0)
 
-	for identifier, asyncContext := range asyncInfo.AsyncContextMap {
+	for _, identifier := range asyncInfo.GetContextIdentifiers() {
+		asyncContext := asyncInfo.AsyncContextMap[identifier]
 		for index, asyncCall := range asyncContext.AsyncCalls {
 			var err error
 			gasNeeded, err = math.AddUint64(gasNeeded, asyncCall.ProvidedGas)
@@ -653,7 +735,8 @@ func (host *vmHost) setupAsyncCallsGas(asyncInfo *arwen.AsyncContextInfo) error
 	}
 
 	gasShare := (gasLeft - gasNeeded) / callsWithZeroGas
-	for identifier, asyncContext := range asyncInfo.AsyncContextMap {
+	for _, identifier := range asyncInfo.GetContextIdentifiers() {
+		asyncContext := asyncInfo.AsyncContextMap[identifier]
 		for index, asyncCall := range asyncContext.AsyncCalls {
 			if asyncCall.ProvidedGas == 0 {
 				asyncInfo.AsyncContextMap[identifier].AsyncCalls[index].GasLimit = gasShare
@@ -677,13 +760,25 @@ func (host *vmHost) getFunctionByCallType(callType vmcommon.CallType) (wasmer.Ex
 	}
 
 	vmInput := runtime.GetVMInput()
+	timestamp := host.Blockchain().CurrentTimeStamp()
 
 	customCallback := false
-	for _, asyncContext := range asyncInfo.AsyncContextMap {
+	for _, contextIdentifier := range asyncInfo.GetContextIdentifiers() {
+		asyncContext := asyncInfo.AsyncContextMap[contextIdentifier]
 		for _, asyncCall := range asyncContext.AsyncCalls {
 			if bytes.Equal(vmInput.CallerAddr, asyncCall.Destination) {
 				customCallback = true
-				runtime.SetCustomCallFunction(asyncCall.SuccessCallback)
+				callbackFunction := asyncCall.SuccessCallback
+				if asyncContext.IsExpired(timestamp) {
+					// The result arrived too late, the callback is told that the call expired
+					vmInput.Arguments = asyncCallExpiredArguments()
+				}
+				if !isAsyncCallSuccessful(vmInput.Arguments) {
+					callbackFunction = asyncCall.ErrorCallback
+				}
+				if len(callbackFunction) > 0 {
+					runtime.SetCustomCallFunction(callbackFunction)
+				}
 				break
 			}
 		}
@@ -696,6 +791,135 @@ func (host *vmHost) getFunctionByCallType(callType vmcommon.CallType) (wasmer.Ex
 	return runtime.GetFunctionToCall()
 }
 
+// isAsyncCallSuccessful returns true if the return code received as the first
+// argument of a callback is Ok
+func isAsyncCallSuccessful(callbackArguments [][]byte) bool {
+	return asyncCallReturnCode(callbackArguments) == vmcommon.Ok
+}
+
+// asyncCallReturnCode interprets the first argument of a callback as the
+// return code of the async call, either as its name, for the async calls sent
+// to other shards, or as its numeric value, for the ones executed on this host
+func asyncCallReturnCode(callbackArguments [][]byte) vmcommon.ReturnCode {
+	if len(callbackArguments) == 0 {
+		return vmcommon.Ok
+	}
+
+	encodedReturnCode := callbackArguments[0]
+	for returnCode := vmcommon.Ok; returnCode <= vmcommon.UpgradeFailed; returnCode++ {
+		if string(encodedReturnCode) == returnCode.String() {
+			return returnCode
+		}
+	}
+
+	returnCode := big.NewInt(0).SetBytes(encodedReturnCode)
+	if !returnCode.IsUint64() || returnCode.Uint64() > uint64(vmcommon.UpgradeFailed) {
+		return vmcommon.ExecutionFailed
+	}
+
+	return vmcommon.ReturnCode(returnCode.Uint64())
+}
+
+// asyncCallExpiredArguments returns the arguments received by the error
+// callback of an async call whose async context expired
+func asyncCallExpiredArguments() [][]byte {
+	return [][]byte{
+		big.NewInt(int64(vmcommon.UserError)).Bytes(),
+		[]byte(arwen.ErrAsyncCallExpired.Error()),
+	}
+}
+
+/**
+ * rejectExpiredAsyncContexts removes the async contexts whose deadline has passed. The error callback of each of their
+ *  async calls still pending is executed, followed by the callback of the async context. The results of these async
+ *  calls which arrive afterwards are not expected anymore.
+ */
+func (host *vmHost) rejectExpiredAsyncContexts(asyncInfo *arwen.AsyncContextInfo) error {
+	timestamp := host.Blockchain().CurrentTimeStamp()
+
+	for _, contextIdentifier := range asyncInfo.GetContextIdentifiers() {
+		asyncContext := asyncInfo.AsyncContextMap[contextIdentifier]
+		if !asyncContext.IsExpired(timestamp) {
+			continue
+		}
+
+		asyncInfo.DeleteAsyncContext(contextIdentifier)
+
+		for _, asyncCall := range asyncContext.AsyncCalls {
+			asyncCall.Status = arwen.AsyncCallRejected
+			err := host.executeOwnCallback(asyncCall.Destination, asyncCall.ErrorCallback, asyncCallExpiredArguments())
+			if err != nil {
+				return err
+			}
+		}
+
+		err := host.executeAsyncContextCallback(contextIdentifier, asyncContext)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * executeAsyncContextCallback executes the callback set for an async context whose async calls were all resolved.
+ *  The callback receives the identifier of the async context as argument.
+ */
+func (host *vmHost) executeAsyncContextCallback(contextIdentifier string, asyncContext *arwen.AsyncContext) error {
+	scAddress := host.Runtime().GetSCAddress()
+	arguments := [][]byte{[]byte(contextIdentifier)}
+	return host.executeOwnCallback(scAddress, asyncContext.Callback, arguments)
+}
+
+/**
+ * executeOwnCallback executes a callback function of the current contract which is not triggered by the result of an
+ *  async call, giving it all the gas left. Nothing is executed if the callback function is not set. Like the callbacks
+ *  of async calls, these callbacks are limited by MaximumAsyncCallbackDepth.
+ */
+func (host *vmHost) executeOwnCallback(callerAddress []byte, callbackFunction string, arguments [][]byte) error {
+	if len(callbackFunction) == 0 {
+		return nil
+	}
+	if host.asyncCallbackDepth >= MaximumAsyncCallbackDepth {
+		return arwen.ErrMaxAsyncCallbackDepthReached
+	}
+
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	dataLength := host.computeDataLengthFromArguments(callbackFunction, arguments)
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.AsyncCallStep
+	gasToUse += metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(dataLength)
+	gasLimit := metering.GasLeft()
+	if gasLimit <= gasToUse {
+		return arwen.ErrNotEnoughGas
+	}
+	metering.UseGas(gasToUse)
+	gasLimit -= gasToUse
+
+	callbackCallInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     callerAddress,
+			Arguments:      arguments,
+			CallValue:      big.NewInt(0),
+			CallType:       vmcommon.AsynchronousCallBack,
+			GasPrice:       runtime.GetVMInput().GasPrice,
+			GasProvided:    gasLimit,
+			CurrentTxHash:  runtime.GetCurrentTxHash(),
+			OriginalTxHash: runtime.GetOriginalTxHash(),
+		},
+		RecipientAddr: runtime.GetSCAddress(),
+		Function:      callbackFunction,
+	}
+
+	host.asyncCallbackDepth++
+	callbackVMOutput, _, callBackErr := host.ExecuteOnDestContext(callbackCallInput)
+	host.asyncCallbackDepth--
+
+	return host.processCallbackVMOutput(callbackVMOutput, callBackErr)
+}
+
 func (host *vmHost) getCurrentAsyncInfo() (*arwen.AsyncContextInfo, error) {
 	runtime := host.Runtime()
 	storage := host.Storage()
@@ -707,10 +931,5 @@ func (host *vmHost) getCurrentAsyncInfo() (*arwen.AsyncContextInfo, error) {
 		return asyncInfo, nil
 	}
 
-	err := json.Unmarshal(buff, &asyncInfo)
-	if err != nil {
-		return nil, err
-	}
-
-	return asyncInfo, nil
+	return arwen.DeserializeAsyncContextInfo(buff)
 }
@@ -0,0 +1,208 @@
+package host
+
+import (
+	"encoding/hex"
+	"testing"
+
+	vmcommon "github.com/kalyan3104/dme-vm-common"
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/kalyan3104/dme-vm-go/arwen/crypto"
+	cryptohook "github.com/kalyan3104/dme-vm-util/mock-hook-crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// a compressed secp256k1 public key and its Bitcoin HASH160, i.e. RIPEMD-160(SHA-256(publicKey))
+var bitcoinPublicKey, _ = hex.DecodeString("0250863ad64a87ae8a2fe83c1af1a8403cb53f53e486d8511dad8a04887e5b2352")
+var bitcoinHash160, _ = hex.DecodeString("f54a5851e9372b87810a8e60cdd2e7cfd80b6e31")
+
+func TestCryptoEI_Ripemd160(t *testing.T) {
+	code := GetTestSCCode("signatures", "../../")
+	host, _ := DefaultTestArwenForCallWithCrypto(t, code, nil, cryptohook.KryptoHookMockInstance)
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "ripemd160Hash"
+	input.Arguments = [][]byte{bitcoinPublicKey}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	expectedHash, _ := cryptohook.KryptoHookMockInstance.Ripemd160(bitcoinPublicKey)
+	require.Equal(t, [][]byte{expectedHash}, vmOutput.ReturnData)
+}
+
+func TestCryptoEI_HashData(t *testing.T) {
+	code := GetTestSCCode("signatures", "../../")
+	data := []byte("abc")
+
+	sha256Hash, _ := cryptohook.KryptoHookMockInstance.Sha256(data)
+	keccak256Hash, _ := cryptohook.KryptoHookMockInstance.Keccak256(data)
+	ripemd160Hash, _ := cryptohook.KryptoHookMockInstance.Ripemd160(data)
+
+	expectedHashes := map[byte][]byte{
+		crypto.HashAlgorithmSHA256:    sha256Hash,
+		crypto.HashAlgorithmKeccak256: keccak256Hash,
+		crypto.HashAlgorithmRipemd160: ripemd160Hash,
+	}
+
+	for algorithmId, expectedHash := range expectedHashes {
+		host, _ := DefaultTestArwenForCallWithCrypto(t, code, nil, cryptohook.KryptoHookMockInstance)
+
+		input := DefaultTestContractCallInput()
+		input.GasProvided = 100000
+		input.Function = "hashWithAlgorithm"
+		input.Arguments = [][]byte{{algorithmId}, data}
+
+		vmOutput, err := host.RunSmartContractCall(input)
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+		require.Equal(t, [][]byte{expectedHash}, vmOutput.ReturnData)
+	}
+}
+
+func TestCryptoEI_HashData_UnknownAlgorithm(t *testing.T) {
+	code := GetTestSCCode("signatures", "../../")
+	host, _ := DefaultTestArwenForCallWithCrypto(t, code, nil, cryptohook.KryptoHookMockInstance)
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "hashWithAlgorithm"
+	input.Arguments = [][]byte{{9}, []byte("abc")}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrUnknownHashAlgorithm.Error(), vmOutput.ReturnMessage)
+	require.Zero(t, vmOutput.GasRemaining)
+}
+
+func TestCryptoEI_BitcoinHash160(t *testing.T) {
+	code := GetTestSCCode("signatures", "../../")
+	host, _ := DefaultTestArwenForCallWithCrypto(t, code, nil, cryptohook.KryptoHookMockInstance)
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "checkHash160"
+	input.Arguments = [][]byte{bitcoinPublicKey, bitcoinHash160}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{1}}, vmOutput.ReturnData)
+
+	wrongHash160 := make([]byte, len(bitcoinHash160))
+	copy(wrongHash160, bitcoinHash160)
+	wrongHash160[19] ^= 1
+	input.Arguments = [][]byte{bitcoinPublicKey, wrongHash160}
+
+	vmOutput, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{}}, vmOutput.ReturnData)
+}
+
+func TestCryptoEI_VerifyEd25519(t *testing.T) {
+	// test 2 of RFC 8032, section 7.1
+	key, _ := hex.DecodeString("3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c")
+	signature, _ := hex.DecodeString("92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00")
+	message := []byte{0x72}
+
+	runSignatureVerification(t, "verifyEd25519Signature", key, message, signature, true)
+	runSignatureVerification(t, "verifyEd25519Signature", key, []byte{0x73}, signature, false)
+}
+
+func TestCryptoEI_VerifyBLS(t *testing.T) {
+	key, _ := hex.DecodeString("95690ac4d432da7b8f2793f9146ffc3a531b11ad4938d147b4b428bd565f597f04063ddab04362704221a2ccddde8cbd")
+	signature, _ := hex.DecodeString("b41835ad61af1592bc396041f51d045e2516b7cc9dd616ad61b5499b267e27d5d82f040c729dc2af6f14d0155e2c9c2b1881785c99643f13a1e26e928040adb1fbf230b53364e7b2eb94e536e6b04bc7458878e788ee03e7c0a04a34f09fdafd")
+	message := []byte("oracle price feed: EGLD/USD 1024")
+
+	runSignatureVerification(t, "verifyBLSSignature", key, message, signature, true)
+	runSignatureVerification(t, "verifyBLSSignature", key, []byte("oracle price feed: EGLD/USD 1025"), signature, false)
+}
+
+func TestCryptoEI_VerifySignatures_HostParametersVerifier(t *testing.T) {
+	code := GetTestSCCode("signatures", "../../")
+	verifier := &recordingSignatureVerifier{}
+	hostParameters := DefaultTestVMHostParameters()
+	hostParameters.SignatureVerifier = verifier
+	host, _ := DefaultTestArwenForCallWithParameters(t, code, nil, cryptohook.KryptoHookMockInstance, hostParameters)
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = "verifyEd25519Signature"
+	input.Arguments = [][]byte{
+		make([]byte, crypto.Ed25519PublicKeyLength),
+		[]byte("message"),
+		make([]byte, crypto.Ed25519SignatureLength),
+	}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{1}}, vmOutput.ReturnData)
+	require.Equal(t, [][]byte{[]byte("message")}, verifier.verifiedMessages)
+}
+
+func TestCryptoEI_VerifySignatures_CryptoHookNotUsedAsVerifier(t *testing.T) {
+	code := GetTestSCCode("signatures", "../../")
+	cryptoHook := &verifyingCryptoHook{CryptoHook: cryptohook.KryptoHookMockInstance}
+	host, _ := DefaultTestArwenForCallWithCrypto(t, code, nil, cryptoHook)
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = "verifyEd25519Signature"
+	input.Arguments = [][]byte{
+		make([]byte, crypto.Ed25519PublicKeyLength),
+		[]byte("message"),
+		make([]byte, crypto.Ed25519SignatureLength),
+	}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{}}, vmOutput.ReturnData)
+	require.Len(t, cryptoHook.verifiedMessages, 0)
+}
+
+// recordingSignatureVerifier accepts any Ed25519 signature and rejects all
+// BLS signatures, recording the messages it verified
+type recordingSignatureVerifier struct {
+	verifiedMessages [][]byte
+}
+
+func (verifier *recordingSignatureVerifier) VerifyEd25519(_ []byte, message []byte, _ []byte) error {
+	verifier.verifiedMessages = append(verifier.verifiedMessages, message)
+	return nil
+}
+
+func (verifier *recordingSignatureVerifier) VerifyBLS(_ []byte, message []byte, _ []byte) error {
+	verifier.verifiedMessages = append(verifier.verifiedMessages, message)
+	return crypto.ErrInvalidSignature
+}
+
+// verifyingCryptoHook is a crypto hook which also happens to implement
+// arwen.SignatureVerifier, which the host must not use as its verifier
+type verifyingCryptoHook struct {
+	vmcommon.CryptoHook
+	recordingSignatureVerifier
+}
+
+func runSignatureVerification(t *testing.T, function string, key []byte, message []byte, signature []byte, expectedValid bool) {
+	code := GetTestSCCode("signatures", "../../")
+	host, _ := DefaultTestArwenForCall(t, code, nil)
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = function
+	input.Arguments = [][]byte{key, message, signature}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	if expectedValid {
+		require.Equal(t, [][]byte{{1}}, vmOutput.ReturnData)
+	} else {
+		require.Equal(t, [][]byte{{}}, vmOutput.ReturnData)
+	}
+}
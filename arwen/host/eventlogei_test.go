@@ -0,0 +1,114 @@
+package host
+
+import (
+	"testing"
+
+	vmcommon "github.com/kalyan3104/dme-vm-common"
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/kalyan3104/dme-vm-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// runEventLogsFunction runs the function on a host whose log limits are left
+// unset, such that the DefaultLogLimits are enforced
+func runEventLogsFunction(t *testing.T, function string, arguments ...[]byte) *vmcommon.VMOutput {
+	return runEventLogsFunctionWithParameters(t, DefaultTestVMHostParameters(), function, arguments...)
+}
+
+func runEventLogsFunctionWithParameters(t *testing.T, hostParameters *arwen.VMHostParameters, function string, arguments ...[]byte) *vmcommon.VMOutput {
+	code := GetTestSCCode("event-logs", "../../")
+	host, _ := DefaultTestArwenForCallWithParameters(t, code, nil, &mock.CryptoHookMock{}, hostParameters)
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 10000000
+	input.Function = function
+	input.Arguments = arguments
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	return vmOutput
+}
+
+func logsData(logs []*vmcommon.LogEntry) [][]byte {
+	data := make([][]byte, len(logs))
+	for i, log := range logs {
+		data[i] = log.Data
+	}
+	return data
+}
+
+func TestEventLogEI_WriteEvent(t *testing.T) {
+	vmOutput := runEventLogsFunction(t, "writeEvent", []byte("data"), []byte("transfer"), []byte("from"), []byte("to"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Len(t, vmOutput.Logs, 1)
+	require.Equal(t, parentAddress, vmOutput.Logs[0].Address)
+	require.Equal(t, []byte("transfer"), vmOutput.Logs[0].Identifier)
+	require.Equal(t, [][]byte{[]byte("from"), []byte("to")}, vmOutput.Logs[0].Topics)
+	require.Equal(t, []byte("data"), vmOutput.Logs[0].Data)
+}
+
+func TestEventLogEI_TooManyTopics(t *testing.T) {
+	arguments := [][]byte{[]byte("data")}
+	for i := 0; i <= arwen.DefaultLogLimits.MaxTopics; i++ {
+		arguments = append(arguments, []byte("topic"))
+	}
+
+	vmOutput := runEventLogsFunction(t, "writeEvent", arguments...)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrTooManyLogTopics.Error(), vmOutput.ReturnMessage)
+	require.Empty(t, vmOutput.Logs)
+}
+
+func TestEventLogEI_LogTooLarge(t *testing.T) {
+	data := make([]byte, arwen.DefaultLogLimits.MaxSize)
+
+	vmOutput := runEventLogsFunction(t, "writeEvent", data, []byte("topic"))
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrLogTooLarge.Error(), vmOutput.ReturnMessage)
+	require.Empty(t, vmOutput.Logs)
+
+	vmOutput = runEventLogsFunction(t, "writeEvent", data[:arwen.DefaultLogLimits.MaxSize-len("topic")], []byte("topic"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Len(t, vmOutput.Logs, 1)
+}
+
+func TestEventLogEI_NoLimits(t *testing.T) {
+	arguments := [][]byte{make([]byte, arwen.DefaultLogLimits.MaxSize)}
+	for i := 0; i <= arwen.DefaultLogLimits.MaxTopics; i++ {
+		arguments = append(arguments, []byte("topic"))
+	}
+
+	hostParameters := DefaultTestVMHostParameters()
+	hostParameters.DisableLogLimits = true
+	vmOutput := runEventLogsFunctionWithParameters(t, hostParameters, "writeEvent", arguments...)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Len(t, vmOutput.Logs, 1)
+}
+
+func TestEventLogEI_CustomLimits(t *testing.T) {
+	hostParameters := DefaultTestVMHostParameters()
+	hostParameters.LogLimits = arwen.LogLimits{MaxTopics: 1}
+
+	vmOutput := runEventLogsFunctionWithParameters(t, hostParameters, "writeEvent", []byte("data"), []byte("transfer"), []byte("from"))
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrTooManyLogTopics.Error(), vmOutput.ReturnMessage)
+
+	// Limits left at zero within custom limits are not enforced.
+	vmOutput = runEventLogsFunctionWithParameters(t, hostParameters, "writeEvent", make([]byte, arwen.DefaultLogLimits.MaxSize+1), []byte("transfer"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Len(t, vmOutput.Logs, 1)
+}
+
+func TestEventLogEI_NestedCall(t *testing.T) {
+	vmOutput := runEventLogsFunction(t, "parentCallsChild", []byte("childLogs"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("parent"), []byte("child"), []byte("after")}, logsData(vmOutput.Logs))
+}
+
+func TestEventLogEI_FailedNestedCall(t *testing.T) {
+	// The logs of the failed child are discarded, along with its other output.
+	vmOutput := runEventLogsFunction(t, "parentCallsChild", []byte("childLogsAndFails"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("parent"), []byte("after")}, logsData(vmOutput.Logs))
+}
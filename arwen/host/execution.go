@@ -1,8 +1,13 @@
 package host
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+
 	vmcommon "github.com/kalyan3104/dme-vm-common"
 	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/kalyan3104/dme-vm-go/wasmer"
 )
 
 func (host *vmHost) doRunSmartContractCreate(input *vmcommon.ContractCreateInput) *vmcommon.VMOutput {
@@ -40,26 +45,35 @@ func (host *vmHost) performCodeDeploy(input arwen.CodeDeployInput) (*vmcommon.VM
 
 	_, _, metering, output, runtime, _ := host.GetContexts()
 
-	err := metering.DeductInitialGasForDirectDeployment(input)
+	_, err := arwen.CodeMetadataFromBytes(input.ContractCodeMetadata)
 	if err != nil {
-		output.SetReturnCode(vmcommon.OutOfGas)
 		return nil, err
 	}
 
-	vmInput := runtime.GetVMInput()
-	err = runtime.StartWasmerInstance(input.ContractCode, vmInput.GasProvided)
+	err = metering.DeductInitialGasForDirectDeployment(input)
 	if err != nil {
-		log.Debug("performCodeDeploy/StartWasmerInstance", "err", err)
-		return nil, arwen.ErrContractInvalid
+		output.SetReturnCode(vmcommon.OutOfGas)
+		return nil, err
 	}
 
-	err = runtime.VerifyContractCode()
+	err = runtime.VerifyContractCode(input.ContractCode)
 	if err != nil {
 		log.Debug("performCodeDeploy/VerifyContractCode", "err", err)
+		if !errors.Is(err, arwen.ErrContractInvalid) {
+			err = fmt.Errorf("%w: %s", arwen.ErrContractInvalid, err)
+		}
+		return nil, err
+	}
+
+	vmInput := runtime.GetVMInput()
+	err = runtime.StartWasmerInstance(input.ContractCode, vmInput.GasProvided)
+	if err != nil {
+		log.Debug("performCodeDeploy/StartWasmerInstance", "err", err)
 		return nil, arwen.ErrContractInvalid
 	}
 
 	idContext := arwen.AddHostContext(host)
+	defer arwen.RemoveHostContext(idContext)
 	runtime.SetInstanceContextID(idContext)
 
 	err = host.callInitFunction()
@@ -87,6 +101,11 @@ func (host *vmHost) doRunSmartContractUpgrade(input *vmcommon.ContractCallInput)
 		return output.CreateVMOutputInCaseOfError(arwen.ErrInvalidUpgradeArguments)
 	}
 
+	err = host.checkUpgradeAllowed(input.RecipientAddr)
+	if err != nil {
+		return output.CreateVMOutputInCaseOfError(err)
+	}
+
 	codeDeployInput := arwen.CodeDeployInput{
 		ContractCode:         code,
 		ContractCodeMetadata: codeMetadata,
@@ -107,26 +126,47 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) (v
 	_, blockchain, metering, output, runtime, storage := host.GetContexts()
 
 	runtime.InitStateFromContractCallInput(input)
-	output.AddTxValueToAccount(input.RecipientAddr, input.CallValue)
-	storage.SetAddress(runtime.GetSCAddress())
+
+	// Token transfers are accepted only through their built-in functions,
+	// which transfer the tokens before the function following them is called.
+	if host.isBuiltinFunctionName(input.Function) && arwen.IsTokenTransferFunction(input.Function) {
+		builtinOutput, executionInput, err := host.processReceivedTokenTransfer(input)
+		if err != nil {
+			return output.CreateVMOutputInCaseOfError(err)
+		}
+		if executionInput == nil {
+			return builtinOutput
+		}
+		input = executionInput
+	}
 
 	contract, err := blockchain.GetCode(runtime.GetSCAddress())
 	if err != nil {
 		return output.CreateVMOutputInCaseOfError(arwen.ErrContractNotFound)
 	}
 
+	err = host.checkPayable(input)
+	if err != nil {
+		return output.CreateVMOutputInCaseOfError(err)
+	}
+
+	output.AddTxValueToAccount(input.RecipientAddr, input.CallValue)
+	storage.SetAddress(runtime.GetSCAddress())
+
 	err = metering.DeductInitialGasForExecution(contract)
 	if err != nil {
 		return output.CreateVMOutputInCaseOfError(arwen.ErrNotEnoughGas)
 	}
 
+	codeHash := host.getCodeHashForModuleCache()
 	vmInput := runtime.GetVMInput()
-	err = runtime.StartWasmerInstance(contract, vmInput.GasProvided)
+	err = runtime.StartCachedWasmerInstance(contract, codeHash, vmInput.GasProvided)
 	if err != nil {
 		return output.CreateVMOutputInCaseOfError(arwen.ErrContractInvalid)
 	}
 
 	idContext := arwen.AddHostContext(host)
+	defer arwen.RemoveHostContext(idContext)
 	runtime.SetInstanceContextID(idContext)
 
 	err = host.callSCMethod()
@@ -144,10 +184,18 @@ func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (vmO
 	log.Trace("ExecuteOnDestContext", "function", input.Function)
 
 	bigInt, _, _, output, runtime, storage := host.GetContexts()
+	bigFloat := host.BigFloat()
+	managedBuffer := host.ManagedBuffer()
 
 	bigInt.PushState()
 	bigInt.InitState()
 
+	bigFloat.PushState()
+	bigFloat.InitState()
+
+	managedBuffer.PushState()
+	managedBuffer.InitState()
+
 	output.PushState()
 	output.CensorVMOutput()
 
@@ -157,10 +205,17 @@ func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (vmO
 	storage.PushState()
 	storage.SetAddress(host.Runtime().GetSCAddress())
 
+	gasLeftBefore := host.enterIndirectExecution(arwen.TraceKindDestContext, &input.VMInput, input.RecipientAddr, input.Function)
 	defer func() {
 		vmOutput = host.finishExecuteOnDestContext(err)
+		host.exitIndirectExecution(arwen.TraceKindDestContext, input.RecipientAddr, gasLeftBefore, vmOutput.ReturnCode, err)
 	}()
 
+	err = host.checkCallStack()
+	if err != nil {
+		return
+	}
+
 	// Perform a value transfer to the called SC. If the execution fails, this
 	// transfer will not persist.
 	err = output.Transfer(input.RecipientAddr, input.CallerAddr, 0, input.CallValue, nil)
@@ -168,18 +223,19 @@ func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (vmO
 		return
 	}
 
-	err = host.execute(input)
+	err = host.execute(input, true)
 	if err != nil {
 		return
 	}
 
 	asyncInfo = runtime.GetAsyncContextInfo()
-	_, err = host.processAsyncInfo(asyncInfo)
 	return
 }
 
 func (host *vmHost) finishExecuteOnDestContext(executeErr error) *vmcommon.VMOutput {
 	bigInt, _, _, output, runtime, storage := host.GetContexts()
+	bigFloat := host.BigFloat()
+	managedBuffer := host.ManagedBuffer()
 
 	if executeErr != nil {
 		// Execution failed: restore contexts as if the execution didn't happen,
@@ -187,6 +243,8 @@ func (host *vmHost) finishExecuteOnDestContext(executeErr error) *vmcommon.VMOut
 		vmOutput := output.CreateVMOutputInCaseOfError(executeErr)
 
 		bigInt.PopSetActiveState()
+		bigFloat.PopSetActiveState()
+		managedBuffer.PopSetActiveState()
 		output.PopSetActiveState()
 		runtime.PopSetActiveState()
 		storage.PopSetActiveState()
@@ -202,6 +260,8 @@ func (host *vmHost) finishExecuteOnDestContext(executeErr error) *vmcommon.VMOut
 	// Execution successful: restore the previous context states, except Output,
 	// which will merge the current state (VMOutput) with the initial state.
 	bigInt.PopSetActiveState()
+	bigFloat.PopSetActiveState()
+	managedBuffer.PopSetActiveState()
 	output.PopMergeActiveState()
 	runtime.PopSetActiveState()
 	storage.PopSetActiveState()
@@ -213,19 +273,30 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 	log.Trace("ExecuteOnSameContext", "function", input.Function)
 
 	bigInt, _, _, output, runtime, _ := host.GetContexts()
+	bigFloat := host.BigFloat()
+	managedBuffer := host.ManagedBuffer()
 
 	// Back up the states of the contexts (except Storage, which isn't affected
 	// by ExecuteOnSameContext())
 	bigInt.PushState()
+	bigFloat.PushState()
+	managedBuffer.PushState()
 	output.PushState()
 	runtime.PushState()
 
 	runtime.InitStateFromContractCallInput(input)
 
+	gasLeftBefore := host.enterIndirectExecution(arwen.TraceKindSameContext, &input.VMInput, input.RecipientAddr, input.Function)
 	defer func() {
+		host.exitIndirectExecution(arwen.TraceKindSameContext, input.RecipientAddr, gasLeftBefore, output.ReturnCode(), err)
 		host.finishExecuteOnSameContext(err)
 	}()
 
+	err = host.checkCallStack()
+	if err != nil {
+		return
+	}
+
 	// Perform a value transfer to the called SC. If the execution fails, this
 	// transfer will not persist.
 	err = output.Transfer(input.RecipientAddr, input.CallerAddr, 0, input.CallValue, nil)
@@ -233,7 +304,7 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 		return
 	}
 
-	err = host.execute(input)
+	err = host.execute(input, false)
 	if err != nil {
 		return
 	}
@@ -245,10 +316,14 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 
 func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
 	bigInt, _, _, output, runtime, _ := host.GetContexts()
+	bigFloat := host.BigFloat()
+	managedBuffer := host.ManagedBuffer()
 
 	if executeErr != nil {
 		// Execution failed: restore contexts as if the execution didn't happen.
 		bigInt.PopSetActiveState()
+		bigFloat.PopSetActiveState()
+		managedBuffer.PopSetActiveState()
 		output.PopSetActiveState()
 		runtime.PopSetActiveState()
 
@@ -258,6 +333,8 @@ func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
 	// Execution successful: discard the backups made at the beginning and
 	// resume from the new state.
 	bigInt.PopDiscard()
+	bigFloat.PopDiscard()
+	managedBuffer.PopDiscard()
 	output.PopDiscard()
 	runtime.PopSetActiveState()
 }
@@ -280,17 +357,30 @@ func (host *vmHost) isBuiltinFunctionName(functionName string) bool {
 func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput) ([]byte, error) {
 	log.Trace("CreateNewContract", "len(code)", len(input.ContractCode), "metadata", input.ContractCodeMetadata)
 
+	gasLeftBefore := host.enterIndirectExecution(arwen.TraceKindCreateContract, &input.VMInput, nil, arwen.InitFunctionName)
+	address, err := host.createNewContract(input)
+	host.exitIndirectExecution(arwen.TraceKindCreateContract, address, gasLeftBefore, returnCodeFromError(err), err)
+
+	return address, err
+}
+
+func (host *vmHost) createNewContract(input *vmcommon.ContractCreateInput) ([]byte, error) {
 	_, blockchain, metering, output, runtime, _ := host.GetContexts()
 
 	// Use all gas initially. In case of successful deployment, the unused gas
 	// will be restored.
 	initialGasProvided := input.GasProvided
-	metering.UseGas(initialGasProvided)
+	metering.ForwardGas(initialGasProvided)
 
 	if runtime.ReadOnly() {
 		return nil, arwen.ErrInvalidCallOnReadOnlyMode
 	}
 
+	_, err := arwen.CodeMetadataFromBytes(input.ContractCodeMetadata)
+	if err != nil {
+		return nil, err
+	}
+
 	runtime.PushState()
 
 	runtime.SetVMInput(&input.VMInput)
@@ -321,6 +411,12 @@ func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput) ([]by
 		return nil, err
 	}
 
+	err = runtime.VerifyContractCode(input.ContractCode)
+	if err != nil {
+		runtime.PopSetActiveState()
+		return nil, err
+	}
+
 	idContext := arwen.AddHostContext(host)
 	runtime.PushInstance()
 
@@ -333,7 +429,9 @@ func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput) ([]by
 		return nil, err
 	}
 
-	err = runtime.VerifyContractCode()
+	runtime.SetInstanceContextID(idContext)
+
+	err = host.callInitFunction()
 	if err != nil {
 		runtime.PopInstance()
 		runtime.PopSetActiveState()
@@ -341,32 +439,244 @@ func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput) ([]by
 		return nil, err
 	}
 
+	output.DeployCode(codeDeployInput)
+
+	gasToRestoreToCaller := metering.GasLeft()
+
+	runtime.PopInstance()
+	runtime.PopSetActiveState()
+	arwen.RemoveHostContext(idContext)
+
+	metering.RestoreGas(gasToRestoreToCaller)
+	return address, nil
+}
+
+// UpgradeContract replaces the code of an existing contract, as requested by
+// the currently running contract, which must be the owner of the upgraded
+// contract. The first two arguments of the input are the new code and its
+// metadata; the rest are passed to the init function of the new code.
+func (host *vmHost) UpgradeContract(input *vmcommon.ContractCallInput) error {
+	log.Trace("UpgradeContract", "address", input.RecipientAddr)
+
+	gasLeftBefore := host.enterIndirectExecution(arwen.TraceKindUpgradeContract, &input.VMInput, input.RecipientAddr, arwen.InitFunctionName)
+	err := host.upgradeContract(input)
+	host.exitIndirectExecution(arwen.TraceKindUpgradeContract, input.RecipientAddr, gasLeftBefore, returnCodeFromError(err), err)
+
+	return err
+}
+
+func (host *vmHost) upgradeContract(input *vmcommon.ContractCallInput) error {
+	_, blockchain, metering, output, runtime, storage := host.GetContexts()
+
+	// Use all gas initially. In case of successful upgrade, the unused gas
+	// will be restored.
+	initialGasProvided := input.GasProvided
+	metering.ForwardGas(initialGasProvided)
+
+	if runtime.ReadOnly() {
+		return arwen.ErrInvalidCallOnReadOnlyMode
+	}
+
+	runtime.PushState()
+	runtime.InitStateFromContractCallInput(input)
+
+	owner, err := blockchain.GetOwnerAddress()
+	if err != nil {
+		runtime.PopSetActiveState()
+		return err
+	}
+	if !bytes.Equal(owner, input.CallerAddr) {
+		runtime.PopSetActiveState()
+		return arwen.ErrUpgradeNotAllowed
+	}
+
+	err = host.checkUpgradeAllowed(input.RecipientAddr)
+	if err != nil {
+		runtime.PopSetActiveState()
+		return err
+	}
+
+	code, codeMetadata, err := runtime.ExtractCodeUpgradeFromArgs()
+	if err != nil {
+		runtime.PopSetActiveState()
+		return arwen.ErrInvalidUpgradeArguments
+	}
+
+	_, err = arwen.CodeMetadataFromBytes(codeMetadata)
+	if err != nil {
+		runtime.PopSetActiveState()
+		return err
+	}
+
+	err = output.Transfer(input.RecipientAddr, input.CallerAddr, 0, input.CallValue, nil)
+	if err != nil {
+		runtime.PopSetActiveState()
+		return err
+	}
+
+	codeDeployInput := arwen.CodeDeployInput{
+		ContractCode:         code,
+		ContractCodeMetadata: codeMetadata,
+		ContractAddress:      input.RecipientAddr,
+	}
+
+	err = metering.DeductInitialGasForIndirectDeployment(codeDeployInput)
+	if err != nil {
+		runtime.PopSetActiveState()
+		return err
+	}
+
+	err = runtime.VerifyContractCode(code)
+	if err != nil {
+		runtime.PopSetActiveState()
+		return err
+	}
+
+	storage.PushState()
+	storage.SetAddress(input.RecipientAddr)
+
+	idContext := arwen.AddHostContext(host)
+	runtime.PushInstance()
+
+	gasForDeployment := runtime.GetVMInput().GasProvided
+	err = runtime.StartWasmerInstance(code, gasForDeployment)
+	if err != nil {
+		host.finishUpgradeContract(idContext)
+		return err
+	}
+
 	runtime.SetInstanceContextID(idContext)
 
 	err = host.callInitFunction()
 	if err != nil {
-		runtime.PopInstance()
-		runtime.PopSetActiveState()
-		arwen.RemoveHostContext(idContext)
-		return nil, err
+		host.finishUpgradeContract(idContext)
+		return err
 	}
 
 	output.DeployCode(codeDeployInput)
 
 	gasToRestoreToCaller := metering.GasLeft()
+	host.finishUpgradeContract(idContext)
 
+	metering.RestoreGas(gasToRestoreToCaller)
+	return nil
+}
+
+func (host *vmHost) finishUpgradeContract(idContext int) {
+	runtime := host.Runtime()
 	runtime.PopInstance()
 	runtime.PopSetActiveState()
+	host.Storage().PopSetActiveState()
 	arwen.RemoveHostContext(idContext)
+}
 
-	metering.RestoreGas(gasToRestoreToCaller)
-	return address, nil
+// checkUpgradeAllowed verifies that the code metadata of the contract at the
+// given address permits upgrading it.
+func (host *vmHost) checkUpgradeAllowed(address []byte) error {
+	codeMetadata, err := host.Blockchain().GetCodeMetadata(address)
+	if err != nil {
+		return err
+	}
+
+	if !codeMetadata.Upgradeable {
+		return arwen.ErrContractNotUpgradeable
+	}
+
+	return nil
+}
+
+// checkPayable verifies that the recipient of the call accepts the call value,
+// if the check is enabled. Callbacks are exempt, because they return value
+// previously sent by the contract itself. It is called once the code of the
+// recipient has been loaded, so any failure to read its code metadata fails
+// the call.
+func (host *vmHost) checkPayable(input *vmcommon.ContractCallInput) error {
+	if !host.payableCheckEnabled {
+		return nil
+	}
+	if input.CallValue == nil || input.CallValue.Sign() <= 0 {
+		return nil
+	}
+	if input.CallType == vmcommon.AsynchronousCallBack {
+		return nil
+	}
+
+	codeMetadata, err := host.Blockchain().GetCodeMetadata(input.RecipientAddr)
+	if err != nil {
+		return err
+	}
+
+	if !codeMetadata.Payable {
+		return arwen.ErrNonPayableContract
+	}
+
+	return nil
+}
+
+// checkCallStack rejects the nested execution if it would exceed the maximum
+// call depth of the host.
+func (host *vmHost) checkCallStack() error {
+	if host.maxCallDepth > 0 && host.Runtime().CallDepth() > host.maxCallDepth {
+		return arwen.ErrMaxCallDepthReached
+	}
+
+	return nil
+}
+
+// getCodeHashForModuleCache returns the code hash of the current contract,
+// under which its compiled module is cached; if the code hash cannot be read,
+// it returns nil, so that the module cache is bypassed
+func (host *vmHost) getCodeHashForModuleCache() []byte {
+	codeHash, err := host.Blockchain().GetCodeHash(host.Runtime().GetSCAddress())
+	if err != nil {
+		return nil
+	}
+	return codeHash
+}
+
+// checkReentrancy rejects the nested execution if its recipient is a
+// non-reentrant contract which is already on the call stack. Callbacks of
+// async calls are exempt, since they are expected to return to their caller.
+// It is called once the code of the recipient has been loaded; code metadata
+// which cannot be read, such as that of contracts deployed by older versions,
+// does not mark the recipient as non-reentrant.
+func (host *vmHost) checkReentrancy(input *vmcommon.ContractCallInput) error {
+	if input.CallType == vmcommon.AsynchronousCallBack {
+		return nil
+	}
+
+	codeMetadata, err := host.Blockchain().GetCodeMetadata(input.RecipientAddr)
+	if err != nil {
+		return nil
+	}
+
+	if codeMetadata.NonReentrant && host.Runtime().IsContractOnTheStack(input.RecipientAddr) {
+		return arwen.ErrReentrantCall
+	}
+
+	return nil
 }
 
-// TODO: Add support for indirect smart contract upgrades.
-func (host *vmHost) execute(input *vmcommon.ContractCallInput) error {
+// execute runs the called function on a new Wasmer instance. When
+// processAsyncCalls is set, the async calls generated by the function are
+// processed before the instance is discarded, so that the gas given to them
+// is taken from the gas left to the called function.
+func (host *vmHost) execute(input *vmcommon.ContractCallInput, processAsyncCalls bool) error {
 	if host.isBuiltinFunctionBeingCalled() {
-		return host.callBuiltinFunction(input)
+		vmOutput, err := host.callBuiltinFunction(input)
+		if err != nil || !arwen.IsTokenTransferFunction(input.Function) {
+			return err
+		}
+
+		// Token transfers to a SC of this shard may continue by calling one
+		// of its functions, with the gas left by the built-in function.
+		if !host.canExecuteSynchronously(input.RecipientAddr, nil) {
+			return nil
+		}
+		input, err = host.initTokenTransferExecution(input, vmOutput.GasRemaining)
+		if err != nil || input == nil {
+			return err
+		}
 	}
 
 	// Use all gas initially, on the Wasmer instance of the caller
@@ -374,7 +684,7 @@ func (host *vmHost) execute(input *vmcommon.ContractCallInput) error {
 	// the unused gas will be restored.
 	_, _, metering, output, runtime, _ := host.GetContexts()
 	initialGasProvided := input.GasProvided
-	metering.UseGas(initialGasProvided)
+	metering.ForwardGas(initialGasProvided)
 
 	if host.isInitFunctionBeingCalled() {
 		return arwen.ErrInitFuncCalledInRun
@@ -385,6 +695,16 @@ func (host *vmHost) execute(input *vmcommon.ContractCallInput) error {
 		return err
 	}
 
+	err = host.checkPayable(input)
+	if err != nil {
+		return err
+	}
+
+	err = host.checkReentrancy(input)
+	if err != nil {
+		return err
+	}
+
 	err = metering.DeductInitialGasForExecution(contract)
 	if err != nil {
 		return err
@@ -393,8 +713,9 @@ func (host *vmHost) execute(input *vmcommon.ContractCallInput) error {
 	idContext := arwen.AddHostContext(host)
 	runtime.PushInstance()
 
+	codeHash := host.getCodeHashForModuleCache()
 	gasForExecution := runtime.GetVMInput().GasProvided
-	err = runtime.StartWasmerInstance(contract, gasForExecution)
+	err = runtime.StartCachedWasmerInstance(contract, codeHash, gasForExecution)
 	if err != nil {
 		runtime.PopInstance()
 		arwen.RemoveHostContext(idContext)
@@ -416,6 +737,15 @@ func (host *vmHost) execute(input *vmcommon.ContractCallInput) error {
 		return arwen.ErrReturnCodeNotOk
 	}
 
+	if processAsyncCalls {
+		_, err = host.processAsyncInfo(runtime.GetAsyncContextInfo())
+		if err != nil {
+			runtime.PopInstance()
+			arwen.RemoveHostContext(idContext)
+			return err
+		}
+	}
+
 	metering.UnlockGasIfAsyncStep()
 
 	gasToRestoreToCaller := metering.GasLeft()
@@ -433,25 +763,19 @@ func (host *vmHost) callSCMethodIndirect() error {
 		return err
 	}
 
-	_, err = function()
-	if err != nil {
-		err = host.handleBreakpointIfAny(err)
-	}
-
-	if err != nil {
-		return err
-	}
-
-	return err
+	return host.callExportedFunction(function)
 }
 
-func (host *vmHost) callBuiltinFunction(input *vmcommon.ContractCallInput) error {
+func (host *vmHost) callBuiltinFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
 	_, _, metering, output, _, _ := host.GetContexts()
 
+	previousGasCategory := metering.SetGasCategory(arwen.GasCategoryBuiltinFunction)
+	defer metering.SetGasCategory(previousGasCategory)
+
 	vmOutput, err := host.blockChainHook.ProcessBuiltInFunction(input)
 	if err != nil {
 		metering.UseGas(input.GasProvided)
-		return err
+		return nil, err
 	}
 
 	gasConsumed := input.GasProvided - vmOutput.GasRemaining
@@ -460,7 +784,7 @@ func (host *vmHost) callBuiltinFunction(input *vmcommon.ContractCallInput) error
 	}
 
 	output.AddToActiveState(vmOutput)
-	return nil
+	return vmOutput, nil
 }
 
 func (host *vmHost) EthereumCallData() []byte {
@@ -477,12 +801,7 @@ func (host *vmHost) callInitFunction() error {
 		return nil
 	}
 
-	_, err := init()
-	if err != nil {
-		err = host.handleBreakpointIfAny(err)
-	}
-
-	return err
+	return host.callExportedFunction(init)
 }
 
 func (host *vmHost) callSCMethod() error {
@@ -499,10 +818,7 @@ func (host *vmHost) callSCMethod() error {
 		return err
 	}
 
-	_, err = function()
-	if err != nil {
-		err = host.handleBreakpointIfAny(err)
-	}
+	err = host.callExportedFunction(function)
 	if err != nil {
 		return err
 	}
@@ -527,6 +843,58 @@ func (host *vmHost) callSCMethod() error {
 	return err
 }
 
+// callExportedFunction calls a function of the current instance and traces
+// the memory high-water mark of the instance. If memory growth is charged, it
+// first checks the gas left for growing the memory to its limit, then uses
+// the gas for the pages of memory grown by the call.
+func (host *vmHost) callExportedFunction(function wasmer.ExportedFunctionCallback) error {
+	runtime := host.Runtime()
+	pagesBefore := runtime.GetMemoryPages()
+
+	if host.memoryGrowGasEnabled {
+		err := host.checkGasForMemoryGrowth(pagesBefore)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := function()
+	if err != nil {
+		err = host.handleBreakpointIfAny(err)
+	}
+
+	pagesAfter := runtime.GetMemoryPages()
+	host.traceMemory(runtime.GetSCAddress(), pagesAfter)
+
+	// The pages grown by a failed execution are charged as well.
+	if host.memoryGrowGasEnabled && pagesAfter > pagesBefore {
+		gasErr := host.Metering().UseGasForMemoryGrowth(pagesAfter - pagesBefore)
+		if err == nil {
+			err = gasErr
+		}
+	}
+
+	return err
+}
+
+// checkGasForMemoryGrowth rejects the execution if the gas left does not
+// cover growing the memory of the instance from its current pages up to the
+// memory limit of the host; without a limit, there is nothing to check.
+func (host *vmHost) checkGasForMemoryGrowth(pages uint32) error {
+	maxPages := host.Runtime().GetMaxMemoryPages()
+	if maxPages <= pages {
+		return nil
+	}
+
+	metering := host.Metering()
+	gasForGrowth := metering.GasSchedule().BaseOperationCost.MemoryGrowPerPage * uint64(maxPages-pages)
+	if gasForGrowth > metering.GasLeft() {
+		return arwen.ErrNotEnoughGas
+	}
+
+	return nil
+}
+
 func (host *vmHost) verifyAllowedFunctionCall() error {
 	runtime := host.Runtime()
 	functionName := runtime.Function()
@@ -20,6 +20,117 @@ func Test_RunERC20Benchmark(t *testing.T) {
 	runERC20Benchmark(t, 1000, 4)
 }
 
+func Test_ModuleCache_SameGasOnHitAndMiss(t *testing.T) {
+	gasRemainingWithCache := runERC20Transfers(t, WasmerModuleCacheSize, 3)
+	gasRemainingWithoutCache := runERC20Transfers(t, 0, 3)
+	require.Equal(t, gasRemainingWithoutCache, gasRemainingWithCache)
+}
+
+func Test_ModuleCache_SameBreakpointsOnHitAndMiss(t *testing.T) {
+	outputsWithCache := runFailingERC20Transfers(t, WasmerModuleCacheSize)
+	outputsWithoutCache := runFailingERC20Transfers(t, 0)
+	require.Equal(t, outputsWithoutCache, outputsWithCache)
+
+	for _, vmOutput := range outputsWithCache[:2] {
+		require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+		require.Equal(t, "wrong args num", vmOutput.ReturnMessage)
+	}
+	require.Equal(t, vmcommon.OutOfGas, outputsWithCache[2].ReturnCode)
+}
+
+func BenchmarkERC20Transfer_ModuleCache(b *testing.B) {
+	runERC20Transfers(b, WasmerModuleCacheSize, b.N)
+}
+
+func BenchmarkERC20Transfer_NoModuleCache(b *testing.B) {
+	runERC20Transfers(b, 0, b.N)
+}
+
+// runERC20Transfers performs ERC20 transfers on a host with the given module
+// cache size and returns the gas remaining after each of them
+func runERC20Transfers(tb testing.TB, moduleCacheSize int, nTransfers int) []uint64 {
+	totalTokenSupply := big.NewInt(int64(nTransfers))
+	host, mockBlockchainHook := deploy(tb, totalTokenSupply)
+	mockBlockchainHook.Accounts[string(scAddress)].CodeHash = []byte("erc20CodeHash")
+	host.Runtime().SetModuleCacheSize(moduleCacheSize)
+
+	gasProvided := uint64(5000000000)
+	transferInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: owner,
+			Arguments: [][]byte{
+				receiver,
+				big.NewInt(1).Bytes(),
+			},
+			CallValue:   big.NewInt(10),
+			CallType:    vmcommon.DirectCall,
+			GasPrice:    100000000000000,
+			GasProvided: gasProvided,
+		},
+		RecipientAddr: scAddress,
+		Function:      "transferToken",
+	}
+
+	if b, ok := tb.(*testing.B); ok {
+		b.ResetTimer()
+	}
+
+	gasRemaining := make([]uint64, 0, nTransfers)
+	for i := 0; i < nTransfers; i++ {
+		transferInput.GasProvided = gasProvided
+		vmOutput, err := host.RunSmartContractCall(transferInput)
+		require.Nil(tb, err)
+		require.NotNil(tb, vmOutput)
+		require.Equal(tb, vmcommon.Ok, vmOutput.ReturnCode)
+
+		mockBlockchainHook.UpdateAccounts(vmOutput.OutputAccounts)
+		gasRemaining = append(gasRemaining, vmOutput.GasRemaining)
+	}
+
+	verifyTransfers(tb, mockBlockchainHook, totalTokenSupply)
+	return gasRemaining
+}
+
+// runFailingERC20Transfers performs, on a host with the given module cache
+// size, two ERC20 transfers stopped by signalError and one which runs out of
+// gas, and returns their outputs; only the first one compiles the contract
+// when the module cache is enabled
+func runFailingERC20Transfers(tb testing.TB, moduleCacheSize int) []*vmcommon.VMOutput {
+	host, mockBlockchainHook := deploy(tb, big.NewInt(1))
+	mockBlockchainHook.Accounts[string(scAddress)].CodeHash = []byte("erc20CodeHash")
+	host.Runtime().SetModuleCacheSize(moduleCacheSize)
+
+	code := mockBlockchainHook.Accounts[string(scAddress)].Code
+	gasForCompilation := uint64(len(code)) * host.Metering().GasSchedule().BaseOperationCost.CompilePerByte
+
+	transferInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  owner,
+			CallValue:   big.NewInt(0),
+			CallType:    vmcommon.DirectCall,
+			GasPrice:    100000000000000,
+			GasProvided: 5000000000,
+		},
+		RecipientAddr: scAddress,
+		Function:      "transferToken",
+	}
+
+	vmOutputs := make([]*vmcommon.VMOutput, 0, 3)
+	for i := 0; i < 2; i++ {
+		vmOutput, err := host.RunSmartContractCall(transferInput)
+		require.Nil(tb, err)
+		vmOutputs = append(vmOutputs, vmOutput)
+	}
+
+	transferInput.Arguments = [][]byte{receiver, big.NewInt(1).Bytes()}
+	transferInput.GasProvided = gasForCompilation + 50000
+	vmOutput, err := host.RunSmartContractCall(transferInput)
+	require.Nil(tb, err)
+	vmOutputs = append(vmOutputs, vmOutput)
+
+	return vmOutputs
+}
+
 func runERC20Benchmark(tb testing.TB, nTransfers int, nRuns int) {
 	totalTokenSupply := big.NewInt(int64(nTransfers * nRuns))
 	host, mockBlockchainHook := deploy(tb, totalTokenSupply)
@@ -1,9 +1,11 @@
 package host
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 
 	vmcommon "github.com/kalyan3104/dme-vm-common"
@@ -104,6 +106,62 @@ func TestExecution_DeployWASM_WithoutMemory(t *testing.T) {
 	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
 }
 
+func TestExecution_DeployWASM_ImportNotAllowed(t *testing.T) {
+	newAddress := []byte("new smartcontract")
+	host := DefaultTestArwenForDeployment(t, 24, newAddress)
+	input := DefaultTestContractCreateInput()
+	input.GasProvided = 1000
+	input.ContractCode = GetTestSCCode("import-not-allowed", "../../")
+	vmOutput, err := host.RunSmartContractCreate(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
+	require.Contains(t, vmOutput.ReturnMessage, arwen.ErrImportNotAllowed.Error())
+}
+
+func TestExecution_DeployWASM_NoValidationPolicy(t *testing.T) {
+	stubBlockchainHook := &mock.BlockchainHookStub{}
+	stubBlockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &mock.AccountMock{Nonce: 24}, nil
+	}
+	hostParameters := DefaultTestVMHostParameters()
+	hostParameters.WASMValidationPolicy = arwen.WASMValidationPolicy{}
+	host, _ := DefaultTestArwenWithParameters(t, stubBlockchainHook, &mock.CryptoHookMock{}, hostParameters)
+
+	err := host.VerifyContractCode(GetTestSCCode("import-not-allowed", "../../"), nil)
+	require.False(t, errors.Is(err, arwen.ErrImportNotAllowed))
+
+	err = host.VerifyContractCode(GetTestSCCode("memory-too-large", "../../"), nil)
+	require.False(t, errors.Is(err, arwen.ErrMemoryTooLarge))
+}
+
+func TestExecution_DeployWASM_MemoryTooLarge(t *testing.T) {
+	newAddress := []byte("new smartcontract")
+	host := DefaultTestArwenForDeployment(t, 24, newAddress)
+	input := DefaultTestContractCreateInput()
+	input.GasProvided = 1000
+	input.ContractCode = GetTestSCCode("memory-too-large", "../../")
+	vmOutput, err := host.RunSmartContractCreate(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
+	require.Contains(t, vmOutput.ReturnMessage, arwen.ErrMemoryTooLarge.Error())
+}
+
+func TestExecution_VerifyContractCode(t *testing.T) {
+	host := DefaultTestArwenForDeployment(t, 24, []byte("new smartcontract"))
+
+	err := host.VerifyContractCode(GetTestSCCode("import-not-allowed", "../../"), nil)
+	require.True(t, errors.Is(err, arwen.ErrImportNotAllowed))
+
+	code := GetTestSCCode("counter", "../../")
+	require.Nil(t, host.VerifyContractCode(code, nil))
+	require.Nil(t, host.VerifyContractCode(code, []string{"increment", "decrement", "get"}))
+
+	err = host.VerifyContractCode(code, []string{})
+	require.True(t, errors.Is(err, arwen.ErrEndpointNotDeclared))
+}
+
 func TestExecution_DeployWASM_WrongInit(t *testing.T) {
 	newAddress := []byte("new smartcontract")
 	host := DefaultTestArwenForDeployment(t, 24, newAddress)
@@ -240,6 +298,23 @@ func TestExecution_Deploy_DisallowFloatingPoint(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, vmOutput)
 	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
+	require.Contains(t, vmOutput.ReturnMessage, arwen.ErrFloatingPointNotAllowed.Error())
+}
+
+func TestExecution_Deploy_InvalidCodeMetadata(t *testing.T) {
+	newAddress := []byte("new smartcontract")
+	host := DefaultTestArwenForDeployment(t, 24, newAddress)
+	input := DefaultTestContractCreateInput()
+	input.GasProvided = 1000
+	input.ContractCode = GetTestSCCode("init-correct", "../../")
+	input.ContractCodeMetadata = []byte{0xFF, 0xFF}
+	input.Arguments = [][]byte{{0}}
+
+	vmOutput, err := host.RunSmartContractCreate(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrInvalidCodeMetadata.Error(), vmOutput.ReturnMessage)
 }
 
 func TestExecution_CallGetUserAccountErr(t *testing.T) {
@@ -274,6 +349,140 @@ func TestExecution_CallOutOfGas(t *testing.T) {
 	require.Equal(t, arwen.ErrNotEnoughGas.Error(), vmOutput.ReturnMessage)
 }
 
+func TestExecution_Call_MemoryTooLarge(t *testing.T) {
+	code := GetTestSCCode("memory-too-large", "../../")
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "doNothing"
+
+	// Contracts already deployed are not limited by the validation policy.
+	host, _ := DefaultTestArwenForCall(t, code, nil)
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	hostParameters := DefaultTestVMHostParameters()
+	hostParameters.MaxMemoryPages = 32
+	host, _ = DefaultTestArwenForCallWithParameters(t, code, nil, nil, hostParameters)
+	vmOutput, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
+}
+
+func runGrowMemoryFunction(t *testing.T, host *vmHost, function string, pages int64) *vmcommon.VMOutput {
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = function
+	input.Arguments = [][]byte{big.NewInt(pages).Bytes()}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	return vmOutput
+}
+
+// defaultTestArwenForMemoryGrowGas creates a host which charges the memory
+// grown by contracts
+func defaultTestArwenForMemoryGrowGas(t *testing.T, code []byte, maxMemoryPages uint32) *vmHost {
+	hostParameters := DefaultTestVMHostParameters()
+	hostParameters.MemoryGrowGasEnabled = true
+	hostParameters.MaxMemoryPages = maxMemoryPages
+	host, _ := DefaultTestArwenForCallWithParameters(t, code, nil, nil, hostParameters)
+	return host
+}
+
+func TestExecution_Call_MemoryGrow(t *testing.T) {
+	code := GetTestSCCode("memory-grow", "../../")
+	host := defaultTestArwenForMemoryGrowGas(t, code, 0)
+	tracer := &mock.ExecutionTracerMock{}
+	host.SetTracer(tracer)
+
+	vmOutput := runGrowMemoryFunction(t, host, "growMemory", 0)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{2}}, vmOutput.ReturnData)
+	gasRemainingWithoutGrowth := vmOutput.GasRemaining
+
+	vmOutput = runGrowMemoryFunction(t, host, "growMemory", 10)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{12}}, vmOutput.ReturnData)
+	gasPerPage := host.Metering().GasSchedule().BaseOperationCost.MemoryGrowPerPage
+	require.Equal(t, gasRemainingWithoutGrowth-10*gasPerPage, vmOutput.GasRemaining)
+
+	memoryEvents := tracer.EventsOfType(arwen.TraceEventMemory)
+	require.Len(t, memoryEvents, 2)
+	require.Equal(t, uint32(2), memoryEvents[0].MemoryPages)
+	require.Equal(t, uint32(12), memoryEvents[1].MemoryPages)
+	require.Equal(t, parentAddress, memoryEvents[1].Address)
+
+	// Without VMHostParameters.MaxMemoryPages, the memory may grow beyond the
+	// limit of the validation policy.
+	maxDeployedMemoryPages := DefaultTestVMHostParameters().WASMValidationPolicy.MaxMemoryPages
+	vmOutput = runGrowMemoryFunction(t, host, "growMemory", int64(maxDeployedMemoryPages))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{byte(maxDeployedMemoryPages + 2)}}, vmOutput.ReturnData)
+}
+
+func TestExecution_Call_MemoryGrow_MaxMemoryPages(t *testing.T) {
+	code := GetTestSCCode("memory-grow", "../../")
+	hostParameters := DefaultTestVMHostParameters()
+	hostParameters.MaxMemoryPages = 20
+	host, _ := DefaultTestArwenForCallWithParameters(t, code, nil, nil, hostParameters)
+
+	// Growing beyond the limit makes memory.grow fail, so the contract traps.
+	vmOutput := runGrowMemoryFunction(t, host, "growMemory", 19)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+
+	vmOutput = runGrowMemoryFunction(t, host, "growMemory", 18)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{20}}, vmOutput.ReturnData)
+}
+
+func TestExecution_Call_MemoryGrow_GasDisabled(t *testing.T) {
+	code := GetTestSCCode("memory-grow", "../../")
+	host, _ := DefaultTestArwenForCall(t, code, nil)
+
+	vmOutput := runGrowMemoryFunction(t, host, "growMemory", 0)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	gasRemainingWithoutGrowth := vmOutput.GasRemaining
+
+	// Without MemoryGrowGasEnabled, the memory grown is not charged.
+	vmOutput = runGrowMemoryFunction(t, host, "growMemory", 10)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{12}}, vmOutput.ReturnData)
+	require.Equal(t, gasRemainingWithoutGrowth, vmOutput.GasRemaining)
+}
+
+func TestExecution_Call_MemoryGrow_GasCheckedBeforeExecution(t *testing.T) {
+	code := GetTestSCCode("memory-grow", "../../")
+	host := defaultTestArwenForMemoryGrowGas(t, code, 20)
+
+	vmOutput := runGrowMemoryFunction(t, host, "growMemory", 0)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	// The gas provided does not cover growing the memory up to the limit,
+	// so the execution is rejected even if it would not grow the memory.
+	host.Metering().GasSchedule().BaseOperationCost.MemoryGrowPerPage = 10000
+	vmOutput = runGrowMemoryFunction(t, host, "growMemory", 0)
+	require.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+}
+
+func TestExecution_Call_MemoryGrow_Failed(t *testing.T) {
+	code := GetTestSCCode("memory-grow", "../../")
+	host := defaultTestArwenForMemoryGrowGas(t, code, 0)
+	host.Metering().SetGasProfiling(true)
+
+	// The pages grown before the contract traps are charged as well.
+	vmOutput := runGrowMemoryFunction(t, host, "growMemoryAndFail", 10)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+
+	gasPerPage := host.Metering().GasSchedule().BaseOperationCost.MemoryGrowPerPage
+	profile := host.Metering().GasProfile()
+	require.Len(t, profile.Contracts, 1)
+	require.Equal(t, 10*gasPerPage, profile.Contracts[0].GasUsed[arwen.GasCategoryMemory])
+}
+
 func TestExecution_CallWasmerError(t *testing.T) {
 	code := []byte("not WASM")
 	host, _ := DefaultTestArwenForCall(t, code, nil)
@@ -337,6 +546,161 @@ func TestExecution_Call_Successful(t *testing.T) {
 	require.Equal(t, big.NewInt(1002).Bytes(), storedBytes)
 }
 
+func TestExecution_Call_ParallelHosts(t *testing.T) {
+	code := GetTestSCCode("counter", "../../")
+
+	numHosts := 8
+	numCalls := 20
+	hosts := make([]*vmHost, numHosts)
+	for i := 0; i < numHosts; i++ {
+		counterValue := int64(1000 * (i + 1))
+		host, stubBlockchainHook := DefaultTestArwenForCall(t, code, nil)
+		stubBlockchainHook.GetStorageDataCalled = func(scAddress []byte, key []byte) ([]byte, error) {
+			return big.NewInt(counterValue).Bytes(), nil
+		}
+		hosts[i] = host
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, numHosts)
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host *vmHost) {
+			defer wg.Done()
+			expectedCounter := big.NewInt(int64(1000*(i+1)) + 1).Bytes()
+			for c := 0; c < numCalls; c++ {
+				input := DefaultTestContractCallInput()
+				input.GasProvided = 100000
+				input.Function = "increment"
+
+				vmOutput, err := host.RunSmartContractCall(input)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if vmOutput.ReturnCode != vmcommon.Ok {
+					errs <- fmt.Errorf("host %d: %s", i, vmOutput.ReturnMessage)
+					return
+				}
+
+				storedBytes := vmOutput.OutputAccounts[string(parentAddress)].StorageUpdates[string(counterKey)].Data
+				if !bytes.Equal(expectedCounter, storedBytes) {
+					errs <- fmt.Errorf("host %d: unexpected counter %v", i, storedBytes)
+					return
+				}
+			}
+		}(i, host)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.Nil(t, err)
+	}
+}
+
+func TestExecution_Call_GasProfile(t *testing.T) {
+	code := GetTestSCCode("counter", "../../")
+	host, stubBlockchainHook := DefaultTestArwenForCall(t, code, nil)
+	stubBlockchainHook.GetStorageDataCalled = func(scAddress []byte, key []byte) ([]byte, error) {
+		return big.NewInt(1001).Bytes(), nil
+	}
+	host.Metering().SetGasProfiling(true)
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "increment"
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	profile := host.Metering().GasProfile()
+	require.Len(t, profile.Contracts, 1)
+
+	contract := profile.Contracts[0]
+	require.Equal(t, parentAddress, contract.Address)
+	require.Equal(t, input.GasProvided-vmOutput.GasRemaining, contract.TotalGasUsed())
+	require.NotZero(t, contract.GasUsed[arwen.GasCategoryOpcodes])
+	require.NotZero(t, contract.GasUsed[arwen.GasCategoryCompilation])
+	require.NotZero(t, contract.GasUsed[arwen.GasCategoryForAPI("int64storageLoad")])
+	require.NotZero(t, contract.GasUsed[arwen.GasCategoryForAPI("int64storageStore")])
+	require.NotZero(t, contract.GasUsed[arwen.GasCategoryForAPI("int64finish")])
+}
+
+// defaultTestArwenForPayableCheck creates an Arwen vmHost for testing, which
+// rejects the call value sent to contracts which are not payable
+func defaultTestArwenForPayableCheck(t *testing.T, code []byte) (*vmHost, *mock.BlockchainHookStub) {
+	hostParameters := DefaultTestVMHostParameters()
+	hostParameters.PayableCheckEnabled = true
+	return DefaultTestArwenForCallWithParameters(t, code, nil, nil, hostParameters)
+}
+
+func TestExecution_Call_NonPayable(t *testing.T) {
+	code := GetTestSCCode("counter", "../../")
+	host, stubBlockchainHook := defaultTestArwenForPayableCheck(t, code)
+	stubBlockchainHook.GetUserAccountCalled = func(scAddress []byte) (vmcommon.UserAccountHandler, error) {
+		return &mock.AccountMock{
+			Code:         code,
+			CodeMetadata: (&arwen.CodeMetadata{Upgradeable: true}).ToBytes(),
+		}, nil
+	}
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "increment"
+	input.CallValue = big.NewInt(10)
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrNonPayableContract.Error(), vmOutput.ReturnMessage)
+}
+
+func TestExecution_Call_NonPayable_CheckDisabled(t *testing.T) {
+	code := GetTestSCCode("counter", "../../")
+	host, stubBlockchainHook := DefaultTestArwenForCall(t, code, nil)
+	stubBlockchainHook.GetUserAccountCalled = func(scAddress []byte) (vmcommon.UserAccountHandler, error) {
+		return &mock.AccountMock{
+			Code:         code,
+			CodeMetadata: []byte{1, 0},
+		}, nil
+	}
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "increment"
+	input.CallValue = big.NewInt(10)
+
+	// Until the check is enabled, contracts deployed without the payable flag
+	// keep accepting call value.
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestExecution_Call_PayableCheck_InvalidCodeMetadata(t *testing.T) {
+	code := GetTestSCCode("counter", "../../")
+	host, stubBlockchainHook := defaultTestArwenForPayableCheck(t, code)
+	stubBlockchainHook.GetUserAccountCalled = func(scAddress []byte) (vmcommon.UserAccountHandler, error) {
+		return &mock.AccountMock{
+			Code:         code,
+			CodeMetadata: []byte{0xFF, 0xFF},
+		}, nil
+	}
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "increment"
+	input.CallValue = big.NewInt(10)
+
+	// The call value must not be accepted when the code metadata can't be read
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.NotEqual(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrInvalidCodeMetadata.Error(), vmOutput.ReturnMessage)
+}
+
 func TestExecution_ExecuteOnSameContext_Simple(t *testing.T) {
 	parentCode := GetTestSCCode("exec-same-ctx-simple-parent", "../../")
 	childCode := GetTestSCCode("exec-same-ctx-simple-child", "../../")
@@ -557,6 +921,40 @@ func TestExecution_ExecuteOnSameContext_Recursive_Direct_ErrMaxInstances(t *test
 	require.Equal(t, int64(1), host.BigInt().GetOne(16).Int64())
 }
 
+func TestExecution_ExecuteOnSameContext_Recursive_Direct_NonReentrant(t *testing.T) {
+	code := GetTestSCCode("exec-same-ctx-recursive", "../../")
+	scBalance := big.NewInt(1000)
+
+	host, stubBlockchainHook := DefaultTestArwenForCall(t, code, scBalance)
+	setTestNonReentrantContract(stubBlockchainHook, parentAddress)
+	tracer := &mock.ExecutionTracerMock{}
+	host.SetTracer(tracer)
+
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = parentAddress
+	input.Function = "callRecursive"
+	input.GasProvided = 1000000
+
+	recursiveCalls := byte(5)
+	input.Arguments = [][]byte{
+		{recursiveCalls},
+	}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+
+	// The first recursive call is rejected, leaving only the outputs of the
+	// first iteration, like when the recursion exceeds the instance limit
+	expectedVMOutput := expectedVMOutput_SameCtx_Recursive_Direct_ErrMaxInstances(int(recursiveCalls))
+	expectedVMOutput.GasRemaining = vmOutput.GasRemaining
+	require.Equal(t, expectedVMOutput, vmOutput)
+
+	rejectedCalls := exitEventsWithError(tracer, arwen.ErrReentrantCall)
+	require.Len(t, rejectedCalls, 1)
+	require.Equal(t, arwen.TraceKindSameContext, rejectedCalls[0].Kind)
+	require.Equal(t, parentAddress, rejectedCalls[0].Address)
+}
+
 func TestExecution_ExecuteOnSameContext_Recursive_Mutual_Methods(t *testing.T) {
 	// Scenario:
 	// SC has a method "callRecursiveMutualMethods" which takes a byte as
@@ -724,6 +1122,28 @@ func TestExecution_ExecuteOnSameContext_BuiltinFunctions(t *testing.T) {
 	require.Equal(t, expectedVMOutput, vmOutput)
 }
 
+func setTestNonReentrantContract(stubBlockchainHook *mock.BlockchainHookStub, address []byte) {
+	getUserAccount := stubBlockchainHook.GetUserAccountCalled
+	stubBlockchainHook.GetUserAccountCalled = func(scAddress []byte) (vmcommon.UserAccountHandler, error) {
+		account, err := getUserAccount(scAddress)
+		if err == nil && bytes.Equal(scAddress, address) {
+			metadata := &arwen.CodeMetadata{Payable: true, NonReentrant: true}
+			account.(*mock.AccountMock).CodeMetadata = metadata.ToBytes()
+		}
+		return account, err
+	}
+}
+
+func exitEventsWithError(tracer *mock.ExecutionTracerMock, err error) []*arwen.TraceEvent {
+	events := make([]*arwen.TraceEvent, 0)
+	for _, event := range tracer.EventsOfType(arwen.TraceEventExecutionExit) {
+		if event.Error == err.Error() {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
 func dummyProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
 	outPutAccounts := make(map[string]*vmcommon.OutputAccount)
 	outPutAccounts[string(parentAddress)] = &vmcommon.OutputAccount{BalanceDelta: big.NewInt(0)}
@@ -830,6 +1250,36 @@ func TestExecution_ExecuteOnDestContext_Successful(t *testing.T) {
 	require.Equal(t, expectedVMOutput, vmOutput)
 }
 
+func TestExecution_ExecuteOnDestContext_GasProfile(t *testing.T) {
+	parentCode := GetTestSCCode("exec-dest-ctx-parent", "../../")
+	childCode := GetTestSCCode("exec-dest-ctx-child", "../../")
+	parentSCBalance := big.NewInt(1000)
+
+	host, _ := DefaultTestArwenForTwoSCs(t, parentCode, childCode, parentSCBalance)
+	host.Metering().SetGasProfiling(true)
+
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = parentAddress
+	input.Function = "parentFunctionChildCall"
+	input.GasProvided = 1000000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	profile := host.Metering().GasProfile()
+	require.Len(t, profile.Contracts, 2)
+
+	parent := profile.Contracts[0]
+	child := profile.Contracts[1]
+	require.Equal(t, parentAddress, parent.Address)
+	require.Equal(t, childAddress, child.Address)
+	require.Equal(t, input.GasProvided-vmOutput.GasRemaining, parent.TotalGasUsed())
+	require.Equal(t, child.TotalGasUsed(), parent.GasUsed[arwen.GasCategoryNestedCalls])
+	require.NotZero(t, child.GasUsed[arwen.GasCategoryOpcodes])
+	require.NotZero(t, parent.GasUsed[arwen.GasCategoryForAPI("executeOnDestContext")])
+}
+
 func TestExecution_ExecuteOnDestContext_Successful_BigInts(t *testing.T) {
 	parentCode := GetTestSCCode("exec-dest-ctx-parent", "../../")
 	childCode := GetTestSCCode("exec-dest-ctx-child", "../../")
@@ -930,6 +1380,108 @@ func TestExecution_ExecuteOnDestContext_Recursive_Mutual_SCs(t *testing.T) {
 	require.Equal(t, int64(1), host.BigInt().GetOne(88).Int64())
 }
 
+func TestExecution_ExecuteOnDestContext_Recursive_Direct_MaxCallDepth(t *testing.T) {
+	code := GetTestSCCode("exec-dest-ctx-recursive", "../../")
+	scBalance := big.NewInt(1000)
+
+	hostParameters := DefaultTestVMHostParameters()
+	hostParameters.MaxCallDepth = 3
+	host, _ := DefaultTestArwenForCallWithParameters(t, code, scBalance, nil, hostParameters)
+	tracer := &mock.ExecutionTracerMock{}
+	host.SetTracer(tracer)
+
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = parentAddress
+	input.Function = "callRecursive"
+	input.GasProvided = 1000000
+
+	recursiveCalls := byte(6)
+	input.Arguments = [][]byte{
+		{recursiveCalls},
+	}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, []byte("Rfinish006"), vmOutput.ReturnData[0])
+
+	// Three nested calls are executed, the fourth one is rejected
+	nestedCalls := 0
+	for _, event := range tracer.EventsOfType(arwen.TraceEventExecutionEnter) {
+		if event.Kind == arwen.TraceKindDestContext {
+			nestedCalls++
+		}
+	}
+	require.Equal(t, 4, nestedCalls)
+	require.Len(t, exitEventsWithError(tracer, arwen.ErrMaxCallDepthReached), 1)
+}
+
+func TestExecution_ExecuteOnDestContext_Recursive_Direct_LegacyCodeMetadata(t *testing.T) {
+	code := GetTestSCCode("exec-dest-ctx-recursive", "../../")
+	scBalance := big.NewInt(1000)
+
+	// The code metadata of the recipient cannot be read, as it was written by
+	// an older version or is malformed, so the recipient is not considered
+	// non-reentrant and the nested calls proceed.
+	for _, codeMetadata := range [][]byte{nil, {1}, {0xFF, 0xFF}} {
+		host, stubBlockchainHook := DefaultTestArwenForCall(t, code, scBalance)
+		getUserAccount := stubBlockchainHook.GetUserAccountCalled
+		stubBlockchainHook.GetUserAccountCalled = func(scAddress []byte) (vmcommon.UserAccountHandler, error) {
+			account, err := getUserAccount(scAddress)
+			if err == nil {
+				account.(*mock.AccountMock).CodeMetadata = codeMetadata
+			}
+			return account, err
+		}
+		tracer := &mock.ExecutionTracerMock{}
+		host.SetTracer(tracer)
+
+		input := DefaultTestContractCallInput()
+		input.RecipientAddr = parentAddress
+		input.Function = "callRecursive"
+		input.GasProvided = 1000000
+		input.Arguments = [][]byte{{2}}
+
+		vmOutput, err := host.RunSmartContractCall(input)
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+		require.Equal(t, []byte("Rfinish002"), vmOutput.ReturnData[0])
+		require.Len(t, exitEventsWithError(tracer, arwen.ErrInvalidCodeMetadata), 0)
+	}
+}
+
+func TestExecution_ExecuteOnDestContext_Recursive_Mutual_SCs_NonReentrant(t *testing.T) {
+	parentCode := GetTestSCCode("exec-dest-ctx-recursive-parent", "../../")
+	childCode := GetTestSCCode("exec-dest-ctx-recursive-child", "../../")
+	parentSCBalance := big.NewInt(1000)
+
+	// The parent may call the child, but the child may not call back the
+	// parent while the parent is still on the call stack.
+	host, stubBlockchainHook := DefaultTestArwenForTwoSCs(t, parentCode, childCode, parentSCBalance)
+	setTestNonReentrantContract(stubBlockchainHook, parentAddress)
+	tracer := &mock.ExecutionTracerMock{}
+	host.SetTracer(tracer)
+
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = parentAddress
+	input.Function = "parentCallsChild"
+	input.GasProvided = 1000000
+
+	recursiveCalls := byte(6)
+	input.Arguments = [][]byte{
+		{recursiveCalls},
+	}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	rejectedCalls := exitEventsWithError(tracer, arwen.ErrReentrantCall)
+	require.Len(t, rejectedCalls, 1)
+	require.Equal(t, arwen.TraceKindDestContext, rejectedCalls[0].Kind)
+	require.Equal(t, parentAddress, rejectedCalls[0].Address)
+}
+
 func TestExecution_ExecuteOnDestContext_Recursive_Mutual_SCs_OutOfGas(t *testing.T) {
 	// TODO this test needs a Wasmer fix to pass completely
 	t.Skip()
@@ -959,6 +1511,87 @@ func TestExecution_ExecuteOnDestContext_Recursive_Mutual_SCs_OutOfGas(t *testing
 	require.Equal(t, arwen.ErrNotEnoughGas.Error(), vmOutput.ReturnMessage)
 }
 
+func TestExecution_UpgradeContract_Indirect(t *testing.T) {
+	parentCode := GetTestSCCode("upgrader", "../../")
+	childCode := GetTestSCCode("exec-dest-ctx-child", "../../")
+	newChildCode := GetTestSCCode("init-correct", "../../")
+	newChildCodeMetadata := []byte{1, 0}
+
+	host, _ := defaultTestArwenForUpgrade(t, parentCode, childCode, []byte{1, 0}, parentAddress)
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = parentAddress
+	input.Function = "upgradeChildContract"
+	input.GasProvided = 1000000
+	input.Arguments = [][]byte{childAddress, newChildCode, newChildCodeMetadata}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("init successful"), []byte("succ")}, vmOutput.ReturnData)
+	require.Equal(t, newChildCode, vmOutput.OutputAccounts[string(childAddress)].Code)
+	require.Equal(t, newChildCodeMetadata, vmOutput.OutputAccounts[string(childAddress)].CodeMetadata)
+}
+
+func TestExecution_UpgradeContract_Indirect_NotOwner(t *testing.T) {
+	parentCode := GetTestSCCode("upgrader", "../../")
+	childCode := GetTestSCCode("exec-dest-ctx-child", "../../")
+	newChildCode := GetTestSCCode("init-correct", "../../")
+
+	host, _ := defaultTestArwenForUpgrade(t, parentCode, childCode, []byte{1, 0}, userAddress)
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = parentAddress
+	input.Function = "upgradeChildContract"
+	input.GasProvided = 1000000
+	input.Arguments = [][]byte{childAddress, newChildCode, {1, 0}}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("fail")}, vmOutput.ReturnData)
+	require.NotContains(t, vmOutput.OutputAccounts, string(childAddress))
+}
+
+func TestExecution_UpgradeContract_Indirect_NotUpgradeable(t *testing.T) {
+	parentCode := GetTestSCCode("upgrader", "../../")
+	childCode := GetTestSCCode("exec-dest-ctx-child", "../../")
+	newChildCode := GetTestSCCode("init-correct", "../../")
+
+	host, _ := defaultTestArwenForUpgrade(t, parentCode, childCode, []byte{0, 2}, parentAddress)
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = parentAddress
+	input.Function = "upgradeChildContract"
+	input.GasProvided = 1000000
+	input.Arguments = [][]byte{childAddress, newChildCode, {1, 0}}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("fail")}, vmOutput.ReturnData)
+	require.NotContains(t, vmOutput.OutputAccounts, string(childAddress))
+}
+
+func TestExecution_UpgradeContract_Direct_NotUpgradeable(t *testing.T) {
+	parentCode := GetTestSCCode("upgrader", "../../")
+	childCode := GetTestSCCode("exec-dest-ctx-child", "../../")
+	newChildCode := GetTestSCCode("init-correct", "../../")
+
+	host, _ := defaultTestArwenForUpgrade(t, parentCode, childCode, []byte{0, 0}, userAddress)
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = childAddress
+	input.Function = arwen.UpgradeFunctionName
+	input.GasProvided = 1000000
+	input.Arguments = [][]byte{newChildCode, {1, 0}, {0}}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.UpgradeFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrContractNotUpgradeable.Error(), vmOutput.ReturnMessage)
+}
+
 func TestExecution_AsyncCall(t *testing.T) {
 	// Scenario
 	// Parent SC calls Child SC
@@ -1057,3 +1690,268 @@ func TestExecution_AsyncCall_CallBackFails(t *testing.T) {
 	expectedVMOutput.GasRemaining = vmOutput.GasRemaining
 	require.Equal(t, expectedVMOutput, vmOutput)
 }
+
+func TestExecution_AsyncCall_CallbackGeneratesAsyncCalls(t *testing.T) {
+	// Scenario
+	// Parent performs an async call to Child, which is executed on this host
+	// The callback of Parent increments a counter and performs an async call
+	// to ThirdParty, which has no code on this host
+	// Assertions: the async call to ThirdParty is sent out and saved as pending
+	code := GetTestSCCode("async-callback-chain", "../../")
+	host, _ := DefaultTestArwenForTwoSCs(t, code, code, big.NewInt(1000))
+
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = parentAddress
+	input.Function = "startChainToThirdParty"
+	input.GasProvided = 1_000_000
+	input.CurrentTxHash = []byte("txhash")
+	input.OriginalTxHash = []byte("txhash")
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	parentAccount := vmOutput.OutputAccounts[string(parentAddress)]
+	require.NotNil(t, parentAccount)
+	require.Equal(t, []byte{1}, parentAccount.StorageUpdates["counter"].Data)
+
+	asyncCallsKey := arwen.CustomStorageKey(arwen.AsyncDataPrefix, input.OriginalTxHash)
+	storedAsyncInfo, err := arwen.DeserializeAsyncContextInfo(parentAccount.StorageUpdates[string(asyncCallsKey)].Data)
+	require.Nil(t, err)
+	require.Len(t, storedAsyncInfo.AsyncContextMap, 1)
+
+	pendingCalls := storedAsyncInfo.AsyncContextMap["chain"].AsyncCalls
+	require.Len(t, pendingCalls, 1)
+	require.Equal(t, thirdPartyAddress, pendingCalls[0].Destination)
+	require.Equal(t, "finalCallback", pendingCalls[0].SuccessCallback)
+
+	thirdPartyAccount := vmOutput.OutputAccounts[string(thirdPartyAddress)]
+	require.NotNil(t, thirdPartyAccount)
+	require.Equal(t, vmcommon.AsynchronousCall, thirdPartyAccount.CallType)
+	require.Equal(t, []byte("childFunction"), thirdPartyAccount.Data)
+}
+
+func TestExecution_AsyncCall_CallbackChainIsLimited(t *testing.T) {
+	// Scenario
+	// Parent performs an async call to Child, which is executed on this host
+	// Every callback of Parent increments a counter and performs a new async
+	// call to Child, so the chain of callbacks never ends by itself
+	// Assertions: the callback exceeding MaximumAsyncCallbackDepth fails, which
+	// reverts its changes, but not the ones of the callbacks before it
+	code := GetTestSCCode("async-callback-chain", "../../")
+	host, _ := DefaultTestArwenForTwoSCs(t, code, code, big.NewInt(1000))
+
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = parentAddress
+	input.Function = "startEndlessChain"
+	input.GasProvided = 1_000_000
+	input.CurrentTxHash = []byte("txhash")
+	input.OriginalTxHash = []byte("txhash")
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	parentAccount := vmOutput.OutputAccounts[string(parentAddress)]
+	require.NotNil(t, parentAccount)
+
+	expectedCounter := big.NewInt(int64(MaximumAsyncCallbackDepth - 1)).Bytes()
+	require.Equal(t, expectedCounter, parentAccount.StorageUpdates["counter"].Data)
+	require.Equal(t, uint64(0), host.asyncCallbackDepth)
+}
+
+func TestExecution_AsyncCall_ContextCallback(t *testing.T) {
+	// Scenario
+	// Parent performs two async calls to Child in the same async context and
+	// sets the callback of the async context
+	// Both async calls are executed on this host, so the callback of the async
+	// context is executed after their own callbacks
+	code := GetTestSCCode("async-callback-chain", "../../")
+	host, _ := DefaultTestArwenForTwoSCs(t, code, code, big.NewInt(1000))
+
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = parentAddress
+	input.Function = "startGroupWithCallback"
+	input.GasProvided = 1_000_000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	parentAccount := vmOutput.OutputAccounts[string(parentAddress)]
+	require.NotNil(t, parentAccount)
+	require.Equal(t, []byte{2}, parentAccount.StorageUpdates["counter"].Data)
+	require.Equal(t, []byte{1}, parentAccount.StorageUpdates["groups"].Data)
+}
+
+func TestExecution_AsyncCall_CrossShardCallback_Error(t *testing.T) {
+	// Scenario
+	// Parent has a pending async call to ThirdParty, saved to its storage
+	// ThirdParty sends back the callback with an error return code
+	// Assertions: the error callback of the async call is executed, then the
+	// callback of the async context, which is now resolved
+	code := GetTestSCCode("async-callback-chain", "../../")
+	host, stubBlockchainHook := DefaultTestArwenForTwoSCs(t, code, code, big.NewInt(1000))
+
+	asyncInfo := &arwen.AsyncContextInfo{CallerAddr: vaultAddress}
+	asyncInfo.AddAsyncCall("chain", "groupCallback", &arwen.AsyncGeneratedCall{
+		Destination:     thirdPartyAddress,
+		Data:            []byte("childFunction"),
+		SuccessCallback: "finalCallback",
+		ErrorCallback:   "errorCallback",
+	})
+	asyncCallsKey := setAsyncContextInfoToStorage(stubBlockchainHook, []byte("txhash"), asyncInfo)
+
+	input := DefaultTestContractCallInput()
+	input.CallerAddr = thirdPartyAddress
+	input.RecipientAddr = parentAddress
+	input.Function = "callBack"
+	input.CallType = vmcommon.AsynchronousCallBack
+	input.Arguments = [][]byte{[]byte(vmcommon.UserError.String())}
+	input.GasProvided = 1_000_000
+	input.CurrentTxHash = []byte("txhash")
+	input.OriginalTxHash = []byte("txhash")
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	parentAccount := vmOutput.OutputAccounts[string(parentAddress)]
+	require.NotNil(t, parentAccount)
+	require.NotContains(t, parentAccount.StorageUpdates, "counter")
+	require.Equal(t, []byte{1}, parentAccount.StorageUpdates["errors"].Data)
+	require.Equal(t, []byte{1}, parentAccount.StorageUpdates["groups"].Data)
+	require.Len(t, parentAccount.StorageUpdates[string(asyncCallsKey)].Data, 0)
+}
+
+func TestExecution_AsyncCall_CrossShardCallback_GeneratesAsyncCalls(t *testing.T) {
+	// Scenario
+	// Parent has a pending async call to ThirdParty, saved to its storage
+	// ThirdParty sends back the callback with a successful return code
+	// The callback of Parent increments a counter and performs an async call
+	// to ThirdParty again
+	// Assertions: the new async call is sent out and saved as pending, so the
+	// original caller of Parent is not notified yet
+	code := GetTestSCCode("async-callback-chain", "../../")
+	host, stubBlockchainHook := DefaultTestArwenForTwoSCs(t, code, code, big.NewInt(1000))
+
+	asyncInfo := &arwen.AsyncContextInfo{CallerAddr: vaultAddress}
+	asyncInfo.AddAsyncCall("chain", "", &arwen.AsyncGeneratedCall{
+		Destination:     thirdPartyAddress,
+		Data:            []byte("childFunction"),
+		SuccessCallback: "callbackToThirdParty",
+		ErrorCallback:   "errorCallback",
+	})
+	asyncCallsKey := setAsyncContextInfoToStorage(stubBlockchainHook, []byte("txhash"), asyncInfo)
+
+	input := DefaultTestContractCallInput()
+	input.CallerAddr = thirdPartyAddress
+	input.RecipientAddr = parentAddress
+	input.Function = "callBack"
+	input.CallType = vmcommon.AsynchronousCallBack
+	input.Arguments = [][]byte{[]byte(vmcommon.Ok.String())}
+	input.GasProvided = 1_000_000
+	input.CurrentTxHash = []byte("txhash")
+	input.OriginalTxHash = []byte("txhash")
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	parentAccount := vmOutput.OutputAccounts[string(parentAddress)]
+	require.NotNil(t, parentAccount)
+	require.Equal(t, []byte{1}, parentAccount.StorageUpdates["counter"].Data)
+
+	storedAsyncInfo, err := arwen.DeserializeAsyncContextInfo(parentAccount.StorageUpdates[string(asyncCallsKey)].Data)
+	require.Nil(t, err)
+	require.Equal(t, vaultAddress, storedAsyncInfo.CallerAddr)
+	require.Len(t, storedAsyncInfo.AsyncContextMap, 1)
+
+	pendingCalls := storedAsyncInfo.AsyncContextMap["chain"].AsyncCalls
+	require.Len(t, pendingCalls, 1)
+	require.Equal(t, thirdPartyAddress, pendingCalls[0].Destination)
+	require.Equal(t, "finalCallback", pendingCalls[0].SuccessCallback)
+
+	thirdPartyAccount := vmOutput.OutputAccounts[string(thirdPartyAddress)]
+	require.NotNil(t, thirdPartyAccount)
+	require.Equal(t, vmcommon.AsynchronousCall, thirdPartyAccount.CallType)
+	require.Equal(t, []byte("childFunction"), thirdPartyAccount.Data)
+	require.NotContains(t, vmOutput.OutputAccounts, string(vaultAddress))
+}
+
+func TestExecution_AsyncCall_CrossShardCallback_Expired(t *testing.T) {
+	// Scenario
+	// Parent has two pending async calls, to ThirdParty and Vault, in an async
+	// context whose deadline has passed
+	// ThirdParty sends back the callback with a successful return code
+	// Assertions: the error callbacks of both async calls are executed, then
+	// the callback of the async context
+	code := GetTestSCCode("async-callback-chain", "../../")
+	host, stubBlockchainHook := DefaultTestArwenForTwoSCs(t, code, code, big.NewInt(1000))
+	stubBlockchainHook.CurrentTimeStampCalled = func() uint64 {
+		return 200
+	}
+
+	asyncInfo := &arwen.AsyncContextInfo{CallerAddr: vaultAddress}
+	for _, destination := range [][]byte{thirdPartyAddress, vaultAddress} {
+		asyncInfo.AddAsyncCall("chain", "groupCallback", &arwen.AsyncGeneratedCall{
+			Destination:     destination,
+			Data:            []byte("childFunction"),
+			SuccessCallback: "finalCallback",
+			ErrorCallback:   "errorCallback",
+		})
+	}
+	asyncInfo.AsyncContextMap["chain"].Deadline = 100
+	asyncCallsKey := setAsyncContextInfoToStorage(stubBlockchainHook, []byte("txhash"), asyncInfo)
+
+	input := DefaultTestContractCallInput()
+	input.CallerAddr = thirdPartyAddress
+	input.RecipientAddr = parentAddress
+	input.Function = "callBack"
+	input.CallType = vmcommon.AsynchronousCallBack
+	input.Arguments = [][]byte{[]byte(vmcommon.Ok.String())}
+	input.GasProvided = 1_000_000
+	input.CurrentTxHash = []byte("txhash")
+	input.OriginalTxHash = []byte("txhash")
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	parentAccount := vmOutput.OutputAccounts[string(parentAddress)]
+	require.NotNil(t, parentAccount)
+	require.NotContains(t, parentAccount.StorageUpdates, "counter")
+	require.Equal(t, []byte{2}, parentAccount.StorageUpdates["errors"].Data)
+	require.Equal(t, []byte{1}, parentAccount.StorageUpdates["groups"].Data)
+	require.Len(t, parentAccount.StorageUpdates[string(asyncCallsKey)].Data, 0)
+}
+
+func TestExecution_AsyncCall_ReturnCodeOfCallback(t *testing.T) {
+	require.Equal(t, vmcommon.Ok, asyncCallReturnCode(nil))
+	require.Equal(t, vmcommon.Ok, asyncCallReturnCode([][]byte{{}}))
+	require.Equal(t, vmcommon.Ok, asyncCallReturnCode([][]byte{{0}}))
+	require.Equal(t, vmcommon.Ok, asyncCallReturnCode([][]byte{[]byte("ok")}))
+	require.Equal(t, vmcommon.UserError, asyncCallReturnCode([][]byte{{4}}))
+	require.Equal(t, vmcommon.UserError, asyncCallReturnCode([][]byte{[]byte("user error")}))
+	require.Equal(t, vmcommon.OutOfGas, asyncCallReturnCode([][]byte{[]byte("out of gas")}))
+	require.Equal(t, vmcommon.ExecutionFailed, asyncCallReturnCode([][]byte{{1, 0, 0, 0, 0, 0, 0, 0, 0}}))
+	require.Equal(t, vmcommon.ExecutionFailed, asyncCallReturnCode([][]byte{[]byte("okay")}))
+
+	require.True(t, isAsyncCallSuccessful([][]byte{{0, 0}, []byte("result")}))
+	require.False(t, isAsyncCallSuccessful([][]byte{[]byte("function not found")}))
+	require.False(t, isAsyncCallSuccessful(asyncCallExpiredArguments()))
+}
+
+// setAsyncContextInfoToStorage makes the async calls pending for the parent
+// SmartContract, as if they were saved to storage in a previous transaction
+func setAsyncContextInfoToStorage(stubBlockchainHook *mock.BlockchainHookStub, txHash []byte, asyncInfo *arwen.AsyncContextInfo) []byte {
+	asyncCallsKey := arwen.CustomStorageKey(arwen.AsyncDataPrefix, txHash)
+	stubBlockchainHook.GetStorageDataCalled = func(address []byte, key []byte) ([]byte, error) {
+		if bytes.Equal(address, parentAddress) && bytes.Equal(key, asyncCallsKey) {
+			return arwen.SerializeAsyncContextInfo(asyncInfo), nil
+		}
+		return nil, nil
+	}
+
+	return asyncCallsKey
+}
@@ -1,11 +1,13 @@
 package host
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
 	vmcommon "github.com/kalyan3104/dme-vm-common"
 	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/kalyan3104/dme-vm-go/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -129,3 +131,94 @@ func TestKalyan3104EI_int64getArgument(t *testing.T) {
 	i64val12345 = big.NewInt(0).SetBytes(data[2])
 	assert.Equal(t, big.NewInt(12345), i64val12345)
 }
+
+func runAccountInfoFunction(t *testing.T, input *vmcommon.ContractCallInput) *vmcommon.VMOutput {
+	code := GetTestSCCode("account-info", "../../")
+	host, stubBlockchainHook := DefaultTestArwenForCall(t, code, nil)
+	getUserAccount := stubBlockchainHook.GetUserAccountCalled
+	stubBlockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		if bytes.Equal(address, childAddress) {
+			metadata := &arwen.CodeMetadata{Upgradeable: true}
+			return &mock.AccountMock{
+				Code:         []byte("child code"),
+				CodeHash:     []byte("childSC code hash..............."),
+				CodeMetadata: metadata.ToBytes(),
+				Nonce:        42,
+			}, nil
+		}
+		if bytes.Equal(address, userAddress) {
+			return &mock.AccountMock{Nonce: 7}, nil
+		}
+		return getUserAccount(address)
+	}
+
+	input.GasProvided = 100000
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	return vmOutput
+}
+
+func TestKalyan3104EI_AccountInfo(t *testing.T) {
+	input := DefaultTestContractCallInput()
+	input.Function = "codeHash"
+	input.Arguments = [][]byte{childAddress}
+	vmOutput := runAccountInfoFunction(t, input)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("childSC code hash...............")}, vmOutput.ReturnData)
+
+	input.Function = "codeMetadata"
+	vmOutput = runAccountInfoFunction(t, input)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{(&arwen.CodeMetadata{Upgradeable: true}).ToBytes()}, vmOutput.ReturnData)
+
+	input.Function = "payable"
+	vmOutput = runAccountInfoFunction(t, input)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{}}, vmOutput.ReturnData)
+
+	// Accounts without code metadata, such as user accounts, are payable.
+	input.Arguments = [][]byte{userAddress}
+	vmOutput = runAccountInfoFunction(t, input)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{1}}, vmOutput.ReturnData)
+
+	input.Function = "accountNonce"
+	vmOutput = runAccountInfoFunction(t, input)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{7}}, vmOutput.ReturnData)
+}
+
+func TestKalyan3104EI_AccountInfo_NotAContract(t *testing.T) {
+	input := DefaultTestContractCallInput()
+	input.Function = "codeHash"
+	input.Arguments = [][]byte{userAddress}
+	vmOutput := runAccountInfoFunction(t, input)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrContractNotFound.Error(), vmOutput.ReturnMessage)
+}
+
+func TestKalyan3104EI_TransactionInfo(t *testing.T) {
+	input := DefaultTestContractCallInput()
+	input.Function = "gasPrice"
+	input.GasPrice = 1000
+	vmOutput := runAccountInfoFunction(t, input)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{big.NewInt(1000).Bytes()}, vmOutput.ReturnData)
+
+	input.Function = "currentTxHash"
+	input.CurrentTxHash = []byte("current transaction hash........")
+	input.OriginalTxHash = []byte("original transaction hash.......")
+	vmOutput = runAccountInfoFunction(t, input)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("current transaction hash........")}, vmOutput.ReturnData)
+}
+
+func TestKalyan3104EI_AccountInfo_Reserved(t *testing.T) {
+	host, _ := DefaultTestArwenForCall(t, nil, nil)
+	names := host.GetAPIMethods().Names()
+	for _, name := range []string{"getCodeHash", "getCodeMetadata", "getCurrentTxHash", "getGasPrice", "getAccountNonce", "isPayable"} {
+		_, ok := names[name]
+		require.True(t, ok, name)
+	}
+}
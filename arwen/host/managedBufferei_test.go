@@ -0,0 +1,86 @@
+package host
+
+import (
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/kalyan3104/dme-vm-common"
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/stretchr/testify/require"
+)
+
+var managedBufferKey = []byte("mBufferKey")
+
+func runManagedBufferFunction(t *testing.T, function string, arguments ...[]byte) *vmcommon.VMOutput {
+	code := GetTestSCCode("managed-buffers", "../../")
+	host, stubBlockchainHook := DefaultTestArwenForCall(t, code, nil)
+	stubBlockchainHook.GetStorageDataCalled = func(scAddress []byte, key []byte) ([]byte, error) {
+		return []byte("stored value"), nil
+	}
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = function
+	input.Arguments = arguments
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	return vmOutput
+}
+
+func TestManagedBufferEI_Append(t *testing.T) {
+	vmOutput := runManagedBufferFunction(t, "concatArguments", []byte("managed "), []byte("buffers"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("managed buffers")}, vmOutput.ReturnData)
+}
+
+func TestManagedBufferEI_Storage(t *testing.T) {
+	vmOutput := runManagedBufferFunction(t, "storeArgument", []byte("new value"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	storedBytes := vmOutput.OutputAccounts[string(parentAddress)].StorageUpdates[string(managedBufferKey)].Data
+	require.Equal(t, []byte("new value"), storedBytes)
+
+	vmOutput = runManagedBufferFunction(t, "loadStored")
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("stored value")}, vmOutput.ReturnData)
+}
+
+func TestManagedBufferEI_GetSlice(t *testing.T) {
+	vmOutput := runManagedBufferFunction(t, "sliceArgument", []byte("managed buffers"), []byte{8}, []byte{6})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("buffer")}, vmOutput.ReturnData)
+
+	vmOutput = runManagedBufferFunction(t, "sliceArgument", []byte("managed buffers"), []byte{8}, []byte{8})
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrBadBounds.Error(), vmOutput.ReturnMessage)
+}
+
+func TestManagedBufferEI_ToBigInt(t *testing.T) {
+	value := big.NewInt(0).Lsh(big.NewInt(1), 100)
+	vmOutput := runManagedBufferFunction(t, "argumentToBigInt", value.Bytes())
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{value.Bytes()}, vmOutput.ReturnData)
+}
+
+func TestManagedBufferEI_UnknownHandle(t *testing.T) {
+	vmOutput := runManagedBufferFunction(t, "finishUnknownHandle")
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrNoManagedBufferUnderThisHandle.Error(), vmOutput.ReturnMessage)
+	require.Zero(t, vmOutput.GasRemaining)
+}
+
+func TestManagedBufferEI_ExecuteOnDestContext(t *testing.T) {
+	// The child starts from an empty set of buffers, so its first new buffer
+	// takes handle 0; its changes are discarded when control returns.
+	vmOutput := runManagedBufferFunction(t, "parentDestContext", []byte("parent"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{}, []byte("child"), []byte("parent")}, vmOutput.ReturnData)
+}
+
+func TestManagedBufferEI_ExecuteOnSameContext(t *testing.T) {
+	// The child sees and modifies the buffers of the parent.
+	vmOutput := runManagedBufferFunction(t, "parentSameContext", []byte("parent"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{1}, []byte("child"), []byte("child")}, vmOutput.ReturnData)
+}
@@ -0,0 +1,198 @@
+package host
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/kalyan3104/dme-vm-common"
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/kalyan3104/dme-vm-go/config"
+	"github.com/kalyan3104/dme-vm-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var storageIterationState = map[string][]byte{
+	"item.a": []byte("a"),
+	"item.b": []byte("b"),
+	"item.c": []byte("c"),
+	"other":  []byte("other"),
+}
+
+func runStorageIterationFunction(t *testing.T, function string, arguments ...[]byte) *vmcommon.VMOutput {
+	return runStorageIterationFunctionWithState(t, storageIterationState, function, arguments...)
+}
+
+func runStorageIterationFunctionWithState(t *testing.T, state map[string][]byte, function string, arguments ...[]byte) *vmcommon.VMOutput {
+	code := GetTestSCCode("storage-iteration", "../../")
+	host, stubBlockchainHook := DefaultTestArwenForCall(t, code, nil)
+	stubBlockchainHook.GetAllStateCalled = func(address []byte) (map[string][]byte, error) {
+		return state, nil
+	}
+	stubBlockchainHook.GetStorageDataCalled = func(scAddress []byte, key []byte) ([]byte, error) {
+		return state[string(key)], nil
+	}
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 10000000
+	input.Function = function
+	input.Arguments = arguments
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	return vmOutput
+}
+
+func int32Argument(value int32) []byte {
+	argument := make([]byte, 4)
+	binary.BigEndian.PutUint32(argument, uint32(value))
+	return argument
+}
+
+func TestStorageEI_CountKeysWithPrefix(t *testing.T) {
+	vmOutput := runStorageIterationFunction(t, "countKeys", []byte("item."))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{3}}, vmOutput.ReturnData)
+
+	vmOutput = runStorageIterationFunction(t, "countKeys", []byte("missing"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{}}, vmOutput.ReturnData)
+}
+
+func TestStorageEI_GetKeysWithPrefix_Pages(t *testing.T) {
+	vmOutput := runStorageIterationFunction(t, "getKeys", []byte("item."), int32Argument(0), int32Argument(2), int32Argument(1024))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{2}, []byte("item.a"), []byte("item.b")}, vmOutput.ReturnData)
+
+	vmOutput = runStorageIterationFunction(t, "getKeys", []byte("item."), int32Argument(2), int32Argument(2), int32Argument(1024))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{1}, []byte("item.c")}, vmOutput.ReturnData)
+
+	vmOutput = runStorageIterationFunction(t, "getKeys", []byte("item."), int32Argument(3), int32Argument(2), int32Argument(1024))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{}}, vmOutput.ReturnData)
+
+	// Only whole keys are written: 10 bytes for each of "item.a" and "item.b".
+	vmOutput = runStorageIterationFunction(t, "getKeys", []byte("item."), int32Argument(0), int32Argument(3), int32Argument(25))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{2}, []byte("item.a"), []byte("item.b")}, vmOutput.ReturnData)
+}
+
+func TestStorageEI_GetKeysWithPrefix_Errors(t *testing.T) {
+	vmOutput := runStorageIterationFunction(t, "getKeys", []byte("item."), int32Argument(0), int32Argument(2), int32Argument(5))
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrStorageKeyTooLargeForBuffer.Error(), vmOutput.ReturnMessage)
+
+	vmOutput = runStorageIterationFunction(t, "getKeys", []byte("item."), int32Argument(-1), int32Argument(2), int32Argument(1024))
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrArgOutOfRange.Error(), vmOutput.ReturnMessage)
+}
+
+func TestStorageEI_GetKeysWithPrefix_PendingUpdates(t *testing.T) {
+	vmOutput := runStorageIterationFunction(t, "updateAndGetKeys", []byte("item.0"), []byte("item.b"), []byte("item."))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{3}, []byte("item.0"), []byte("item.a"), []byte("item.c")}, vmOutput.ReturnData)
+}
+
+func TestStorageEI_GetKeysWithPrefix_GasPerScannedKey(t *testing.T) {
+	fewKeys := runStorageIterationFunction(t, "countKeys", []byte("other"))
+	manyKeys := runStorageIterationFunction(t, "countKeys", []byte("item."))
+	require.Equal(t, vmcommon.Ok, fewKeys.ReturnCode)
+	require.Equal(t, vmcommon.Ok, manyKeys.ReturnCode)
+
+	// The prefixes have the same length and all the keys are scanned for
+	// both, so the number of matching keys makes no difference.
+	require.Equal(t, fewKeys.GasRemaining, manyKeys.GasRemaining)
+
+	largerState := map[string][]byte{"item.d": []byte("d")}
+	for key, value := range storageIterationState {
+		largerState[key] = value
+	}
+	noKeys := runStorageIterationFunction(t, "countKeys", []byte("missing"))
+	noKeysInLargerState := runStorageIterationFunctionWithState(t, largerState, "countKeys", []byte("missing"))
+	require.Equal(t, [][]byte{{}}, noKeysInLargerState.ReturnData)
+
+	gasSchedule, _ := config.CreateGasConfig(config.MakeGasMapForTests())
+	perKey := gasSchedule.Kalyan3104APICost.StorageKeyIteration
+	perByte := gasSchedule.BaseOperationCost.DataCopyPerByte
+	require.Equal(t, perKey+7*perByte, noKeys.GasRemaining-noKeysInLargerState.GasRemaining)
+}
+
+var readableAddress = []byte("readableSC......................")
+var otherShardAddress = []byte("otherShardSC....................")
+
+func runStorageReaderFunction(t *testing.T, function string, arguments ...[]byte) *vmcommon.VMOutput {
+	code := GetTestSCCode("storage-reader", "../../")
+	host, stubBlockchainHook := DefaultTestArwenForCall(t, code, nil)
+	getUserAccount := stubBlockchainHook.GetUserAccountCalled
+	stubBlockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		if bytes.Equal(address, readableAddress) {
+			return &mock.AccountMock{}, nil
+		}
+		if bytes.Equal(address, childAddress) {
+			metadata := &arwen.CodeMetadata{NotReadable: true}
+			return &mock.AccountMock{CodeMetadata: metadata.ToBytes()}, nil
+		}
+		return getUserAccount(address)
+	}
+	stubBlockchainHook.GetShardOfAddressCalled = func(address []byte) uint32 {
+		if bytes.Equal(address, otherShardAddress) {
+			return 1
+		}
+		return 0
+	}
+	stubBlockchainHook.GetStorageDataCalled = func(address []byte, key []byte) ([]byte, error) {
+		if bytes.Equal(key, []byte("amount")) {
+			return big.NewInt(1000000).Bytes(), nil
+		}
+		return append(address[:8:8], key...), nil
+	}
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = function
+	input.Arguments = arguments
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	return vmOutput
+}
+
+func TestStorageEI_LoadFromAddress(t *testing.T) {
+	vmOutput := runStorageReaderFunction(t, "readFromAddress", childAddress, []byte("key"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("childSC.key")}, vmOutput.ReturnData)
+
+	vmOutput = runStorageReaderFunction(t, "readBigIntFromAddress", childAddress, []byte("amount"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{big.NewInt(1000000).Bytes()}, vmOutput.ReturnData)
+
+	// Reading does not produce storage updates for the foreign account.
+	childAccount, ok := vmOutput.OutputAccounts[string(childAddress)]
+	if ok {
+		require.Empty(t, childAccount.StorageUpdates)
+	}
+}
+
+func TestStorageEI_LoadFromAddress_OtherShard(t *testing.T) {
+	vmOutput := runStorageReaderFunction(t, "readFromAddress", otherShardAddress, []byte("key"))
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrStorageReadFromOtherShard.Error(), vmOutput.ReturnMessage)
+
+	vmOutput = runStorageReaderFunction(t, "readBigIntFromAddress", otherShardAddress, []byte("amount"))
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrStorageReadFromOtherShard.Error(), vmOutput.ReturnMessage)
+}
+
+func TestStorageEI_LoadFromAddress_ProtectedKey(t *testing.T) {
+	vmOutput := runStorageReaderFunction(t, "readFromAddress", readableAddress, []byte("KALYAN3104key"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("readableKALYAN3104key")}, vmOutput.ReturnData)
+
+	vmOutput = runStorageReaderFunction(t, "readFromAddress", childAddress, []byte("KALYAN3104key"))
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrStorageKeyNotReadable.Error(), vmOutput.ReturnMessage)
+}
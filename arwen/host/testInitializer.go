@@ -59,7 +59,24 @@ func DefaultTestArwenForDeployment(t *testing.T, ownerNonce uint64, newAddress [
 }
 
 func DefaultTestArwenForCall(tb testing.TB, code []byte, balance *big.Int) (*vmHost, *mock.BlockchainHookStub) {
-	mockCryptoHook := &mock.CryptoHookMock{}
+	return DefaultTestArwenForCallWithCrypto(tb, code, balance, &mock.CryptoHookMock{})
+}
+
+// DefaultTestArwenForCallWithCrypto creates an Arwen vmHost configured for
+// testing a call to a SmartContract, with the given crypto hook
+func DefaultTestArwenForCallWithCrypto(tb testing.TB, code []byte, balance *big.Int, cryptoHook vmcommon.CryptoHook) (*vmHost, *mock.BlockchainHookStub) {
+	return DefaultTestArwenForCallWithParameters(tb, code, balance, cryptoHook, DefaultTestVMHostParameters())
+}
+
+// DefaultTestArwenForCallWithParameters creates an Arwen vmHost configured for
+// testing a call to a SmartContract, with the given crypto hook and parameters
+func DefaultTestArwenForCallWithParameters(
+	tb testing.TB,
+	code []byte,
+	balance *big.Int,
+	cryptoHook vmcommon.CryptoHook,
+	hostParameters *arwen.VMHostParameters,
+) (*vmHost, *mock.BlockchainHookStub) {
 	stubBlockchainHook := &mock.BlockchainHookStub{}
 	stubBlockchainHook.GetUserAccountCalled = func(scAddress []byte) (vmcommon.UserAccountHandler, error) {
 		if bytes.Equal(scAddress, parentAddress) {
@@ -71,7 +88,7 @@ func DefaultTestArwenForCall(tb testing.TB, code []byte, balance *big.Int) (*vmH
 		return nil, errAccountNotFound
 	}
 
-	host, _ := DefaultTestArwen(tb, stubBlockchainHook, mockCryptoHook)
+	host, _ := DefaultTestArwenWithParameters(tb, stubBlockchainHook, cryptoHook, hostParameters)
 	return host, stubBlockchainHook
 }
 
@@ -100,14 +117,75 @@ func DefaultTestArwenForTwoSCs(t *testing.T, parentCode []byte, childCode []byte
 	return host, stubBlockchainHook
 }
 
+// defaultTestArwenForUpgrade creates an Arwen vmHost configured for testing
+// upgrades of the child SmartContract, requested by the parent SmartContract
+func defaultTestArwenForUpgrade(
+	t *testing.T,
+	parentCode []byte,
+	childCode []byte,
+	childCodeMetadata []byte,
+	childOwner []byte,
+) (*vmHost, *mock.BlockchainHookStub) {
+	mockCryptoHook := &mock.CryptoHookMock{}
+	stubBlockchainHook := &mock.BlockchainHookStub{}
+
+	stubBlockchainHook.GetUserAccountCalled = func(scAddress []byte) (vmcommon.UserAccountHandler, error) {
+		if bytes.Equal(scAddress, parentAddress) {
+			return &mock.AccountMock{
+				Code:    parentCode,
+				Balance: big.NewInt(1000),
+			}, nil
+		}
+		if bytes.Equal(scAddress, childAddress) {
+			return &mock.AccountMock{
+				Code:         childCode,
+				CodeMetadata: childCodeMetadata,
+				OwnerAddress: childOwner,
+			}, nil
+		}
+
+		return nil, errAccountNotFound
+	}
+
+	host, _ := DefaultTestArwen(t, stubBlockchainHook, mockCryptoHook)
+	return host, stubBlockchainHook
+}
+
 func DefaultTestArwen(tb testing.TB, blockchain vmcommon.BlockchainHook, crypto vmcommon.CryptoHook) (*vmHost, error) {
-	host, err := NewArwenVM(blockchain, crypto, &arwen.VMHostParameters{
+	return DefaultTestArwenWithParameters(tb, blockchain, crypto, DefaultTestVMHostParameters())
+}
+
+// DefaultTestVMHostParameters creates the parameters of the Arwen vmHosts
+// created for testing
+func DefaultTestVMHostParameters() *arwen.VMHostParameters {
+	return &arwen.VMHostParameters{
 		VMType:                       defaultVMType,
 		BlockGasLimit:                uint64(1000),
 		GasSchedule:                  config.MakeGasMapForTests(),
 		ProtocolBuiltinFunctions:     make(vmcommon.FunctionNames),
 		Kalyan3104ProtectedKeyPrefix: []byte("KALYAN3104"),
-	})
+		DisallowFloatingPoint:        true,
+		WASMValidationPolicy: arwen.WASMValidationPolicy{
+			MaxFunctions:    10000,
+			MaxLocals:       2000,
+			MaxGlobals:      100,
+			MaxTableSize:    10000,
+			MaxDataSegments: 1000,
+			MaxMemoryPages:  32,
+			RestrictImports: true,
+		},
+	}
+}
+
+// DefaultTestArwenWithParameters creates an Arwen vmHost for testing, with
+// the given parameters
+func DefaultTestArwenWithParameters(
+	tb testing.TB,
+	blockchain vmcommon.BlockchainHook,
+	crypto vmcommon.CryptoHook,
+	hostParameters *arwen.VMHostParameters,
+) (*vmHost, error) {
+	host, err := NewArwenVM(blockchain, crypto, hostParameters)
 	require.Nil(tb, err)
 	require.NotNil(tb, host)
 	return host, err
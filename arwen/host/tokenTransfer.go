@@ -0,0 +1,109 @@
+package host
+
+import (
+	"math/big"
+
+	vmcommon "github.com/kalyan3104/dme-vm-common"
+	"github.com/kalyan3104/dme-vm-go/arwen"
+)
+
+// TransferTokens transfers the given tokens from the current SC to the
+// destination by calling the appropriate token transfer built-in function,
+// which is followed by a call to the given function of the destination, if
+// any. The function of a destination in the same shard is called
+// synchronously, while a transfer to another shard is sent to the
+// destination shard by the built-in function itself.
+func (host *vmHost) TransferTokens(
+	destination []byte,
+	gasLimit uint64,
+	transfers []*arwen.TokenTransfer,
+	function string,
+	arguments [][]byte,
+) error {
+	_, blockchain, _, _, runtime, _ := host.GetContexts()
+
+	builtinFunction, builtinArguments := arwen.TokenTransferCall(transfers, function, arguments)
+	if !host.isBuiltinFunctionName(builtinFunction) {
+		return arwen.ErrTokenTransferNotAvailable
+	}
+
+	sender := runtime.GetSCAddress()
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     sender,
+			Arguments:      builtinArguments,
+			CallValue:      big.NewInt(0),
+			CallType:       vmcommon.DirectCall,
+			GasPrice:       runtime.GetVMInput().GasPrice,
+			GasProvided:    gasLimit,
+			CurrentTxHash:  runtime.GetCurrentTxHash(),
+			OriginalTxHash: runtime.GetOriginalTxHash(),
+		},
+		RecipientAddr: destination,
+		Function:      builtinFunction,
+	}
+
+	if blockchain.GetShardOfAddress(sender) != blockchain.GetShardOfAddress(destination) {
+		host.traceAsyncCall(destination, arwen.CallData(builtinFunction, builtinArguments), arwen.AsyncBuiltinFunc)
+	}
+
+	_, _, err := host.ExecuteOnDestContext(input)
+	return err
+}
+
+// processReceivedTokenTransfer executes the token transfer built-in function
+// called directly on the VM, so that only the tokens actually transferred by
+// it are made available to the function which follows the transfer; the
+// returned input is nil if the transfer calls no function, in which case the
+// returned VMOutput is the one of the built-in function
+func (host *vmHost) processReceivedTokenTransfer(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *vmcommon.ContractCallInput, error) {
+	vmOutput, err := host.blockChainHook.ProcessBuiltInFunction(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	executionInput, err := host.initTokenTransferExecution(input, vmOutput.GasRemaining)
+	if err != nil || executionInput == nil {
+		return vmOutput, nil, err
+	}
+
+	host.Output().AddToActiveState(vmOutput)
+	return vmOutput, executionInput, nil
+}
+
+// initTokenTransferExecution prepares the runtime for the call which follows
+// a token transfer, making the transferred tokens available to the called
+// function; it returns nil if the transfer calls no function
+func (host *vmHost) initTokenTransferExecution(input *vmcommon.ContractCallInput, gasProvided uint64) (*vmcommon.ContractCallInput, error) {
+	transfers, function, arguments, err := arwen.ParseTokenTransferCall(input.Function, input.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	if len(function) == 0 {
+		return nil, nil
+	}
+	if host.isBuiltinFunctionName(function) {
+		return nil, arwen.ErrInvalidTokenTransfer
+	}
+
+	executionInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     input.CallerAddr,
+			Arguments:      arguments,
+			CallValue:      big.NewInt(0),
+			CallType:       input.CallType,
+			GasPrice:       input.GasPrice,
+			GasProvided:    gasProvided,
+			CurrentTxHash:  input.CurrentTxHash,
+			OriginalTxHash: input.OriginalTxHash,
+		},
+		RecipientAddr: input.RecipientAddr,
+		Function:      function,
+	}
+
+	runtime := host.Runtime()
+	runtime.InitStateFromContractCallInput(executionInput)
+	runtime.SetTokenTransfers(transfers)
+
+	return executionInput, nil
+}
@@ -0,0 +1,200 @@
+package host
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/kalyan3104/dme-vm-common"
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/kalyan3104/dme-vm-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const builtinFunctionGas = 100
+
+func tokenValue(value int64) []byte {
+	return arwen.PadBytesLeft(big.NewInt(value).Bytes(), arwen.BalanceLen)
+}
+
+// defaultTestArwenForTokenTransfers creates an Arwen vmHost in which both the
+// parent and the child SCs run the token-transfers contract, recording the
+// calls to the token transfer built-in functions
+func defaultTestArwenForTokenTransfers(t *testing.T) (*vmHost, *mock.BlockchainHookStub, *[]*vmcommon.ContractCallInput) {
+	code := GetTestSCCode("token-transfers", "../../")
+	host, stubBlockchainHook := DefaultTestArwenForTwoSCs(t, code, code, nil)
+	host.protocolBuiltinFunctions = vmcommon.FunctionNames{
+		arwen.TokenTransferFunctionName:      struct{}{},
+		arwen.MultiTokenTransferFunctionName: struct{}{},
+	}
+
+	builtinCalls := make([]*vmcommon.ContractCallInput, 0)
+	stubBlockchainHook.ProcessBuiltInFunctionCalled = func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		builtinCalls = append(builtinCalls, input)
+		return &vmcommon.VMOutput{
+			GasRemaining:   input.GasProvided - builtinFunctionGas,
+			OutputAccounts: make(map[string]*vmcommon.OutputAccount),
+		}, nil
+	}
+
+	return host, stubBlockchainHook, &builtinCalls
+}
+
+func runTokenTransfersFunction(t *testing.T, host *vmHost, function string, arguments ...[]byte) *vmcommon.VMOutput {
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 10000000
+	input.Function = function
+	input.Arguments = arguments
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	return vmOutput
+}
+
+func TestTokenTransferEI_TransferToken(t *testing.T) {
+	host, _, builtinCalls := defaultTestArwenForTokenTransfers(t)
+
+	vmOutput := runTokenTransfersFunction(t, host, "sendToken", childAddress, []byte("TOKEN-1"), tokenValue(42))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Len(t, vmOutput.ReturnData, 0)
+
+	require.Len(t, *builtinCalls, 1)
+	builtinCall := (*builtinCalls)[0]
+	require.Equal(t, arwen.TokenTransferFunctionName, builtinCall.Function)
+	require.Equal(t, [][]byte{[]byte("TOKEN-1"), {42}}, builtinCall.Arguments)
+	require.Equal(t, parentAddress, builtinCall.CallerAddr)
+	require.Equal(t, childAddress, builtinCall.RecipientAddr)
+	require.Equal(t, uint64(500000), builtinCall.GasProvided)
+}
+
+func TestTokenTransferEI_TransferTokenAndExecute(t *testing.T) {
+	host, _, builtinCalls := defaultTestArwenForTokenTransfers(t)
+
+	vmOutput := runTokenTransfersFunction(t, host, "sendToken", childAddress, []byte("TOKEN-1"), tokenValue(42), []byte("receiveTokens"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{1}, []byte("TOKEN-1"), tokenValue(42), {42}}, vmOutput.ReturnData)
+
+	require.Len(t, *builtinCalls, 1)
+	require.Equal(t, [][]byte{[]byte("TOKEN-1"), {42}, []byte("receiveTokens")}, (*builtinCalls)[0].Arguments)
+}
+
+func TestTokenTransferEI_MultiTransfer(t *testing.T) {
+	host, _, builtinCalls := defaultTestArwenForTokenTransfers(t)
+
+	vmOutput := runTokenTransfersFunction(t, host, "sendTokens",
+		childAddress, []byte("TOKEN-1"), tokenValue(1), []byte("TOKEN-2"), tokenValue(2), []byte("receiveTokens"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{
+		{2},
+		[]byte("TOKEN-1"), tokenValue(1), {1},
+		[]byte("TOKEN-2"), tokenValue(2), {2},
+	}, vmOutput.ReturnData)
+
+	require.Len(t, *builtinCalls, 1)
+	builtinCall := (*builtinCalls)[0]
+	require.Equal(t, arwen.MultiTokenTransferFunctionName, builtinCall.Function)
+	require.Equal(t, [][]byte{{2}, []byte("TOKEN-1"), {1}, []byte("TOKEN-2"), {2}, []byte("receiveTokens")}, builtinCall.Arguments)
+}
+
+func TestTokenTransferEI_CrossShard(t *testing.T) {
+	host, stubBlockchainHook, builtinCalls := defaultTestArwenForTokenTransfers(t)
+	stubBlockchainHook.GetShardOfAddressCalled = func(address []byte) uint32 {
+		if bytes.Equal(address, otherShardAddress) {
+			return 1
+		}
+		return 0
+	}
+
+	// The built-in function sends the transfer to the destination shard.
+	stubBlockchainHook.ProcessBuiltInFunctionCalled = func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		*builtinCalls = append(*builtinCalls, input)
+		gasRemaining := input.GasProvided - builtinFunctionGas
+		return &vmcommon.VMOutput{
+			OutputAccounts: map[string]*vmcommon.OutputAccount{
+				string(input.RecipientAddr): {
+					Address:  input.RecipientAddr,
+					Data:     arwen.CallData(input.Function, input.Arguments),
+					GasLimit: gasRemaining,
+				},
+			},
+		}, nil
+	}
+
+	vmOutput := runTokenTransfersFunction(t, host, "sendToken", otherShardAddress, []byte("TOKEN-1"), tokenValue(42), []byte("receiveTokens"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Len(t, vmOutput.ReturnData, 0)
+
+	require.Len(t, *builtinCalls, 1)
+	require.Equal(t, otherShardAddress, (*builtinCalls)[0].RecipientAddr)
+
+	// The transfer is sent to the destination shard only once.
+	destinationAccount := vmOutput.OutputAccounts[string(otherShardAddress)]
+	require.NotNil(t, destinationAccount)
+	require.Equal(t, arwen.CallData(arwen.TokenTransferFunctionName, (*builtinCalls)[0].Arguments), destinationAccount.Data)
+	require.Equal(t, uint64(500000-builtinFunctionGas), destinationAccount.GasLimit)
+}
+
+func TestTokenTransferEI_NotAvailable(t *testing.T) {
+	host, _, builtinCalls := defaultTestArwenForTokenTransfers(t)
+	host.protocolBuiltinFunctions = make(vmcommon.FunctionNames)
+
+	vmOutput := runTokenTransfersFunction(t, host, "sendToken", childAddress, []byte("TOKEN-1"), tokenValue(42))
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrTokenTransferNotAvailable.Error(), vmOutput.ReturnMessage)
+	require.Len(t, *builtinCalls, 0)
+}
+
+func TestTokenTransferEI_ReceivedTokens(t *testing.T) {
+	host, _, builtinCalls := defaultTestArwenForTokenTransfers(t)
+
+	// A direct call receives no tokens.
+	vmOutput := runTokenTransfersFunction(t, host, "receiveTokens")
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{}}, vmOutput.ReturnData)
+
+	vmOutput = runTokenTransfersFunction(t, host, "tokenIdentifierOutOfRange")
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrArgOutOfRange.Error(), vmOutput.ReturnMessage)
+
+	// A token transfer reaching the VM is processed by its built-in function
+	// before the function following it is executed.
+	vmOutput = runTokenTransfersFunction(t, host, arwen.TokenTransferFunctionName, []byte("TOKEN-1"), []byte{42}, []byte("receiveTokens"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{1}, []byte("TOKEN-1"), tokenValue(42), {42}}, vmOutput.ReturnData)
+	require.Len(t, *builtinCalls, 1)
+	require.Equal(t, arwen.TokenTransferFunctionName, (*builtinCalls)[0].Function)
+	require.Equal(t, [][]byte{[]byte("TOKEN-1"), {42}, []byte("receiveTokens")}, (*builtinCalls)[0].Arguments)
+
+	vmOutput = runTokenTransfersFunction(t, host, arwen.TokenTransferFunctionName, []byte("TOKEN-1"), []byte{42})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Len(t, vmOutput.ReturnData, 0)
+	require.Len(t, *builtinCalls, 2)
+
+	vmOutput = runTokenTransfersFunction(t, host, arwen.TokenTransferFunctionName, []byte("TOKEN-1"))
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrInvalidTokenTransfer.Error(), vmOutput.ReturnMessage)
+}
+
+func TestTokenTransferEI_ReceivedTokens_BuiltinFunctionFailed(t *testing.T) {
+	host, stubBlockchainHook, _ := defaultTestArwenForTokenTransfers(t)
+	stubBlockchainHook.ProcessBuiltInFunctionCalled = func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		return nil, arwen.ErrInvalidTokenTransfer
+	}
+
+	vmOutput := runTokenTransfersFunction(t, host, arwen.TokenTransferFunctionName, []byte("TOKEN-1"), []byte{42}, []byte("receiveTokens"))
+	require.NotEqual(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Len(t, vmOutput.ReturnData, 0)
+}
+
+func TestTokenTransferEI_ReceivedTokens_NotBuiltinFunction(t *testing.T) {
+	host, _, builtinCalls := defaultTestArwenForTokenTransfers(t)
+	host.protocolBuiltinFunctions = make(vmcommon.FunctionNames)
+
+	// Without its built-in function, a token transfer is an ordinary call,
+	// which cannot make any tokens available to the called SC.
+	vmOutput := runTokenTransfersFunction(t, host, arwen.TokenTransferFunctionName, []byte("TOKEN-1"), []byte{42}, []byte("receiveTokens"))
+	require.Equal(t, vmcommon.FunctionNotFound, vmOutput.ReturnCode)
+	require.Len(t, vmOutput.ReturnData, 0)
+	require.Len(t, *builtinCalls, 0)
+}
@@ -0,0 +1,134 @@
+package host
+
+import (
+	vmcommon "github.com/kalyan3104/dme-vm-common"
+	"github.com/kalyan3104/dme-vm-go/arwen"
+)
+
+func (host *vmHost) isTracingOrProfiling() bool {
+	return host.tracer != nil || host.meteringContext.IsGasProfiling()
+}
+
+// enterTransaction starts tracing the transaction and profiling the gas it uses
+func (host *vmHost) enterTransaction(input *vmcommon.VMInput, address []byte, function string) {
+	host.traceExecutionEnter(arwen.TraceKindTransaction, input, address, function)
+	host.meteringContext.StartGasProfile()
+}
+
+// exitTransaction ends the tracing and the gas profile of a transaction,
+// from its VMOutput, which is nil if the execution panicked
+func (host *vmHost) exitTransaction(address []byte, gasProvided uint64, vmOutput *vmcommon.VMOutput, err error) {
+	if !host.isTracingOrProfiling() {
+		return
+	}
+
+	gasUsed := gasProvided
+	returnCode := vmcommon.ExecutionFailed
+	if vmOutput != nil {
+		returnCode = vmOutput.ReturnCode
+		if vmOutput.GasRemaining < gasProvided {
+			gasUsed = gasProvided - vmOutput.GasRemaining
+		} else {
+			gasUsed = 0
+		}
+	}
+
+	host.meteringContext.EndGasProfileFrame(address, gasUsed)
+	host.traceExecutionExit(arwen.TraceKindTransaction, address, gasUsed, returnCode, err)
+}
+
+// enterIndirectExecution starts tracing an execution started by a contract
+// and profiling the gas it uses, returning the gas left to the caller, from
+// which the gas used by the execution is computed on exit
+func (host *vmHost) enterIndirectExecution(kind string, input *vmcommon.VMInput, address []byte, function string) uint64 {
+	if !host.isTracingOrProfiling() {
+		return 0
+	}
+
+	host.traceExecutionEnter(kind, input, address, function)
+	host.meteringContext.StartGasProfileFrame()
+	return host.meteringContext.GasLeft()
+}
+
+func (host *vmHost) exitIndirectExecution(kind string, address []byte, gasLeftBefore uint64, returnCode vmcommon.ReturnCode, err error) {
+	if !host.isTracingOrProfiling() {
+		return
+	}
+
+	gasUsed := uint64(0)
+	gasLeftAfter := host.meteringContext.GasLeft()
+	if gasLeftAfter < gasLeftBefore {
+		gasUsed = gasLeftBefore - gasLeftAfter
+	}
+
+	host.meteringContext.EndGasProfileFrame(address, gasUsed)
+	host.traceExecutionExit(kind, address, gasUsed, returnCode, err)
+}
+
+func (host *vmHost) traceExecutionEnter(kind string, input *vmcommon.VMInput, address []byte, function string) {
+	if host.tracer == nil {
+		return
+	}
+
+	host.tracer.Trace(&arwen.TraceEvent{
+		Type:        arwen.TraceEventExecutionEnter,
+		Kind:        kind,
+		Address:     address,
+		Caller:      input.CallerAddr,
+		Function:    function,
+		CallValue:   input.CallValue,
+		GasProvided: input.GasProvided,
+	})
+}
+
+func (host *vmHost) traceExecutionExit(kind string, address []byte, gasUsed uint64, returnCode vmcommon.ReturnCode, err error) {
+	if host.tracer == nil {
+		return
+	}
+
+	event := &arwen.TraceEvent{
+		Type:       arwen.TraceEventExecutionExit,
+		Kind:       kind,
+		Address:    address,
+		GasUsed:    gasUsed,
+		ReturnCode: returnCode.String(),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	host.tracer.Trace(event)
+}
+
+func (host *vmHost) traceAsyncCall(destination []byte, data []byte, mode arwen.AsyncCallExecutionMode) {
+	if host.tracer == nil {
+		return
+	}
+
+	host.tracer.Trace(&arwen.TraceEvent{
+		Type:        arwen.TraceEventAsyncCall,
+		Address:     host.Runtime().GetSCAddress(),
+		Destination: destination,
+		Data:        data,
+		Mode:        mode.String(),
+	})
+}
+
+func (host *vmHost) traceMemory(address []byte, memoryPages uint32) {
+	if host.tracer == nil {
+		return
+	}
+
+	host.tracer.Trace(&arwen.TraceEvent{
+		Type:        arwen.TraceEventMemory,
+		Address:     address,
+		MemoryPages: memoryPages,
+	})
+}
+
+func returnCodeFromError(err error) vmcommon.ReturnCode {
+	if err != nil {
+		return vmcommon.ExecutionFailed
+	}
+	return vmcommon.Ok
+}
@@ -0,0 +1,75 @@
+package arwen
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type namedHost struct {
+	VMHost
+	name string
+}
+
+func TestHostContext_AddRemove(t *testing.T) {
+	first := &namedHost{name: "first"}
+	second := &namedHost{name: "second"}
+
+	firstID := AddHostContext(first)
+	secondID := AddHostContext(second)
+	require.NotEqual(t, firstID, secondID)
+
+	require.True(t, first == getHostContext(firstID))
+	require.True(t, second == getHostContext(secondID))
+
+	RemoveHostContext(firstID)
+	require.Panics(t, func() { getHostContext(firstID) })
+	require.True(t, second == getHostContext(secondID))
+
+	RemoveHostContext(secondID)
+}
+
+func TestHostContext_ManyHostsNoCollisions(t *testing.T) {
+	numHosts := 1000
+	ids := make(map[int]bool)
+	for i := 0; i < numHosts; i++ {
+		id := AddHostContext(&namedHost{name: fmt.Sprint(i)})
+		require.False(t, ids[id])
+		ids[id] = true
+	}
+
+	for id := range ids {
+		RemoveHostContext(id)
+	}
+}
+
+func TestHostContext_Concurrent(t *testing.T) {
+	numGoroutines := 16
+	numIterations := 1000
+
+	var wg sync.WaitGroup
+	errors := make(chan error, numGoroutines)
+	for g := 0; g < numGoroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			host := &namedHost{name: fmt.Sprint(g)}
+			for i := 0; i < numIterations; i++ {
+				id := AddHostContext(host)
+				if getHostContext(id) != VMHost(host) {
+					errors <- fmt.Errorf("goroutine %d resolved a foreign host", g)
+					return
+				}
+				RemoveHostContext(id)
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	close(errors)
+	for err := range errors {
+		require.Nil(t, err)
+	}
+}
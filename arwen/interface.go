@@ -22,21 +22,36 @@ type CallArgsParser interface {
 	IsInterfaceNil() bool
 }
 
+// SignatureVerifier verifies signatures of messages, complementing the
+// hashing functions of vmcommon.CryptoHook
+type SignatureVerifier interface {
+	VerifyEd25519(key []byte, message []byte, signature []byte) error
+	VerifyBLS(key []byte, message []byte, signature []byte) error
+}
+
 type VMHost interface {
 	Crypto() vmcommon.CryptoHook
+	SignatureVerifier() SignatureVerifier
 	Blockchain() BlockchainContext
 	Runtime() RuntimeContext
 	BigInt() BigIntContext
+	BigFloat() BigFloatContext
+	ManagedBuffer() ManagedBufferContext
 	Output() OutputContext
 	Metering() MeteringContext
 	Storage() StorageContext
 
 	CreateNewContract(input *vmcommon.ContractCreateInput) ([]byte, error)
+	UpgradeContract(input *vmcommon.ContractCallInput) error
 	ExecuteOnSameContext(input *vmcommon.ContractCallInput) (*AsyncContextInfo, error)
 	ExecuteOnDestContext(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *AsyncContextInfo, error)
+	TransferTokens(destination []byte, gasLimit uint64, transfers []*TokenTransfer, function string, arguments [][]byte) error
 	EthereumCallData() []byte
 	GetAPIMethods() *wasmer.Imports
 	GetProtocolBuiltinFunctions() vmcommon.FunctionNames
+	VerifyContractCode(code []byte, endpoints []string) error
+	Tracer() ExecutionTracer
+	SetTracer(tracer ExecutionTracer)
 }
 
 type BlockchainContext interface {
@@ -60,6 +75,7 @@ type BlockchainContext interface {
 	GetCodeHash(addr []byte) ([]byte, error)
 	GetCode(addr []byte) ([]byte, error)
 	GetCodeSize(addr []byte) (int32, error)
+	GetCodeMetadata(addr []byte) (*CodeMetadata, error)
 	BlockHash(number int64) []byte
 	GetOwnerAddress() ([]byte, error)
 	GetShardOfAddress(addr []byte) uint32
@@ -80,6 +96,8 @@ type RuntimeContext interface {
 	Arguments() [][]byte
 	GetCurrentTxHash() []byte
 	GetOriginalTxHash() []byte
+	TokenTransfers() []*TokenTransfer
+	SetTokenTransfers(transfers []*TokenTransfer)
 	ExtractCodeUpgradeFromArgs() ([]byte, []byte, error)
 	SignalUserError(message string)
 	FailExecution(err error)
@@ -93,12 +111,21 @@ type RuntimeContext interface {
 	PushInstance()
 	PopInstance()
 	RunningInstancesCount() uint64
+	CallDepth() uint64
+	IsContractOnTheStack(address []byte) bool
 	ClearInstanceStack()
 	ReadOnly() bool
 	SetReadOnly(readOnly bool)
 	StartWasmerInstance(contract []byte, gasLimit uint64) error
+	StartCachedWasmerInstance(contract []byte, codeHash []byte, gasLimit uint64) error
 	SetMaxInstanceCount(uint64)
-	VerifyContractCode() error
+	SetModuleCacheSize(size int)
+	SetMaxMemoryPages(maxPages uint32)
+	GetMaxMemoryPages() uint32
+	GetMemoryPages() uint32
+	VerifyContractCode(code []byte) error
+	VerifyContractEndpoints(code []byte, endpoints []string) error
+	SetValidationPolicy(policy WASMValidationPolicy)
 	SetInstanceContext(instCtx *wasmer.InstanceContext)
 	GetInstanceContext() *wasmer.InstanceContext
 	GetInstanceExports() wasmer.ExportsMap
@@ -113,6 +140,8 @@ type RuntimeContext interface {
 	Kalyan3104APIErrorShouldFailExecution() bool
 	CryptoAPIErrorShouldFailExecution() bool
 	BigIntAPIErrorShouldFailExecution() bool
+	BigFloatAPIErrorShouldFailExecution() bool
+	ManagedBufferAPIErrorShouldFailExecution() bool
 }
 
 type BigIntContext interface {
@@ -124,6 +153,26 @@ type BigIntContext interface {
 	GetThree(id1, id2, id3 int32) (*big.Int, *big.Int, *big.Int)
 }
 
+type BigFloatContext interface {
+	StateStack
+
+	Put(value *BigFloat) int32
+	GetOne(id int32) *BigFloat
+	GetTwo(id1, id2 int32) (*BigFloat, *BigFloat)
+	GetThree(id1, id2, id3 int32) (*BigFloat, *BigFloat, *BigFloat)
+}
+
+type ManagedBufferContext interface {
+	StateStack
+
+	NewBuffer() int32
+	NewBufferFromBytes(bytes []byte) int32
+	SetBytes(handle int32, bytes []byte)
+	GetBytes(handle int32) ([]byte, error)
+	AppendBytes(handle int32, bytes []byte) error
+	GetSlice(handle int32, startPosition int32, sliceLength int32) ([]byte, error)
+}
+
 type OutputContext interface {
 	StateStack
 	PopMergeActiveState()
@@ -131,7 +180,9 @@ type OutputContext interface {
 	AddToActiveState(rightOutput *vmcommon.VMOutput)
 
 	GetOutputAccount(address []byte) (*vmcommon.OutputAccount, bool)
-	WriteLog(address []byte, topics [][]byte, data []byte)
+	SetLogLimits(limits LogLimits)
+	LogLimits() LogLimits
+	WriteLog(address []byte, topics [][]byte, data []byte) error
 	Transfer(destination []byte, sender []byte, gasLimit uint64, value *big.Int, input []byte) error
 	SelfDestruct(address []byte, beneficiary []byte)
 	GetRefund() uint64
@@ -152,16 +203,25 @@ type OutputContext interface {
 type MeteringContext interface {
 	GasSchedule() *config.GasCost
 	UseGas(gas uint64)
+	ForwardGas(gas uint64)
 	FreeGas(gas uint64)
 	RestoreGas(gas uint64)
 	GasLeft() uint64
 	BoundGasLimit(value int64) uint64
 	BlockGasLimit() uint64
+	UseGasForMemoryGrowth(pages uint32) error
 	DeductInitialGasForExecution(contract []byte) error
 	DeductInitialGasForDirectDeployment(input CodeDeployInput) error
 	DeductInitialGasForIndirectDeployment(input CodeDeployInput) error
 	UnlockGasIfAsyncStep()
 	GetGasLockedForAsyncStep() uint64
+	SetGasProfiling(enabled bool)
+	IsGasProfiling() bool
+	GasProfile() *GasProfile
+	SetGasCategory(category string) string
+	StartGasProfile()
+	StartGasProfileFrame()
+	EndGasProfileFrame(address []byte, gasUsed uint64)
 }
 
 type StorageStatus int
@@ -179,6 +239,9 @@ type StorageContext interface {
 	SetAddress(address []byte)
 	GetStorageUpdates(address []byte) map[string]*vmcommon.StorageUpdate
 	GetStorage(key []byte) []byte
+	GetStorageFromAddress(address []byte, key []byte) ([]byte, error)
+	CountStorageKeysWithPrefix(prefix []byte) (int, error)
+	GetStorageKeysWithPrefix(prefix []byte, startIndex int, maxKeys int) ([][]byte, error)
 	SetStorage(key []byte, value []byte) (StorageStatus, error)
 }
 
@@ -0,0 +1,60 @@
+package arwen
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+var _ ExecutionTracer = (*JSONLinesTracer)(nil)
+
+// JSONLinesTracer writes each traced event as a line of JSON, annotated with
+// the depth of the execution it happened in, so that the lines form the call
+// tree of the traced executions
+type JSONLinesTracer struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	depth   int
+	err     error
+}
+
+type jsonLinesTraceEvent struct {
+	Depth int
+	*TraceEvent
+}
+
+// NewJSONLinesTracer creates a new JSONLinesTracer, writing to the given writer
+func NewJSONLinesTracer(writer io.Writer) *JSONLinesTracer {
+	return &JSONLinesTracer{
+		encoder: json.NewEncoder(writer),
+	}
+}
+
+// Trace writes the event, unless a previous write has failed
+func (tracer *JSONLinesTracer) Trace(event *TraceEvent) {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+
+	if event.Type == TraceEventExecutionExit && tracer.depth > 0 {
+		tracer.depth--
+	}
+
+	if tracer.err == nil {
+		tracer.err = tracer.encoder.Encode(&jsonLinesTraceEvent{
+			Depth:      tracer.depth,
+			TraceEvent: event,
+		})
+	}
+
+	if event.Type == TraceEventExecutionEnter {
+		tracer.depth++
+	}
+}
+
+// Err returns the error of the first failed write, if any
+func (tracer *JSONLinesTracer) Err() error {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+
+	return tracer.err
+}
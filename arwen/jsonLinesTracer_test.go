@@ -0,0 +1,68 @@
+package arwen
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct {
+	writes int
+}
+
+func (writer *failingWriter) Write(_ []byte) (int, error) {
+	writer.writes++
+	return 0, errors.New("write failed")
+}
+
+func TestJSONLinesTracer_Depth(t *testing.T) {
+	t.Parallel()
+
+	buffer := &bytes.Buffer{}
+	tracer := NewJSONLinesTracer(buffer)
+
+	tracer.Trace(&TraceEvent{Type: TraceEventExecutionEnter, Kind: TraceKindTransaction, Function: "main", CallValue: big.NewInt(10)})
+	tracer.Trace(&TraceEvent{Type: TraceEventAPICall, Function: "getGasLeft", GasUsed: 2})
+	tracer.Trace(&TraceEvent{Type: TraceEventExecutionEnter, Kind: TraceKindDestContext, Function: "child"})
+	tracer.Trace(&TraceEvent{Type: TraceEventStorageStore, Key: []byte("k"), Value: []byte("v")})
+	tracer.Trace(&TraceEvent{Type: TraceEventExecutionExit, Kind: TraceKindDestContext, ReturnCode: "ok"})
+	tracer.Trace(&TraceEvent{Type: TraceEventExecutionExit, Kind: TraceKindTransaction, ReturnCode: "ok", GasUsed: 100})
+	require.Nil(t, tracer.Err())
+
+	expected := []string{
+		`{"Depth":0,"Type":"enter","Kind":"transaction","Function":"main","CallValue":10}`,
+		`{"Depth":1,"Type":"apiCall","Function":"getGasLeft","GasUsed":2}`,
+		`{"Depth":1,"Type":"enter","Kind":"destContext","Function":"child"}`,
+		`{"Depth":2,"Type":"storageStore","Key":"aw==","Value":"dg=="}`,
+		`{"Depth":1,"Type":"exit","Kind":"destContext","ReturnCode":"ok"}`,
+		`{"Depth":0,"Type":"exit","Kind":"transaction","GasUsed":100,"ReturnCode":"ok"}`,
+	}
+	require.Equal(t, strings.Join(expected, "\n")+"\n", buffer.String())
+}
+
+func TestJSONLinesTracer_UnbalancedExit(t *testing.T) {
+	t.Parallel()
+
+	buffer := &bytes.Buffer{}
+	tracer := NewJSONLinesTracer(buffer)
+
+	tracer.Trace(&TraceEvent{Type: TraceEventExecutionExit})
+	tracer.Trace(&TraceEvent{Type: TraceEventTransfer})
+	require.Equal(t, "{\"Depth\":0,\"Type\":\"exit\"}\n{\"Depth\":0,\"Type\":\"transfer\"}\n", buffer.String())
+}
+
+func TestJSONLinesTracer_WriteError(t *testing.T) {
+	t.Parallel()
+
+	writer := &failingWriter{}
+	tracer := NewJSONLinesTracer(writer)
+
+	tracer.Trace(&TraceEvent{Type: TraceEventExecutionEnter})
+	tracer.Trace(&TraceEvent{Type: TraceEventExecutionExit})
+	require.NotNil(t, tracer.Err())
+	require.Equal(t, 1, writer.writes)
+}
@@ -0,0 +1,228 @@
+package kalyan3104api
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef unsigned char uint8_t;
+// typedef int int32_t;
+//
+// extern int32_t bigFloatNew(void* context, long long mantissa, int32_t decimals);
+//
+// extern void bigFloatAdd(void* context, int32_t destination, int32_t op1, int32_t op2);
+// extern void bigFloatSub(void* context, int32_t destination, int32_t op1, int32_t op2);
+// extern void bigFloatMul(void* context, int32_t destination, int32_t op1, int32_t op2);
+// extern void bigFloatDiv(void* context, int32_t destination, int32_t op1, int32_t op2, int32_t decimals);
+//
+// extern void bigFloatRound(void* context, int32_t destination, int32_t op, int32_t decimals);
+// extern void bigFloatTruncate(void* context, int32_t destination, int32_t op, int32_t decimals);
+//
+// extern void bigFloatFromBigInt(void* context, int32_t destination, int32_t bigIntOp, int32_t decimals);
+// extern void bigFloatToBigInt(void* context, int32_t bigIntDestination, int32_t op, int32_t decimals);
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/kalyan3104/dme-vm-go/wasmer"
+)
+
+// BigFloatImports creates a new wasmer.Imports populated with the BigFloat API methods
+func BigFloatImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace("env")
+
+	imports, err := imports.Append("bigFloatNew", bigFloatNew, C.bigFloatNew)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatAdd", bigFloatAdd, C.bigFloatAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatSub", bigFloatSub, C.bigFloatSub)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatMul", bigFloatMul, C.bigFloatMul)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatDiv", bigFloatDiv, C.bigFloatDiv)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatRound", bigFloatRound, C.bigFloatRound)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatTruncate", bigFloatTruncate, C.bigFloatTruncate)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatFromBigInt", bigFloatFromBigInt, C.bigFloatFromBigInt)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatToBigInt", bigFloatToBigInt, C.bigFloatToBigInt)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+//export bigFloatNew
+func bigFloatNew(context unsafe.Pointer, mantissa int64, decimals int32) int32 {
+	defer arwen.TraceAPICall(context, "bigFloatNew", mantissa, int64(decimals))()
+
+	bigFloat := arwen.GetBigFloatContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatNew
+	metering.UseGas(gasToUse)
+
+	value, err := arwen.NewBigFloat(mantissa, decimals)
+	if arwen.WithFault(err, context, runtime.BigFloatAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return bigFloat.Put(value)
+}
+
+//export bigFloatAdd
+func bigFloatAdd(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigFloatAdd", int64(destination), int64(op1), int64(op2))()
+
+	bigFloat := arwen.GetBigFloatContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatAdd
+	metering.UseGas(gasToUse)
+
+	dest, a, b := bigFloat.GetThree(destination, op1, op2)
+	dest.Add(a, b)
+}
+
+//export bigFloatSub
+func bigFloatSub(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigFloatSub", int64(destination), int64(op1), int64(op2))()
+
+	bigFloat := arwen.GetBigFloatContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatSub
+	metering.UseGas(gasToUse)
+
+	dest, a, b := bigFloat.GetThree(destination, op1, op2)
+	dest.Sub(a, b)
+}
+
+//export bigFloatMul
+func bigFloatMul(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigFloatMul", int64(destination), int64(op1), int64(op2))()
+
+	bigFloat := arwen.GetBigFloatContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatMul
+	metering.UseGas(gasToUse)
+
+	dest, a, b := bigFloat.GetThree(destination, op1, op2)
+	_, err := dest.Mul(a, b)
+	arwen.WithFault(err, context, runtime.BigFloatAPIErrorShouldFailExecution())
+}
+
+//export bigFloatDiv
+func bigFloatDiv(context unsafe.Pointer, destination, op1, op2, decimals int32) {
+	defer arwen.TraceAPICall(context, "bigFloatDiv", int64(destination), int64(op1), int64(op2), int64(decimals))()
+
+	bigFloat := arwen.GetBigFloatContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatDiv
+	metering.UseGas(gasToUse)
+
+	dest, a, b := bigFloat.GetThree(destination, op1, op2)
+	_, err := dest.Quo(a, b, decimals)
+	arwen.WithFault(err, context, runtime.BigFloatAPIErrorShouldFailExecution())
+}
+
+//export bigFloatRound
+func bigFloatRound(context unsafe.Pointer, destination, op, decimals int32) {
+	defer arwen.TraceAPICall(context, "bigFloatRound", int64(destination), int64(op), int64(decimals))()
+
+	bigFloat := arwen.GetBigFloatContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatRound
+	metering.UseGas(gasToUse)
+
+	dest, a := bigFloat.GetTwo(destination, op)
+	_, err := dest.Round(a, decimals)
+	arwen.WithFault(err, context, runtime.BigFloatAPIErrorShouldFailExecution())
+}
+
+//export bigFloatTruncate
+func bigFloatTruncate(context unsafe.Pointer, destination, op, decimals int32) {
+	defer arwen.TraceAPICall(context, "bigFloatTruncate", int64(destination), int64(op), int64(decimals))()
+
+	bigFloat := arwen.GetBigFloatContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatTruncate
+	metering.UseGas(gasToUse)
+
+	dest, a := bigFloat.GetTwo(destination, op)
+	_, err := dest.Truncate(a, decimals)
+	arwen.WithFault(err, context, runtime.BigFloatAPIErrorShouldFailExecution())
+}
+
+//export bigFloatFromBigInt
+func bigFloatFromBigInt(context unsafe.Pointer, destination, bigIntOp, decimals int32) {
+	defer arwen.TraceAPICall(context, "bigFloatFromBigInt", int64(destination), int64(bigIntOp), int64(decimals))()
+
+	bigFloat := arwen.GetBigFloatContext(context)
+	bigInt := arwen.GetBigIntContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatFromBigInt
+	metering.UseGas(gasToUse)
+
+	dest := bigFloat.GetOne(destination)
+	_, err := dest.SetBigInt(bigInt.GetOne(bigIntOp), decimals)
+	arwen.WithFault(err, context, runtime.BigFloatAPIErrorShouldFailExecution())
+}
+
+//export bigFloatToBigInt
+func bigFloatToBigInt(context unsafe.Pointer, bigIntDestination, op, decimals int32) {
+	defer arwen.TraceAPICall(context, "bigFloatToBigInt", int64(bigIntDestination), int64(op), int64(decimals))()
+
+	bigFloat := arwen.GetBigFloatContext(context)
+	bigInt := arwen.GetBigIntContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatToBigInt
+	metering.UseGas(gasToUse)
+
+	value, err := bigFloat.GetOne(op).BigInt(decimals)
+	if arwen.WithFault(err, context, runtime.BigFloatAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	bigInt.GetOne(bigIntDestination).Set(value)
+}
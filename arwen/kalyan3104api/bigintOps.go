@@ -39,17 +39,26 @@ package kalyan3104api
 // extern void bigIntShr(void* context, int32_t destination, int32_t op, int32_t bits);
 // extern void bigIntShl(void* context, int32_t destination, int32_t op, int32_t bits);
 //
+// extern void bigIntPow(void* context, int32_t destination, int32_t op1, int32_t op2);
+// extern void bigIntSqrt(void* context, int32_t destination, int32_t op);
+// extern int32_t bigIntLog2(void* context, int32_t op);
+// extern void bigIntModPow(void* context, int32_t destination, int32_t base, int32_t exponent, int32_t modulus);
+// extern void bigIntModInverse(void* context, int32_t destination, int32_t op, int32_t modulus);
+//
 // extern void bigIntFinishUnsigned(void* context, int32_t reference);
 // extern void bigIntFinishSigned(void* context, int32_t reference);
 // extern int32_t bigIntStorageStoreUnsigned(void *context, int32_t keyOffset, int32_t keyLength, int32_t source);
 // extern int32_t bigIntStorageLoadUnsigned(void *context, int32_t keyOffset, int32_t keyLength, int32_t destination);
+// extern int32_t bigIntStorageLoadUnsignedFromAddress(void *context, int32_t addressOffset, int32_t keyOffset, int32_t keyLength, int32_t destination);
 // extern void bigIntGetUnsignedArgument(void *context, int32_t id, int32_t destination);
 // extern void bigIntGetSignedArgument(void *context, int32_t id, int32_t destination);
 // extern void bigIntGetCallValue(void *context, int32_t destination);
+// extern void bigIntGetTokenCallValue(void *context, int32_t index, int32_t destination);
 // extern void bigIntGetExternalBalance(void *context, int32_t addressOffset, int32_t result);
 import "C"
 
 import (
+	"math/big"
 	"unsafe"
 
 	twos "github.com/kalyan3104/dme-components-big-int/twos-complement"
@@ -196,6 +205,31 @@ func BigIntImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("bigIntPow", bigIntPow, C.bigIntPow)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntSqrt", bigIntSqrt, C.bigIntSqrt)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntLog2", bigIntLog2, C.bigIntLog2)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntModPow", bigIntModPow, C.bigIntModPow)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntModInverse", bigIntModInverse, C.bigIntModInverse)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("bigIntFinishUnsigned", bigIntFinishUnsigned, C.bigIntFinishUnsigned)
 	if err != nil {
 		return nil, err
@@ -216,6 +250,11 @@ func BigIntImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("bigIntStorageLoadUnsignedFromAddress", bigIntStorageLoadUnsignedFromAddress, C.bigIntStorageLoadUnsignedFromAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("bigIntGetUnsignedArgument", bigIntGetUnsignedArgument, C.bigIntGetUnsignedArgument)
 	if err != nil {
 		return nil, err
@@ -231,6 +270,11 @@ func BigIntImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("bigIntGetTokenCallValue", bigIntGetTokenCallValue, C.bigIntGetTokenCallValue)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("bigIntGetExternalBalance", bigIntGetExternalBalance, C.bigIntGetExternalBalance)
 	if err != nil {
 		return nil, err
@@ -241,6 +285,8 @@ func BigIntImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 
 //export bigIntGetUnsignedArgument
 func bigIntGetUnsignedArgument(context unsafe.Pointer, id int32, destination int32) {
+	defer arwen.TraceAPICall(context, "bigIntGetUnsignedArgument", int64(id), int64(destination))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -260,6 +306,8 @@ func bigIntGetUnsignedArgument(context unsafe.Pointer, id int32, destination int
 
 //export bigIntGetSignedArgument
 func bigIntGetSignedArgument(context unsafe.Pointer, id int32, destination int32) {
+	defer arwen.TraceAPICall(context, "bigIntGetSignedArgument", int64(id), int64(destination))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -279,6 +327,8 @@ func bigIntGetSignedArgument(context unsafe.Pointer, id int32, destination int32
 
 //export bigIntStorageStoreUnsigned
 func bigIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32, source int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntStorageStoreUnsigned", int64(keyOffset), int64(keyLength), int64(source))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
@@ -305,6 +355,8 @@ func bigIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32, keyLeng
 
 //export bigIntStorageLoadUnsigned
 func bigIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32, destination int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntStorageLoadUnsigned", int64(keyOffset), int64(keyLength), int64(destination))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
@@ -327,8 +379,46 @@ func bigIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, keyLengt
 	return int32(len(bytes))
 }
 
+//export bigIntStorageLoadUnsignedFromAddress
+func bigIntStorageLoadUnsignedFromAddress(context unsafe.Pointer, addressOffset int32, keyOffset int32, keyLength int32, destination int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntStorageLoadUnsignedFromAddress", int64(addressOffset), int64(keyOffset), int64(keyLength), int64(destination))()
+
+	bigInt := arwen.GetBigIntContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntStorageLoadUnsignedFromAddress
+	metering.UseGas(gasToUse)
+
+	bytes, err := storage.GetStorageFromAddress(address, key)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	value := bigInt.GetOne(destination)
+	value.SetBytes(bytes)
+
+	gasToUse = metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(len(bytes))
+	metering.UseGas(gasToUse)
+
+	return int32(len(bytes))
+}
+
 //export bigIntGetCallValue
 func bigIntGetCallValue(context unsafe.Pointer, destination int32) {
+	defer arwen.TraceAPICall(context, "bigIntGetCallValue", int64(destination))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -340,8 +430,31 @@ func bigIntGetCallValue(context unsafe.Pointer, destination int32) {
 	metering.UseGas(gasToUse)
 }
 
+//export bigIntGetTokenCallValue
+func bigIntGetTokenCallValue(context unsafe.Pointer, index int32, destination int32) {
+	defer arwen.TraceAPICall(context, "bigIntGetTokenCallValue", int64(index), int64(destination))()
+
+	bigInt := arwen.GetBigIntContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntGetTokenCallValue
+	metering.UseGas(gasToUse)
+
+	transfers := runtime.TokenTransfers()
+	if index < 0 || int(index) >= len(transfers) {
+		arwen.WithFault(arwen.ErrArgOutOfRange, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+
+	value := bigInt.GetOne(destination)
+	value.Set(transfers[index].Value)
+}
+
 //export bigIntGetExternalBalance
 func bigIntGetExternalBalance(context unsafe.Pointer, addressOffset int32, result int32) {
+	defer arwen.TraceAPICall(context, "bigIntGetExternalBalance", int64(addressOffset), int64(result))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
@@ -363,6 +476,8 @@ func bigIntGetExternalBalance(context unsafe.Pointer, addressOffset int32, resul
 
 //export bigIntNew
 func bigIntNew(context unsafe.Pointer, smallValue int64) int32 {
+	defer arwen.TraceAPICall(context, "bigIntNew", smallValue)()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -374,6 +489,8 @@ func bigIntNew(context unsafe.Pointer, smallValue int64) int32 {
 
 //export bigIntUnsignedByteLength
 func bigIntUnsignedByteLength(context unsafe.Pointer, reference int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntUnsignedByteLength", int64(reference))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -388,6 +505,8 @@ func bigIntUnsignedByteLength(context unsafe.Pointer, reference int32) int32 {
 
 //export bigIntSignedByteLength
 func bigIntSignedByteLength(context unsafe.Pointer, reference int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntSignedByteLength", int64(reference))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -402,6 +521,8 @@ func bigIntSignedByteLength(context unsafe.Pointer, reference int32) int32 {
 
 //export bigIntGetUnsignedBytes
 func bigIntGetUnsignedBytes(context unsafe.Pointer, reference int32, byteOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntGetUnsignedBytes", int64(reference), int64(byteOffset))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -423,6 +544,8 @@ func bigIntGetUnsignedBytes(context unsafe.Pointer, reference int32, byteOffset
 
 //export bigIntGetSignedBytes
 func bigIntGetSignedBytes(context unsafe.Pointer, reference int32, byteOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntGetSignedBytes", int64(reference), int64(byteOffset))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -444,6 +567,8 @@ func bigIntGetSignedBytes(context unsafe.Pointer, reference int32, byteOffset in
 
 //export bigIntSetUnsignedBytes
 func bigIntSetUnsignedBytes(context unsafe.Pointer, destination int32, byteOffset int32, byteLength int32) {
+	defer arwen.TraceAPICall(context, "bigIntSetUnsignedBytes", int64(destination), int64(byteOffset), int64(byteLength))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -463,6 +588,8 @@ func bigIntSetUnsignedBytes(context unsafe.Pointer, destination int32, byteOffse
 
 //export bigIntSetSignedBytes
 func bigIntSetSignedBytes(context unsafe.Pointer, destination int32, byteOffset int32, byteLength int32) {
+	defer arwen.TraceAPICall(context, "bigIntSetSignedBytes", int64(destination), int64(byteOffset), int64(byteLength))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -482,6 +609,8 @@ func bigIntSetSignedBytes(context unsafe.Pointer, destination int32, byteOffset
 
 //export bigIntIsInt64
 func bigIntIsInt64(context unsafe.Pointer, destination int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntIsInt64", int64(destination))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -497,6 +626,8 @@ func bigIntIsInt64(context unsafe.Pointer, destination int32) int32 {
 
 //export bigIntGetInt64
 func bigIntGetInt64(context unsafe.Pointer, destination int32) int64 {
+	defer arwen.TraceAPICall(context, "bigIntGetInt64", int64(destination))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -509,6 +640,8 @@ func bigIntGetInt64(context unsafe.Pointer, destination int32) int64 {
 
 //export bigIntSetInt64
 func bigIntSetInt64(context unsafe.Pointer, destination int32, value int64) {
+	defer arwen.TraceAPICall(context, "bigIntSetInt64", int64(destination), value)()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -521,6 +654,8 @@ func bigIntSetInt64(context unsafe.Pointer, destination int32, value int64) {
 
 //export bigIntAdd
 func bigIntAdd(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntAdd", int64(destination), int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -533,6 +668,8 @@ func bigIntAdd(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export bigIntSub
 func bigIntSub(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntSub", int64(destination), int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -545,6 +682,8 @@ func bigIntSub(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export bigIntMul
 func bigIntMul(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntMul", int64(destination), int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -557,6 +696,8 @@ func bigIntMul(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export bigIntTDiv
 func bigIntTDiv(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntTDiv", int64(destination), int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -574,6 +715,8 @@ func bigIntTDiv(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export bigIntTMod
 func bigIntTMod(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntTMod", int64(destination), int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -591,6 +734,8 @@ func bigIntTMod(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export bigIntEDiv
 func bigIntEDiv(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntEDiv", int64(destination), int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -608,6 +753,8 @@ func bigIntEDiv(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export bigIntEMod
 func bigIntEMod(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntEMod", int64(destination), int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -625,6 +772,8 @@ func bigIntEMod(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export bigIntAbs
 func bigIntAbs(context unsafe.Pointer, destination, op int32) {
+	defer arwen.TraceAPICall(context, "bigIntAbs", int64(destination), int64(op))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -637,6 +786,8 @@ func bigIntAbs(context unsafe.Pointer, destination, op int32) {
 
 //export bigIntNeg
 func bigIntNeg(context unsafe.Pointer, destination, op int32) {
+	defer arwen.TraceAPICall(context, "bigIntNeg", int64(destination), int64(op))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -649,6 +800,8 @@ func bigIntNeg(context unsafe.Pointer, destination, op int32) {
 
 //export bigIntSign
 func bigIntSign(context unsafe.Pointer, op int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntSign", int64(op))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -661,6 +814,8 @@ func bigIntSign(context unsafe.Pointer, op int32) int32 {
 
 //export bigIntCmp
 func bigIntCmp(context unsafe.Pointer, op1, op2 int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntCmp", int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -673,6 +828,8 @@ func bigIntCmp(context unsafe.Pointer, op1, op2 int32) int32 {
 
 //export bigIntNot
 func bigIntNot(context unsafe.Pointer, destination, op int32) {
+	defer arwen.TraceAPICall(context, "bigIntNot", int64(destination), int64(op))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -690,6 +847,8 @@ func bigIntNot(context unsafe.Pointer, destination, op int32) {
 
 //export bigIntAnd
 func bigIntAnd(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntAnd", int64(destination), int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -707,6 +866,8 @@ func bigIntAnd(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export bigIntOr
 func bigIntOr(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntOr", int64(destination), int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -724,6 +885,8 @@ func bigIntOr(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export bigIntXor
 func bigIntXor(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntXor", int64(destination), int64(op1), int64(op2))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -741,6 +904,8 @@ func bigIntXor(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export bigIntShr
 func bigIntShr(context unsafe.Pointer, destination, op, bits int32) {
+	defer arwen.TraceAPICall(context, "bigIntShr", int64(destination), int64(op), int64(bits))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -758,6 +923,8 @@ func bigIntShr(context unsafe.Pointer, destination, op, bits int32) {
 
 //export bigIntShl
 func bigIntShl(context unsafe.Pointer, destination, op, bits int32) {
+	defer arwen.TraceAPICall(context, "bigIntShl", int64(destination), int64(op), int64(bits))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -773,8 +940,164 @@ func bigIntShl(context unsafe.Pointer, destination, op, bits int32) {
 	dest.Lsh(a, uint(bits))
 }
 
+//export bigIntPow
+func bigIntPow(context unsafe.Pointer, destination, op1, op2 int32) {
+	defer arwen.TraceAPICall(context, "bigIntPow", int64(destination), int64(op1), int64(op2))()
+
+	bigInt := arwen.GetBigIntContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	dest, a, b := bigInt.GetThree(destination, op1, op2)
+	if b.Sign() < 0 {
+		runtime := arwen.GetRuntimeContext(context)
+		arwen.WithFault(arwen.ErrNegativeExponent, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+
+	// the result has at most bitLen(a) * b bits, and is built by as many
+	// multiplications as the exponent has bits
+	resultBits := big.NewInt(1)
+	if a.CmpAbs(big.NewInt(1)) > 0 {
+		resultBits.Mul(big.NewInt(int64(a.BitLen())), b)
+	}
+	gasSize := bitsToBytes(resultBits)
+	gasSize.Mul(gasSize, big.NewInt(int64(b.BitLen())))
+	if !useGasForBigIntMath(context, metering.GasSchedule().BigIntAPICost.BigIntPow, gasSize) {
+		return
+	}
+
+	dest.Exp(a, b, nil)
+}
+
+//export bigIntSqrt
+func bigIntSqrt(context unsafe.Pointer, destination, op int32) {
+	defer arwen.TraceAPICall(context, "bigIntSqrt", int64(destination), int64(op))()
+
+	bigInt := arwen.GetBigIntContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	dest, a := bigInt.GetTwo(destination, op)
+	if a.Sign() < 0 {
+		runtime := arwen.GetRuntimeContext(context)
+		arwen.WithFault(arwen.ErrSqrtNegative, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+
+	gasSize := bitsToBytes(big.NewInt(int64(a.BitLen())))
+	if !useGasForBigIntMath(context, metering.GasSchedule().BigIntAPICost.BigIntSqrt, gasSize) {
+		return
+	}
+
+	dest.Sqrt(a)
+}
+
+//export bigIntLog2
+func bigIntLog2(context unsafe.Pointer, op int32) int32 {
+	defer arwen.TraceAPICall(context, "bigIntLog2", int64(op))()
+
+	bigInt := arwen.GetBigIntContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntLog2
+	metering.UseGas(gasToUse)
+
+	a := bigInt.GetOne(op)
+	if a.Sign() <= 0 {
+		runtime := arwen.GetRuntimeContext(context)
+		arwen.WithFault(arwen.ErrLog2NonPositive, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return -1
+	}
+
+	return int32(a.BitLen() - 1)
+}
+
+//export bigIntModPow
+func bigIntModPow(context unsafe.Pointer, destination, base, exponent, modulus int32) {
+	defer arwen.TraceAPICall(context, "bigIntModPow", int64(destination), int64(base), int64(exponent), int64(modulus))()
+
+	bigInt := arwen.GetBigIntContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	dest, a, b := bigInt.GetThree(destination, base, exponent)
+	m := bigInt.GetOne(modulus)
+	if m.Sign() <= 0 {
+		runtime := arwen.GetRuntimeContext(context)
+		arwen.WithFault(arwen.ErrNonPositiveModulus, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+	if b.Sign() < 0 {
+		runtime := arwen.GetRuntimeContext(context)
+		arwen.WithFault(arwen.ErrNegativeExponent, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+
+	// every multiplication is reduced modulo m, and there are as many as the
+	// exponent has bits
+	gasSize := bitsToBytes(big.NewInt(int64(m.BitLen())))
+	gasSize.Mul(gasSize, big.NewInt(int64(b.BitLen())))
+	if !useGasForBigIntMath(context, metering.GasSchedule().BigIntAPICost.BigIntModPow, gasSize) {
+		return
+	}
+
+	dest.Exp(a, b, m)
+}
+
+//export bigIntModInverse
+func bigIntModInverse(context unsafe.Pointer, destination, op, modulus int32) {
+	defer arwen.TraceAPICall(context, "bigIntModInverse", int64(destination), int64(op), int64(modulus))()
+
+	bigInt := arwen.GetBigIntContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	dest, a, m := bigInt.GetThree(destination, op, modulus)
+	if m.Sign() <= 0 {
+		runtime := arwen.GetRuntimeContext(context)
+		arwen.WithFault(arwen.ErrNonPositiveModulus, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+
+	gasSize := bitsToBytes(big.NewInt(int64(m.BitLen() + a.BitLen())))
+	if !useGasForBigIntMath(context, metering.GasSchedule().BigIntAPICost.BigIntModInverse, gasSize) {
+		return
+	}
+
+	inverse := big.NewInt(0).ModInverse(a, m)
+	if inverse == nil {
+		runtime := arwen.GetRuntimeContext(context)
+		arwen.WithFault(arwen.ErrNoModInverse, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+	dest.Set(inverse)
+}
+
+// useGasForBigIntMath charges baseCost plus BigIntCostPerByte for each of the
+// size bytes; if the gas left does not cover it, the execution fails before the
+// caller performs the operation, so huge operands cannot be abused
+func useGasForBigIntMath(context unsafe.Pointer, baseCost uint64, size *big.Int) bool {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := big.NewInt(0).SetUint64(metering.GasSchedule().BigIntAPICost.BigIntCostPerByte)
+	gasToUse.Mul(gasToUse, size)
+	gasToUse.Add(gasToUse, big.NewInt(0).SetUint64(baseCost))
+	if !gasToUse.IsUint64() || gasToUse.Uint64() > metering.GasLeft() {
+		runtime := arwen.GetRuntimeContext(context)
+		arwen.WithFault(arwen.ErrNotEnoughGas, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return false
+	}
+
+	metering.UseGas(gasToUse.Uint64())
+	return true
+}
+
+func bitsToBytes(bits *big.Int) *big.Int {
+	bytes := big.NewInt(0).Add(bits, big.NewInt(7))
+	return bytes.Rsh(bytes, 3)
+}
+
 //export bigIntFinishUnsigned
 func bigIntFinishUnsigned(context unsafe.Pointer, reference int32) {
+	defer arwen.TraceAPICall(context, "bigIntFinishUnsigned", int64(reference))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -790,6 +1113,8 @@ func bigIntFinishUnsigned(context unsafe.Pointer, reference int32) {
 
 //export bigIntFinishSigned
 func bigIntFinishSigned(context unsafe.Pointer, reference int32) {
+	defer arwen.TraceAPICall(context, "bigIntFinishSigned", int64(reference))()
+
 	bigInt := arwen.GetBigIntContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
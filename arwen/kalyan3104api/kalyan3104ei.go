@@ -10,9 +10,15 @@ package kalyan3104api
 // extern void getOwnerAddress(void *context, int32_t resultOffset);
 // extern int32_t getShardOfAddress(void *context, int32_t addressOffset);
 // extern int32_t isSmartContract(void *context, int32_t addressOffset);
+// extern int32_t isPayable(void *context, int32_t addressOffset);
+// extern int32_t getCodeHash(void *context, int32_t addressOffset, int32_t resultOffset);
+// extern int32_t getCodeMetadata(void *context, int32_t addressOffset, int32_t resultOffset);
+// extern long long getAccountNonce(void *context, int32_t addressOffset);
 // extern void getExternalBalance(void *context, int32_t addressOffset, int32_t resultOffset);
 // extern int32_t blockHash(void *context, long long nonce, int32_t resultOffset);
 // extern int32_t transferValue(void *context, int32_t dstOffset, int32_t valueOffset, int32_t dataOffset, int32_t length);
+// extern int32_t transferToken(void *context, long long gas, int32_t dstOffset, int32_t tokenIdOffset, int32_t tokenIdLength, int32_t valueOffset, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
+// extern int32_t multiTransfer(void *context, long long gas, int32_t dstOffset, int32_t numTransfers, int32_t tokenIdLengthsOffset, int32_t tokenIdsOffset, int32_t valuesOffset, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
 // extern int32_t getArgumentLength(void *context, int32_t id);
 // extern int32_t getArgument(void *context, int32_t id, int32_t argOffset);
 // extern int32_t getFunction(void *context, int32_t functionOffset);
@@ -20,21 +26,32 @@ package kalyan3104api
 // extern int32_t storageStore(void *context, int32_t keyOffset, int32_t keyLength , int32_t dataOffset, int32_t dataLength);
 // extern int32_t storageLoadLength(void *context, int32_t keyOffset, int32_t keyLength );
 // extern int32_t storageLoad(void *context, int32_t keyOffset, int32_t keyLength , int32_t dataOffset);
+// extern int32_t storageLoadFromAddress(void *context, int32_t addressOffset, int32_t keyOffset, int32_t keyLength, int32_t dataOffset);
+// extern int32_t storageCountKeysWithPrefix(void *context, int32_t prefixOffset, int32_t prefixLength);
+// extern int32_t storageGetKeysWithPrefix(void *context, int32_t prefixOffset, int32_t prefixLength, int32_t startIndex, int32_t maxKeys, int32_t resultOffset, int32_t resultLength);
 // extern void getCaller(void *context, int32_t resultOffset);
 // extern int32_t callValue(void *context, int32_t resultOffset);
+// extern int32_t getNumTokenTransfers(void *context);
+// extern int32_t getTokenIdentifier(void *context, int32_t index, int32_t resultOffset);
+// extern int32_t getTokenCallValue(void *context, int32_t index, int32_t resultOffset);
 // extern void writeLog(void *context, int32_t pointer, int32_t length, int32_t topicPtr, int32_t numTopics);
+// extern void writeEventLog(void *context, int32_t numTopics, int32_t topicLengthsOffset, int32_t topicOffset, int32_t dataOffset, int32_t dataLength);
 // extern void returnData(void* context, int32_t dataOffset, int32_t length);
 // extern void signalError(void* context, int32_t messageOffset, int32_t messageLength);
 // extern long long getGasLeft(void *context);
+// extern long long getGasPrice(void *context);
 //
 // extern int32_t executeOnDestContext(void *context, long long gas, int32_t addressOffset, int32_t valueOffset, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
 // extern int32_t executeOnSameContext(void *context, long long gas, int32_t addressOffset, int32_t valueOffset, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
 // extern int32_t delegateExecution(void *context, long long gas, int32_t addressOffset, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
 // extern int32_t executeReadOnly(void *context, long long gas, int32_t addressOffset, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
 // extern int32_t createContract(void *context, int32_t valueOffset, int32_t codeOffset, int32_t length, int32_t resultOffset, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
+// extern int32_t createContractWithMetadata(void *context, int32_t valueOffset, int32_t codeOffset, int32_t codeMetadataOffset, int32_t length, int32_t resultOffset, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
+// extern int32_t upgradeContract(void *context, long long gas, int32_t addressOffset, int32_t valueOffset, int32_t codeOffset, int32_t codeMetadataOffset, int32_t length, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
 // extern void asyncCall(void *context, int32_t dstOffset, int32_t valueOffset, int32_t dataOffset, int32_t length);
 // extern void createAsyncCall(void *context, int32_t identifierOffset, int32_t identifierLength, int32_t dstOffset, int32_t valueOffset, int32_t dataOffset, int32_t length, int32_t successCallback, int32_t successLength, int32_t errorCallback, int32_t errorLength, long long gas);
 // extern int32_t setAsyncContextCallback(void *context, int32_t identifierOffset, int32_t identifierLength, int32_t callback, int32_t callbackLength);
+// extern int32_t setAsyncContextDeadline(void *context, int32_t identifierOffset, int32_t identifierLength, long long deadline);
 //
 // extern int32_t getNumReturnData(void *context);
 // extern int32_t getReturnDataSize(void *context, int32_t resultID);
@@ -58,6 +75,7 @@ package kalyan3104api
 // extern long long getPrevBlockEpoch(void *context);
 // extern void getPrevBlockRandomSeed(void *context, int32_t resultOffset);
 // extern void getOriginalTxHash(void *context, int32_t resultOffset);
+// extern void getCurrentTxHash(void *context, int32_t resultOffset);
 //
 // extern long long int64getArgument(void *context, int32_t id);
 // extern int32_t int64storageStore(void *context, int32_t keyOffset, int32_t keyLength , long long value);
@@ -66,6 +84,7 @@ package kalyan3104api
 import "C"
 
 import (
+	"encoding/binary"
 	"math/big"
 	"unsafe"
 
@@ -100,6 +119,26 @@ func Kalyan3104EIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("isPayable", isPayable, C.isPayable)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getCodeHash", getCodeHash, C.getCodeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getCodeMetadata", getCodeMetadata, C.getCodeMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getAccountNonce", getAccountNonce, C.getAccountNonce)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getExternalBalance", getExternalBalance, C.getExternalBalance)
 	if err != nil {
 		return nil, err
@@ -115,6 +154,16 @@ func Kalyan3104EIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("transferToken", transferToken, C.transferToken)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("multiTransfer", multiTransfer, C.multiTransfer)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("asyncCall", asyncCall, C.asyncCall)
 	if err != nil {
 		return nil, err
@@ -130,6 +179,11 @@ func Kalyan3104EIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("setAsyncContextDeadline", setAsyncContextDeadline, C.setAsyncContextDeadline)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getArgumentLength", getArgumentLength, C.getArgumentLength)
 	if err != nil {
 		return nil, err
@@ -165,6 +219,21 @@ func Kalyan3104EIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("storageLoadFromAddress", storageLoadFromAddress, C.storageLoadFromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("storageCountKeysWithPrefix", storageCountKeysWithPrefix, C.storageCountKeysWithPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("storageGetKeysWithPrefix", storageGetKeysWithPrefix, C.storageGetKeysWithPrefix)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getStorageLock", getStorageLock, C.getStorageLock)
 	if err != nil {
 		return nil, err
@@ -195,11 +264,31 @@ func Kalyan3104EIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("getNumTokenTransfers", getNumTokenTransfers, C.getNumTokenTransfers)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getTokenIdentifier", getTokenIdentifier, C.getTokenIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getTokenCallValue", getTokenCallValue, C.getTokenCallValue)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("writeLog", writeLog, C.writeLog)
 	if err != nil {
 		return nil, err
 	}
 
+	imports, err = imports.Append("writeEventLog", writeEventLog, C.writeEventLog)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("finish", returnData, C.returnData)
 	if err != nil {
 		return nil, err
@@ -270,6 +359,16 @@ func Kalyan3104EIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("getGasPrice", getGasPrice, C.getGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getCurrentTxHash", getCurrentTxHash, C.getCurrentTxHash)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("executeOnDestContext", executeOnDestContext, C.executeOnDestContext)
 	if err != nil {
 		return nil, err
@@ -290,7 +389,15 @@ func Kalyan3104EIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
-	// TODO: Add extra function, upgradeContract()
+	imports, err = imports.Append("createContractWithMetadata", createContractWithMetadata, C.createContractWithMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("upgradeContract", upgradeContract, C.upgradeContract)
+	if err != nil {
+		return nil, err
+	}
 
 	imports, err = imports.Append("executeReadOnly", executeReadOnly, C.executeReadOnly)
 	if err != nil {
@@ -337,6 +444,8 @@ func Kalyan3104EIImports() (*wasmer.Imports, error) {
 
 //export getGasLeft
 func getGasLeft(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "getGasLeft")()
+
 	metering := arwen.GetMeteringContext(context)
 
 	gasToUse := metering.GasSchedule().Kalyan3104APICost.GetGasLeft
@@ -345,8 +454,23 @@ func getGasLeft(context unsafe.Pointer) int64 {
 	return int64(metering.GasLeft())
 }
 
+//export getGasPrice
+func getGasPrice(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "getGasPrice")()
+
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.GetGasPrice
+	metering.UseGas(gasToUse)
+
+	return int64(runtime.GetVMInput().GasPrice)
+}
+
 //export getSCAddress
 func getSCAddress(context unsafe.Pointer, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "getSCAddress", int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -362,6 +486,8 @@ func getSCAddress(context unsafe.Pointer, resultOffset int32) {
 
 //export getOwnerAddress
 func getOwnerAddress(context unsafe.Pointer, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "getOwnerAddress", int64(resultOffset))()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -382,6 +508,8 @@ func getOwnerAddress(context unsafe.Pointer, resultOffset int32) {
 
 //export getShardOfAddress
 func getShardOfAddress(context unsafe.Pointer, addressOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "getShardOfAddress", int64(addressOffset))()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -399,6 +527,8 @@ func getShardOfAddress(context unsafe.Pointer, addressOffset int32) int32 {
 
 //export isSmartContract
 func isSmartContract(context unsafe.Pointer, addressOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "isSmartContract", int64(addressOffset))()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -415,8 +545,117 @@ func isSmartContract(context unsafe.Pointer, addressOffset int32) int32 {
 	return int32(arwen.BooleanToInt(isSmartContract))
 }
 
+//export isPayable
+func isPayable(context unsafe.Pointer, addressOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "isPayable", int64(addressOffset))()
+
+	blockchain := arwen.GetBlockchainContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 0
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.IsPayable
+	metering.UseGas(gasToUse)
+
+	codeMetadata, err := blockchain.GetCodeMetadata(address)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 0
+	}
+
+	return int32(arwen.BooleanToInt(codeMetadata.Payable))
+}
+
+//export getCodeHash
+func getCodeHash(context unsafe.Pointer, addressOffset int32, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "getCodeHash", int64(addressOffset), int64(resultOffset))()
+
+	blockchain := arwen.GetBlockchainContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.GetCodeHash
+	metering.UseGas(gasToUse)
+
+	codeHash, err := blockchain.GetCodeHash(address)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	err = runtime.MemStore(resultOffset, codeHash)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(codeHash))
+}
+
+//export getCodeMetadata
+func getCodeMetadata(context unsafe.Pointer, addressOffset int32, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "getCodeMetadata", int64(addressOffset), int64(resultOffset))()
+
+	blockchain := arwen.GetBlockchainContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.GetCodeMetadata
+	metering.UseGas(gasToUse)
+
+	codeMetadata, err := blockchain.GetCodeMetadata(address)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	codeMetadataBytes := codeMetadata.ToBytes()
+	err = runtime.MemStore(resultOffset, codeMetadataBytes)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(codeMetadataBytes))
+}
+
+//export getAccountNonce
+func getAccountNonce(context unsafe.Pointer, addressOffset int32) int64 {
+	defer arwen.TraceAPICall(context, "getAccountNonce", int64(addressOffset))()
+
+	blockchain := arwen.GetBlockchainContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 0
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.GetAccountNonce
+	metering.UseGas(gasToUse)
+
+	nonce, err := blockchain.GetNonce(address)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 0
+	}
+
+	return int64(nonce)
+}
+
 //export signalError
 func signalError(context unsafe.Pointer, messageOffset int32, messageLength int32) {
+	defer arwen.TraceAPICall(context, "signalError", int64(messageOffset), int64(messageLength))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -432,6 +671,8 @@ func signalError(context unsafe.Pointer, messageOffset int32, messageLength int3
 
 //export getExternalBalance
 func getExternalBalance(context unsafe.Pointer, addressOffset int32, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "getExternalBalance", int64(addressOffset), int64(resultOffset))()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -454,6 +695,8 @@ func getExternalBalance(context unsafe.Pointer, addressOffset int32, resultOffse
 
 //export blockHash
 func blockHash(context unsafe.Pointer, nonce int64, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "blockHash", nonce, int64(resultOffset))()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -472,6 +715,8 @@ func blockHash(context unsafe.Pointer, nonce int64, resultOffset int32) int32 {
 
 //export transferValue
 func transferValue(context unsafe.Pointer, destOffset int32, valueOffset int32, dataOffset int32, length int32) int32 {
+	defer arwen.TraceAPICall(context, "transferValue", int64(destOffset), int64(valueOffset), int64(dataOffset), int64(length))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 	output := arwen.GetOutputContext(context)
@@ -504,6 +749,145 @@ func transferValue(context unsafe.Pointer, destOffset int32, valueOffset int32,
 	return 0
 }
 
+//export transferToken
+func transferToken(
+	context unsafe.Pointer,
+	gasLimit int64,
+	destOffset int32,
+	tokenIdOffset int32,
+	tokenIdLength int32,
+	valueOffset int32,
+	functionOffset int32,
+	functionLength int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
+) int32 {
+	defer arwen.TraceAPICall(context, "transferToken", gasLimit, int64(destOffset), int64(tokenIdOffset), int64(tokenIdLength), int64(valueOffset), int64(functionOffset), int64(functionLength), int64(numArguments), int64(argumentsLengthOffset), int64(dataOffset))()
+
+	host := arwen.GetVmContext(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	dest, err := runtime.MemLoad(destOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	tokenIdentifier, err := runtime.MemLoad(tokenIdOffset, tokenIdLength)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	value, err := runtime.MemLoad(valueOffset, arwen.BalanceLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	function, data, actualLen, err := getArgumentsFromMemory(
+		context,
+		functionOffset,
+		functionLength,
+		numArguments,
+		argumentsLengthOffset,
+		dataOffset,
+	)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.TransferToken
+	gasToUse += metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(tokenIdLength+actualLen)
+	metering.UseGas(gasToUse)
+
+	transfers := []*arwen.TokenTransfer{
+		{
+			TokenIdentifier: tokenIdentifier,
+			Value:           big.NewInt(0).SetBytes(value),
+		},
+	}
+
+	err = host.TransferTokens(dest, metering.BoundGasLimit(gasLimit), transfers, function, data)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
+//export multiTransfer
+func multiTransfer(
+	context unsafe.Pointer,
+	gasLimit int64,
+	destOffset int32,
+	numTransfers int32,
+	tokenIdLengthsOffset int32,
+	tokenIdsOffset int32,
+	valuesOffset int32,
+	functionOffset int32,
+	functionLength int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
+) int32 {
+	defer arwen.TraceAPICall(context, "multiTransfer", gasLimit, int64(destOffset), int64(numTransfers), int64(tokenIdLengthsOffset), int64(tokenIdsOffset), int64(valuesOffset), int64(functionOffset), int64(functionLength), int64(numArguments), int64(argumentsLengthOffset), int64(dataOffset))()
+
+	host := arwen.GetVmContext(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	if numTransfers <= 0 {
+		arwen.WithFault(arwen.ErrArgOutOfRange, context, runtime.Kalyan3104APIErrorShouldFailExecution())
+		return 1
+	}
+
+	dest, err := runtime.MemLoad(destOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	tokenIdentifiers, tokenIdentifiersLen, err := getSlicesFromMemory(context, numTransfers, tokenIdLengthsOffset, tokenIdsOffset)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	values, err := runtime.MemLoad(valuesOffset, numTransfers*arwen.BalanceLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	function, data, actualLen, err := getArgumentsFromMemory(
+		context,
+		functionOffset,
+		functionLength,
+		numArguments,
+		argumentsLengthOffset,
+		dataOffset,
+	)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.TransferToken * uint64(numTransfers)
+	gasToUse += metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(tokenIdentifiersLen+actualLen)
+	metering.UseGas(gasToUse)
+
+	transfers := make([]*arwen.TokenTransfer, numTransfers)
+	for i := range transfers {
+		transfers[i] = &arwen.TokenTransfer{
+			TokenIdentifier: tokenIdentifiers[i],
+			Value:           big.NewInt(0).SetBytes(values[i*arwen.BalanceLen : (i+1)*arwen.BalanceLen]),
+		}
+	}
+
+	err = host.TransferTokens(dest, metering.BoundGasLimit(gasLimit), transfers, function, data)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
 //export createAsyncCall
 func createAsyncCall(context unsafe.Pointer,
 	asyncContextIdentifier int32,
@@ -518,6 +902,8 @@ func createAsyncCall(context unsafe.Pointer,
 	errorLength int32,
 	gas int64,
 ) {
+	defer arwen.TraceAPICall(context, "createAsyncCall", int64(asyncContextIdentifier), int64(identifierLength), int64(destOffset), int64(valueOffset), int64(dataOffset), int64(length), int64(successOffset), int64(successLength), int64(errorOffset), int64(errorLength), gas)()
+
 	runtime := arwen.GetRuntimeContext(context)
 
 	acIdentifier, err := runtime.MemLoad(asyncContextIdentifier, identifierLength)
@@ -571,6 +957,8 @@ func setAsyncContextCallback(context unsafe.Pointer,
 	callback int32,
 	callbackLength int32,
 ) int32 {
+	defer arwen.TraceAPICall(context, "setAsyncContextCallback", int64(asyncContextIdentifier), int64(identifierLength), int64(callback), int64(callbackLength))()
+
 	runtime := arwen.GetRuntimeContext(context)
 
 	acIdentifier, err := runtime.MemLoad(asyncContextIdentifier, identifierLength)
@@ -593,8 +981,44 @@ func setAsyncContextCallback(context unsafe.Pointer,
 	return 0
 }
 
+//export setAsyncContextDeadline
+func setAsyncContextDeadline(context unsafe.Pointer,
+	asyncContextIdentifier int32,
+	identifierLength int32,
+	deadline int64,
+) int32 {
+	defer arwen.TraceAPICall(context, "setAsyncContextDeadline", int64(asyncContextIdentifier), int64(identifierLength), deadline)()
+
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.SetAsyncContextDeadline
+	metering.UseGas(gasToUse)
+
+	acIdentifier, err := runtime.MemLoad(asyncContextIdentifier, identifierLength)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	asyncContext, err := runtime.GetAsyncContext(acIdentifier)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	if deadline < 0 {
+		arwen.WithFault(arwen.ErrInvalidAsyncContextDeadline, context, runtime.Kalyan3104APIErrorShouldFailExecution())
+		return -1
+	}
+
+	asyncContext.Deadline = uint64(deadline)
+
+	return 0
+}
+
 //export asyncCall
 func asyncCall(context unsafe.Pointer, destOffset int32, valueOffset int32, dataOffset int32, length int32) {
+	defer arwen.TraceAPICall(context, "asyncCall", int64(destOffset), int64(valueOffset), int64(dataOffset), int64(length))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -642,6 +1066,8 @@ func asyncCall(context unsafe.Pointer, destOffset int32, valueOffset int32, data
 
 //export getArgumentLength
 func getArgumentLength(context unsafe.Pointer, id int32) int32 {
+	defer arwen.TraceAPICall(context, "getArgumentLength", int64(id))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -658,6 +1084,8 @@ func getArgumentLength(context unsafe.Pointer, id int32) int32 {
 
 //export getArgument
 func getArgument(context unsafe.Pointer, id int32, argOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "getArgument", int64(id), int64(argOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -679,6 +1107,8 @@ func getArgument(context unsafe.Pointer, id int32, argOffset int32) int32 {
 
 //export getFunction
 func getFunction(context unsafe.Pointer, functionOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "getFunction", int64(functionOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -696,6 +1126,8 @@ func getFunction(context unsafe.Pointer, functionOffset int32) int32 {
 
 //export getNumArguments
 func getNumArguments(context unsafe.Pointer) int32 {
+	defer arwen.TraceAPICall(context, "getNumArguments")()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -708,6 +1140,8 @@ func getNumArguments(context unsafe.Pointer) int32 {
 
 //export storageStore
 func storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32, dataLength int32) int32 {
+	defer arwen.TraceAPICall(context, "storageStore", int64(keyOffset), int64(keyLength), int64(dataOffset), int64(dataLength))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -735,6 +1169,8 @@ func storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32, data
 
 //export storageLoadLength
 func storageLoadLength(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
+	defer arwen.TraceAPICall(context, "storageLoadLength", int64(keyOffset), int64(keyLength))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -744,41 +1180,175 @@ func storageLoadLength(context unsafe.Pointer, keyOffset int32, keyLength int32)
 		return -1
 	}
 
-	data := storage.GetStorage(key)
-
-	gasToUse := metering.GasSchedule().Kalyan3104APICost.StorageLoad
-	metering.UseGas(gasToUse)
+	data := storage.GetStorage(key)
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.StorageLoad
+	metering.UseGas(gasToUse)
+
+	return int32(len(data))
+}
+
+//export storageLoad
+func storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "storageLoad", int64(keyOffset), int64(keyLength), int64(dataOffset))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	data := storage.GetStorage(key)
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.StorageLoad
+	gasToUse += metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(len(data))
+	metering.UseGas(gasToUse)
+
+	err = runtime.MemStore(dataOffset, data)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(data))
+}
+
+//export storageLoadFromAddress
+func storageLoadFromAddress(context unsafe.Pointer, addressOffset int32, keyOffset int32, keyLength int32, dataOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "storageLoadFromAddress", int64(addressOffset), int64(keyOffset), int64(keyLength), int64(dataOffset))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.StorageLoadFromAddress
+	metering.UseGas(gasToUse)
+
+	data, err := storage.GetStorageFromAddress(address, key)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse = metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(len(data))
+	metering.UseGas(gasToUse)
+
+	err = runtime.MemStore(dataOffset, data)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(data))
+}
+
+//export storageCountKeysWithPrefix
+func storageCountKeysWithPrefix(context unsafe.Pointer, prefixOffset int32, prefixLength int32) int32 {
+	defer arwen.TraceAPICall(context, "storageCountKeysWithPrefix", int64(prefixOffset), int64(prefixLength))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	prefix, err := runtime.MemLoad(prefixOffset, prefixLength)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.StorageCountKeys
+	metering.UseGas(gasToUse)
+
+	count, err := storage.CountStorageKeysWithPrefix(prefix)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(count)
+}
+
+//export storageGetKeysWithPrefix
+func storageGetKeysWithPrefix(
+	context unsafe.Pointer,
+	prefixOffset int32,
+	prefixLength int32,
+	startIndex int32,
+	maxKeys int32,
+	resultOffset int32,
+	resultLength int32,
+) int32 {
+	defer arwen.TraceAPICall(context, "storageGetKeysWithPrefix", int64(prefixOffset), int64(prefixLength), int64(startIndex), int64(maxKeys), int64(resultOffset), int64(resultLength))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	if startIndex < 0 || maxKeys < 0 || resultLength < 0 {
+		arwen.WithFault(arwen.ErrArgOutOfRange, context, runtime.Kalyan3104APIErrorShouldFailExecution())
+		return -1
+	}
+
+	prefix, err := runtime.MemLoad(prefixOffset, prefixLength)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.StorageGetKeys
+	metering.UseGas(gasToUse)
+
+	keys, err := storage.GetStorageKeysWithPrefix(prefix, int(startIndex), int(maxKeys))
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	if len(keys) == 0 {
+		return 0
+	}
 
-	return int32(len(data))
-}
+	const keyLengthSize = 4
+	result := make([]byte, 0)
+	numKeys := int32(0)
+	for _, key := range keys {
+		if len(result)+keyLengthSize+len(key) > int(resultLength) {
+			break
+		}
 
-//export storageLoad
-func storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32) int32 {
-	runtime := arwen.GetRuntimeContext(context)
-	storage := arwen.GetStorageContext(context)
-	metering := arwen.GetMeteringContext(context)
+		keyLength := make([]byte, keyLengthSize)
+		binary.BigEndian.PutUint32(keyLength, uint32(len(key)))
+		result = append(result, keyLength...)
+		result = append(result, key...)
+		numKeys++
+	}
 
-	key, err := runtime.MemLoad(keyOffset, keyLength)
-	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+	if numKeys == 0 {
+		arwen.WithFault(arwen.ErrStorageKeyTooLargeForBuffer, context, runtime.Kalyan3104APIErrorShouldFailExecution())
 		return -1
 	}
 
-	data := storage.GetStorage(key)
-
-	gasToUse := metering.GasSchedule().Kalyan3104APICost.StorageLoad
-	gasToUse += metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(len(data))
+	gasToUse = metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(len(result))
 	metering.UseGas(gasToUse)
 
-	err = runtime.MemStore(dataOffset, data)
+	err = runtime.MemStore(resultOffset, result)
 	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
 		return -1
 	}
 
-	return int32(len(data))
+	return numKeys
 }
 
 //export setStorageLock
 func setStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32, lockTimestamp int64) int32 {
+	defer arwen.TraceAPICall(context, "setStorageLock", int64(keyOffset), int64(keyLength), lockTimestamp)()
+
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -802,6 +1372,8 @@ func setStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32, lo
 
 //export getStorageLock
 func getStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
+	defer arwen.TraceAPICall(context, "getStorageLock", int64(keyOffset), int64(keyLength))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 	storage := arwen.GetStorageContext(context)
@@ -823,6 +1395,8 @@ func getStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32) in
 
 //export isStorageLocked
 func isStorageLocked(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
+	defer arwen.TraceAPICall(context, "isStorageLocked", int64(keyOffset), int64(keyLength))()
+
 	timeLock := getStorageLock(context, keyOffset, keyLength)
 	if timeLock < 0 {
 		return -1
@@ -838,11 +1412,15 @@ func isStorageLocked(context unsafe.Pointer, keyOffset int32, keyLength int32) i
 
 //export clearStorageLock
 func clearStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
+	defer arwen.TraceAPICall(context, "clearStorageLock", int64(keyOffset), int64(keyLength))()
+
 	return setStorageLock(context, keyOffset, keyLength, 0)
 }
 
 //export getCaller
 func getCaller(context unsafe.Pointer, resultOffset int32) {
+	defer arwen.TraceAPICall(context, "getCaller", int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -859,6 +1437,8 @@ func getCaller(context unsafe.Pointer, resultOffset int32) {
 
 //export callValue
 func callValue(context unsafe.Pointer, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "callValue", int64(resultOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -876,8 +1456,79 @@ func callValue(context unsafe.Pointer, resultOffset int32) int32 {
 	return int32(len(value))
 }
 
+//export getNumTokenTransfers
+func getNumTokenTransfers(context unsafe.Pointer) int32 {
+	defer arwen.TraceAPICall(context, "getNumTokenTransfers")()
+
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.GetNumTokenTransfers
+	metering.UseGas(gasToUse)
+
+	return int32(len(runtime.TokenTransfers()))
+}
+
+//export getTokenIdentifier
+func getTokenIdentifier(context unsafe.Pointer, index int32, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "getTokenIdentifier", int64(index), int64(resultOffset))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.GetTokenIdentifier
+	metering.UseGas(gasToUse)
+
+	transfer, err := getTokenTransfer(runtime, index)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	err = runtime.MemStore(resultOffset, transfer.TokenIdentifier)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(transfer.TokenIdentifier))
+}
+
+//export getTokenCallValue
+func getTokenCallValue(context unsafe.Pointer, index int32, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "getTokenCallValue", int64(index), int64(resultOffset))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.GetTokenCallValue
+	metering.UseGas(gasToUse)
+
+	transfer, err := getTokenTransfer(runtime, index)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	value := arwen.PadBytesLeft(transfer.Value.Bytes(), arwen.BalanceLen)
+	err = runtime.MemStore(resultOffset, value)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(value))
+}
+
+func getTokenTransfer(runtime arwen.RuntimeContext, index int32) (*arwen.TokenTransfer, error) {
+	transfers := runtime.TokenTransfers()
+	if index < 0 || int(index) >= len(transfers) {
+		return nil, arwen.ErrArgOutOfRange
+	}
+
+	return transfers[index], nil
+}
+
 //export writeLog
 func writeLog(context unsafe.Pointer, pointer int32, length int32, topicPtr int32, numTopics int32) {
+	defer arwen.TraceAPICall(context, "writeLog", int64(pointer), int64(length), int64(topicPtr), int64(numTopics))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -899,15 +1550,58 @@ func writeLog(context unsafe.Pointer, pointer int32, length int32, topicPtr int3
 		}
 	}
 
-	output.WriteLog(runtime.GetSCAddress(), topics, log)
+	err = output.WriteLog(runtime.GetSCAddress(), topics, log)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return
+	}
 
 	gasToUse := metering.GasSchedule().Kalyan3104APICost.Log
 	gasToUse += metering.GasSchedule().BaseOperationCost.PersistPerByte * uint64(numTopics*arwen.HashLen+length)
 	metering.UseGas(gasToUse)
 }
 
+//export writeEventLog
+func writeEventLog(
+	context unsafe.Pointer,
+	numTopics int32,
+	topicLengthsOffset int32,
+	topicOffset int32,
+	dataOffset int32,
+	dataLength int32,
+) {
+	defer arwen.TraceAPICall(context, "writeEventLog", int64(numTopics), int64(topicLengthsOffset), int64(topicOffset), int64(dataOffset), int64(dataLength))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	output := arwen.GetOutputContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	if output.LogLimits().TooManyTopics(int(numTopics)) {
+		arwen.WithFault(arwen.ErrTooManyLogTopics, context, runtime.Kalyan3104APIErrorShouldFailExecution())
+		return
+	}
+
+	topics, topicDataTotalLen, err := getSlicesFromMemory(context, numTopics, topicLengthsOffset, topicOffset)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return
+	}
+
+	data, err := runtime.MemLoad(dataOffset, dataLength)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.Log
+	gasToUse += metering.GasSchedule().BaseOperationCost.PersistPerByte * uint64(topicDataTotalLen+dataLength)
+	metering.UseGas(gasToUse)
+
+	err = output.WriteLog(runtime.GetSCAddress(), topics, data)
+	arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution())
+}
+
 //export getBlockTimestamp
 func getBlockTimestamp(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "getBlockTimestamp")()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -919,6 +1613,8 @@ func getBlockTimestamp(context unsafe.Pointer) int64 {
 
 //export getBlockNonce
 func getBlockNonce(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "getBlockNonce")()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -930,6 +1626,8 @@ func getBlockNonce(context unsafe.Pointer) int64 {
 
 //export getBlockRound
 func getBlockRound(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "getBlockRound")()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -941,6 +1639,8 @@ func getBlockRound(context unsafe.Pointer) int64 {
 
 //export getBlockEpoch
 func getBlockEpoch(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "getBlockEpoch")()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -952,6 +1652,8 @@ func getBlockEpoch(context unsafe.Pointer) int64 {
 
 //export getBlockRandomSeed
 func getBlockRandomSeed(context unsafe.Pointer, pointer int32) {
+	defer arwen.TraceAPICall(context, "getBlockRandomSeed", int64(pointer))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -966,6 +1668,8 @@ func getBlockRandomSeed(context unsafe.Pointer, pointer int32) {
 
 //export getStateRootHash
 func getStateRootHash(context unsafe.Pointer, pointer int32) {
+	defer arwen.TraceAPICall(context, "getStateRootHash", int64(pointer))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -980,6 +1684,8 @@ func getStateRootHash(context unsafe.Pointer, pointer int32) {
 
 //export getPrevBlockTimestamp
 func getPrevBlockTimestamp(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "getPrevBlockTimestamp")()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -991,6 +1697,8 @@ func getPrevBlockTimestamp(context unsafe.Pointer) int64 {
 
 //export getPrevBlockNonce
 func getPrevBlockNonce(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "getPrevBlockNonce")()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -1002,6 +1710,8 @@ func getPrevBlockNonce(context unsafe.Pointer) int64 {
 
 //export getPrevBlockRound
 func getPrevBlockRound(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "getPrevBlockRound")()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -1013,6 +1723,8 @@ func getPrevBlockRound(context unsafe.Pointer) int64 {
 
 //export getPrevBlockEpoch
 func getPrevBlockEpoch(context unsafe.Pointer) int64 {
+	defer arwen.TraceAPICall(context, "getPrevBlockEpoch")()
+
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -1024,6 +1736,8 @@ func getPrevBlockEpoch(context unsafe.Pointer) int64 {
 
 //export getPrevBlockRandomSeed
 func getPrevBlockRandomSeed(context unsafe.Pointer, pointer int32) {
+	defer arwen.TraceAPICall(context, "getPrevBlockRandomSeed", int64(pointer))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	blockchain := arwen.GetBlockchainContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -1038,6 +1752,8 @@ func getPrevBlockRandomSeed(context unsafe.Pointer, pointer int32) {
 
 //export returnData
 func returnData(context unsafe.Pointer, pointer int32, length int32) {
+	defer arwen.TraceAPICall(context, "returnData", int64(pointer), int64(length))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -1055,6 +1771,8 @@ func returnData(context unsafe.Pointer, pointer int32, length int32) {
 
 //export int64getArgument
 func int64getArgument(context unsafe.Pointer, id int32) int64 {
+	defer arwen.TraceAPICall(context, "int64getArgument", int64(id))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -1078,6 +1796,8 @@ func int64getArgument(context unsafe.Pointer, id int32) int64 {
 
 //export int64storageStore
 func int64storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32, value int64) int32 {
+	defer arwen.TraceAPICall(context, "int64storageStore", int64(keyOffset), int64(keyLength), value)()
+
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -1102,6 +1822,8 @@ func int64storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32,
 
 //export int64storageLoad
 func int64storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
+	defer arwen.TraceAPICall(context, "int64storageLoad", int64(keyOffset), int64(keyLength))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -1123,6 +1845,8 @@ func int64storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32)
 
 //export int64finish
 func int64finish(context unsafe.Pointer, value int64) {
+	defer arwen.TraceAPICall(context, "int64finish", value)()
+
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -1145,6 +1869,8 @@ func executeOnSameContext(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	defer arwen.TraceAPICall(context, "executeOnSameContext", gasLimit, int64(addressOffset), int64(valueOffset), int64(functionOffset), int64(functionLength), int64(numArguments), int64(argumentsLengthOffset), int64(dataOffset))()
+
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -1209,6 +1935,8 @@ func executeOnDestContext(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	defer arwen.TraceAPICall(context, "executeOnDestContext", gasLimit, int64(addressOffset), int64(valueOffset), int64(functionOffset), int64(functionLength), int64(numArguments), int64(argumentsLengthOffset), int64(dataOffset))()
+
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -1276,30 +2004,49 @@ func getArgumentsFromMemory(
 		return "", nil, 0, err
 	}
 
-	argumentsLengthData, err := runtime.MemLoad(argumentsLengthOffset, numArguments*4)
+	data, actualLen, err := getSlicesFromMemory(context, numArguments, argumentsLengthOffset, dataOffset)
 	if err != nil {
 		return "", nil, 0, err
 	}
 
+	return string(function), data, actualLen, nil
+}
+
+// getSlicesFromMemory loads numSlices consecutive byte slices starting at
+// dataOffset, their lengths being 4-byte little-endian integers starting at
+// lengthsOffset; it also returns the total length of the slices
+func getSlicesFromMemory(
+	context unsafe.Pointer,
+	numSlices int32,
+	lengthsOffset int32,
+	dataOffset int32,
+) ([][]byte, int32, error) {
+	runtime := arwen.GetRuntimeContext(context)
+
+	lengthsData, err := runtime.MemLoad(lengthsOffset, numSlices*4)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	currOffset := dataOffset
-	data, err := arwen.GuardedMakeByteSlice2D(numArguments)
+	data, err := arwen.GuardedMakeByteSlice2D(numSlices)
 	if err != nil {
-		return "", nil, 0, err
+		return nil, 0, err
 	}
 
-	for i := int32(0); i < numArguments; i++ {
-		currArgLenData := argumentsLengthData[i*4 : i*4+4]
-		actualLen := bytesToInt32(currArgLenData)
+	for i := int32(0); i < numSlices; i++ {
+		currLenData := lengthsData[i*4 : i*4+4]
+		actualLen := bytesToInt32(currLenData)
 
 		data[i], err = runtime.MemLoad(currOffset, actualLen)
 		if err != nil {
-			return "", nil, 0, err
+			return nil, 0, err
 		}
 
 		currOffset += actualLen
 	}
 
-	return string(function), data, currOffset - dataOffset, nil
+	return data, currOffset - dataOffset, nil
 }
 
 //export delegateExecution
@@ -1313,6 +2060,8 @@ func delegateExecution(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	defer arwen.TraceAPICall(context, "delegateExecution", gasLimit, int64(addressOffset), int64(functionOffset), int64(functionLength), int64(numArguments), int64(argumentsLengthOffset), int64(dataOffset))()
+
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
 	output := host.Output()
@@ -1387,6 +2136,8 @@ func executeReadOnly(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	defer arwen.TraceAPICall(context, "executeReadOnly", gasLimit, int64(addressOffset), int64(functionOffset), int64(functionLength), int64(numArguments), int64(argumentsLengthOffset), int64(dataOffset))()
+
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
 	output := host.Output()
@@ -1454,6 +2205,70 @@ func createContract(
 	numArguments int32,
 	argumentsLengthOffset int32,
 	dataOffset int32,
+) int32 {
+	defer arwen.TraceAPICall(context, "createContract", int64(valueOffset), int64(codeOffset), int64(length), int64(resultOffset), int64(numArguments), int64(argumentsLengthOffset), int64(dataOffset))()
+
+	// Contracts created without explicit code metadata are upgradeable, as
+	// they have always been.
+	codeMetadata := (&arwen.CodeMetadata{Upgradeable: true}).ToBytes()
+
+	return createContractWithCodeMetadata(
+		context,
+		valueOffset,
+		codeOffset,
+		codeMetadata,
+		length,
+		resultOffset,
+		numArguments,
+		argumentsLengthOffset,
+		dataOffset,
+	)
+}
+
+//export createContractWithMetadata
+func createContractWithMetadata(
+	context unsafe.Pointer,
+	valueOffset int32,
+	codeOffset int32,
+	codeMetadataOffset int32,
+	length int32,
+	resultOffset int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
+) int32 {
+	defer arwen.TraceAPICall(context, "createContractWithMetadata", int64(valueOffset), int64(codeOffset), int64(codeMetadataOffset), int64(length), int64(resultOffset), int64(numArguments), int64(argumentsLengthOffset), int64(dataOffset))()
+
+	runtime := arwen.GetRuntimeContext(context)
+
+	codeMetadata, err := runtime.MemLoad(codeMetadataOffset, arwen.CodeMetadataLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return createContractWithCodeMetadata(
+		context,
+		valueOffset,
+		codeOffset,
+		codeMetadata,
+		length,
+		resultOffset,
+		numArguments,
+		argumentsLengthOffset,
+		dataOffset,
+	)
+}
+
+func createContractWithCodeMetadata(
+	context unsafe.Pointer,
+	valueOffset int32,
+	codeOffset int32,
+	codeMetadata []byte,
+	length int32,
+	resultOffset int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
 ) int32 {
 	host := arwen.GetVmContext(context)
 	runtime := host.Runtime()
@@ -1495,9 +2310,8 @@ func createContract(
 			GasPrice:    0,
 			GasProvided: gasLimit,
 		},
-		ContractCode: code,
-		// TODO: Receive code metadata as argument
-		ContractCodeMetadata: []byte{1, 0},
+		ContractCode:         code,
+		ContractCodeMetadata: codeMetadata,
 	}
 
 	newAddress, err := host.CreateNewContract(contractCreate)
@@ -1513,8 +2327,89 @@ func createContract(
 	return 0
 }
 
+//export upgradeContract
+func upgradeContract(
+	context unsafe.Pointer,
+	gasLimit int64,
+	addressOffset int32,
+	valueOffset int32,
+	codeOffset int32,
+	codeMetadataOffset int32,
+	length int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
+) int32 {
+	defer arwen.TraceAPICall(context, "upgradeContract", gasLimit, int64(addressOffset), int64(valueOffset), int64(codeOffset), int64(codeMetadataOffset), int64(length), int64(numArguments), int64(argumentsLengthOffset), int64(dataOffset))()
+
+	host := arwen.GetVmContext(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	sender := runtime.GetSCAddress()
+	dest, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	value, err := runtime.MemLoad(valueOffset, arwen.BalanceLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	code, err := runtime.MemLoad(codeOffset, length)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	codeMetadata, err := runtime.MemLoad(codeMetadataOffset, arwen.CodeMetadataLen)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	_, data, actualLen, err := getArgumentsFromMemory(
+		context,
+		0,
+		0,
+		numArguments,
+		argumentsLengthOffset,
+		dataOffset,
+	)
+	if arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.CreateContract
+	gasToUse += metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(actualLen)
+	metering.UseGas(gasToUse)
+
+	// The new code and its metadata are passed as the first two arguments, the
+	// same way as for upgrades initiated by a transaction.
+	arguments := append([][]byte{code, codeMetadata}, data...)
+	contractCallInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  sender,
+			Arguments:   arguments,
+			CallValue:   big.NewInt(0).SetBytes(value),
+			GasPrice:    0,
+			GasProvided: metering.BoundGasLimit(gasLimit),
+		},
+		RecipientAddr: dest,
+		Function:      arwen.UpgradeFunctionName,
+	}
+
+	err = host.UpgradeContract(contractCallInput)
+	if err != nil {
+		return 1
+	}
+
+	return 0
+}
+
 //export getNumReturnData
 func getNumReturnData(context unsafe.Pointer) int32 {
+	defer arwen.TraceAPICall(context, "getNumReturnData")()
+
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -1527,6 +2422,8 @@ func getNumReturnData(context unsafe.Pointer) int32 {
 
 //export getReturnDataSize
 func getReturnDataSize(context unsafe.Pointer, resultID int32) int32 {
+	defer arwen.TraceAPICall(context, "getReturnDataSize", int64(resultID))()
+
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -1543,6 +2440,8 @@ func getReturnDataSize(context unsafe.Pointer, resultID int32) int32 {
 
 //export getReturnData
 func getReturnData(context unsafe.Pointer, resultID int32, dataOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "getReturnData", int64(resultID), int64(dataOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -1565,6 +2464,8 @@ func getReturnData(context unsafe.Pointer, resultID int32, dataOffset int32) int
 
 //export getOriginalTxHash
 func getOriginalTxHash(context unsafe.Pointer, dataOffset int32) {
+	defer arwen.TraceAPICall(context, "getOriginalTxHash", int64(dataOffset))()
+
 	runtime := arwen.GetRuntimeContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -1574,3 +2475,17 @@ func getOriginalTxHash(context unsafe.Pointer, dataOffset int32) {
 	err := runtime.MemStore(dataOffset, runtime.GetOriginalTxHash())
 	_ = arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution())
 }
+
+//export getCurrentTxHash
+func getCurrentTxHash(context unsafe.Pointer, dataOffset int32) {
+	defer arwen.TraceAPICall(context, "getCurrentTxHash", int64(dataOffset))()
+
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().Kalyan3104APICost.GetCurrentTxHash
+	metering.UseGas(gasToUse)
+
+	err := runtime.MemStore(dataOffset, runtime.GetCurrentTxHash())
+	_ = arwen.WithFault(err, context, runtime.Kalyan3104APIErrorShouldFailExecution())
+}
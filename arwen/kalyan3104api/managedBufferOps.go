@@ -0,0 +1,261 @@
+package kalyan3104api
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef unsigned char uint8_t;
+// typedef int int32_t;
+//
+// extern int32_t mBufferNew(void* context);
+// extern int32_t mBufferFromArgument(void* context, int32_t id, int32_t destinationHandle);
+// extern int32_t mBufferStorageStore(void* context, int32_t keyOffset, int32_t keyLength, int32_t sourceHandle);
+// extern int32_t mBufferStorageLoad(void* context, int32_t keyOffset, int32_t keyLength, int32_t destinationHandle);
+// extern int32_t mBufferAppend(void* context, int32_t accumulatorHandle, int32_t dataHandle);
+// extern int32_t mBufferGetSlice(void* context, int32_t sourceHandle, int32_t startingPosition, int32_t sliceLength, int32_t resultOffset);
+// extern int32_t mBufferFinish(void* context, int32_t sourceHandle);
+// extern int32_t mBufferToBigInt(void* context, int32_t sourceHandle, int32_t destinationHandle);
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/kalyan3104/dme-vm-go/wasmer"
+)
+
+// ManagedBufferImports creates a new wasmer.Imports populated with the ManagedBuffer API methods
+func ManagedBufferImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace("env")
+
+	imports, err := imports.Append("mBufferNew", mBufferNew, C.mBufferNew)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferFromArgument", mBufferFromArgument, C.mBufferFromArgument)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferStorageStore", mBufferStorageStore, C.mBufferStorageStore)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferStorageLoad", mBufferStorageLoad, C.mBufferStorageLoad)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferAppend", mBufferAppend, C.mBufferAppend)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferGetSlice", mBufferGetSlice, C.mBufferGetSlice)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferFinish", mBufferFinish, C.mBufferFinish)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferToBigInt", mBufferToBigInt, C.mBufferToBigInt)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+//export mBufferNew
+func mBufferNew(context unsafe.Pointer) int32 {
+	defer arwen.TraceAPICall(context, "mBufferNew")()
+
+	managedBuffer := arwen.GetManagedBufferContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferNew
+	metering.UseGas(gasToUse)
+
+	return managedBuffer.NewBuffer()
+}
+
+//export mBufferFromArgument
+func mBufferFromArgument(context unsafe.Pointer, id int32, destinationHandle int32) int32 {
+	defer arwen.TraceAPICall(context, "mBufferFromArgument", int64(id), int64(destinationHandle))()
+
+	managedBuffer := arwen.GetManagedBufferContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferFromArgument
+	metering.UseGas(gasToUse)
+
+	args := runtime.Arguments()
+	if id < 0 || int32(len(args)) <= id {
+		arwen.WithFault(arwen.ErrArgIndexOutOfRange, context, runtime.ManagedBufferAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse = metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(len(args[id]))
+	metering.UseGas(gasToUse)
+
+	managedBuffer.SetBytes(destinationHandle, args[id])
+
+	return 0
+}
+
+//export mBufferStorageStore
+func mBufferStorageStore(context unsafe.Pointer, keyOffset int32, keyLength int32, sourceHandle int32) int32 {
+	defer arwen.TraceAPICall(context, "mBufferStorageStore", int64(keyOffset), int64(keyLength), int64(sourceHandle))()
+
+	managedBuffer := arwen.GetManagedBufferContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	bytes, err := managedBuffer.GetBytes(sourceHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferStorageStore
+	metering.UseGas(gasToUse)
+
+	storageStatus, err := storage.SetStorage(key, bytes)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(storageStatus)
+}
+
+//export mBufferStorageLoad
+func mBufferStorageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32, destinationHandle int32) int32 {
+	defer arwen.TraceAPICall(context, "mBufferStorageLoad", int64(keyOffset), int64(keyLength), int64(destinationHandle))()
+
+	managedBuffer := arwen.GetManagedBufferContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	bytes := storage.GetStorage(key)
+	managedBuffer.SetBytes(destinationHandle, bytes)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferStorageLoad
+	gasToUse += metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(len(bytes))
+	metering.UseGas(gasToUse)
+
+	return int32(len(bytes))
+}
+
+//export mBufferAppend
+func mBufferAppend(context unsafe.Pointer, accumulatorHandle int32, dataHandle int32) int32 {
+	defer arwen.TraceAPICall(context, "mBufferAppend", int64(accumulatorHandle), int64(dataHandle))()
+
+	managedBuffer := arwen.GetManagedBufferContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	data, err := managedBuffer.GetBytes(dataHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferAppend
+	gasToUse += metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(len(data))
+	metering.UseGas(gasToUse)
+
+	err = managedBuffer.AppendBytes(accumulatorHandle, data)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
+//export mBufferGetSlice
+func mBufferGetSlice(context unsafe.Pointer, sourceHandle int32, startingPosition int32, sliceLength int32, resultOffset int32) int32 {
+	defer arwen.TraceAPICall(context, "mBufferGetSlice", int64(sourceHandle), int64(startingPosition), int64(sliceLength), int64(resultOffset))()
+
+	managedBuffer := arwen.GetManagedBufferContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferGetSlice
+	metering.UseGas(gasToUse)
+
+	slice, err := managedBuffer.GetSlice(sourceHandle, startingPosition, sliceLength)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse = metering.GasSchedule().BaseOperationCost.DataCopyPerByte * uint64(len(slice))
+	metering.UseGas(gasToUse)
+
+	err = runtime.MemStore(resultOffset, slice)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
+//export mBufferFinish
+func mBufferFinish(context unsafe.Pointer, sourceHandle int32) int32 {
+	defer arwen.TraceAPICall(context, "mBufferFinish", int64(sourceHandle))()
+
+	managedBuffer := arwen.GetManagedBufferContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	output := arwen.GetOutputContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	bytes, err := managedBuffer.GetBytes(sourceHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferFinish
+	gasToUse += metering.GasSchedule().BaseOperationCost.PersistPerByte * uint64(len(bytes))
+	metering.UseGas(gasToUse)
+
+	output.Finish(bytes)
+
+	return 0
+}
+
+//export mBufferToBigInt
+func mBufferToBigInt(context unsafe.Pointer, sourceHandle int32, destinationHandle int32) int32 {
+	defer arwen.TraceAPICall(context, "mBufferToBigInt", int64(sourceHandle), int64(destinationHandle))()
+
+	managedBuffer := arwen.GetManagedBufferContext(context)
+	bigInt := arwen.GetBigIntContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	bytes, err := managedBuffer.GetBytes(sourceHandle)
+	if arwen.WithFault(err, context, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferToBigInt
+	metering.UseGas(gasToUse)
+
+	value := bigInt.GetOne(destinationHandle)
+	value.SetBytes(bytes)
+
+	return 0
+}
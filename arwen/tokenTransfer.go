@@ -0,0 +1,108 @@
+package arwen
+
+import (
+	"encoding/hex"
+	"math/big"
+)
+
+// TokenTransferFunctionName is the protocol built-in function transferring a
+// single fungible token; its arguments are
+// token identifier, value, [function, arguments...]
+const TokenTransferFunctionName = "DCTTransfer"
+
+// MultiTokenTransferFunctionName is the protocol built-in function
+// transferring several fungible tokens at once; its arguments are
+// number of transfers, (token identifier, value)..., [function, arguments...]
+const MultiTokenTransferFunctionName = "MultiDCTTransfer"
+
+// TokenTransfer is a payment of a fungible token
+type TokenTransfer struct {
+	TokenIdentifier []byte
+	Value           *big.Int
+}
+
+// IsTokenTransferFunction returns whether the given function is one of the
+// token transfer built-in functions
+func IsTokenTransferFunction(function string) bool {
+	return function == TokenTransferFunctionName || function == MultiTokenTransferFunctionName
+}
+
+// TokenTransferCall returns the built-in function, and its arguments, which
+// transfers the given tokens and then calls the given function, if any
+func TokenTransferCall(transfers []*TokenTransfer, function string, arguments [][]byte) (string, [][]byte) {
+	builtinFunction := TokenTransferFunctionName
+	builtinArguments := make([][]byte, 0, 2*len(transfers)+len(arguments)+2)
+	if len(transfers) != 1 {
+		builtinFunction = MultiTokenTransferFunctionName
+		builtinArguments = append(builtinArguments, big.NewInt(int64(len(transfers))).Bytes())
+	}
+
+	for _, transfer := range transfers {
+		builtinArguments = append(builtinArguments, transfer.TokenIdentifier, transfer.Value.Bytes())
+	}
+
+	if len(function) > 0 {
+		builtinArguments = append(builtinArguments, []byte(function))
+		builtinArguments = append(builtinArguments, arguments...)
+	}
+
+	return builtinFunction, builtinArguments
+}
+
+// ParseTokenTransferCall returns the tokens transferred by a call to a token
+// transfer built-in function, followed by the function to call after the
+// transfer, which may be empty, and its arguments
+func ParseTokenTransferCall(builtinFunction string, builtinArguments [][]byte) ([]*TokenTransfer, string, [][]byte, error) {
+	numTransfers := uint64(1)
+	argumentsIndex := uint64(0)
+	switch builtinFunction {
+	case TokenTransferFunctionName:
+	case MultiTokenTransferFunctionName:
+		if len(builtinArguments) == 0 {
+			return nil, "", nil, ErrInvalidTokenTransfer
+		}
+		numTransfersBig := big.NewInt(0).SetBytes(builtinArguments[0])
+		if !numTransfersBig.IsUint64() || numTransfersBig.Uint64() == 0 {
+			return nil, "", nil, ErrInvalidTokenTransfer
+		}
+		numTransfers = numTransfersBig.Uint64()
+		argumentsIndex = 1
+	default:
+		return nil, "", nil, ErrInvalidTokenTransfer
+	}
+
+	if uint64(len(builtinArguments)) < argumentsIndex+2*numTransfers {
+		return nil, "", nil, ErrInvalidTokenTransfer
+	}
+
+	transfers := make([]*TokenTransfer, numTransfers)
+	for i := range transfers {
+		tokenIdentifier := builtinArguments[argumentsIndex]
+		if len(tokenIdentifier) == 0 {
+			return nil, "", nil, ErrInvalidTokenTransfer
+		}
+		transfers[i] = &TokenTransfer{
+			TokenIdentifier: tokenIdentifier,
+			Value:           big.NewInt(0).SetBytes(builtinArguments[argumentsIndex+1]),
+		}
+		argumentsIndex += 2
+	}
+
+	remainingArguments := builtinArguments[argumentsIndex:]
+	if len(remainingArguments) == 0 {
+		return transfers, "", nil, nil
+	}
+
+	return transfers, string(remainingArguments[0]), remainingArguments[1:], nil
+}
+
+// CallData encodes a call to the given function as the Data field of a
+// transaction, i.e. function@hex(argument1)@hex(argument2)...
+func CallData(function string, arguments [][]byte) []byte {
+	data := []byte(function)
+	for _, argument := range arguments {
+		data = append(data, '@')
+		data = append(data, hex.EncodeToString(argument)...)
+	}
+	return data
+}
@@ -0,0 +1,68 @@
+package arwen
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenTransferCall_Single(t *testing.T) {
+	transfers := []*TokenTransfer{{TokenIdentifier: []byte("TOKEN-1"), Value: big.NewInt(42)}}
+
+	function, arguments := TokenTransferCall(transfers, "", nil)
+	require.Equal(t, TokenTransferFunctionName, function)
+	require.Equal(t, [][]byte{[]byte("TOKEN-1"), {42}}, arguments)
+
+	function, arguments = TokenTransferCall(transfers, "deposit", [][]byte{[]byte("arg")})
+	require.Equal(t, TokenTransferFunctionName, function)
+	require.Equal(t, [][]byte{[]byte("TOKEN-1"), {42}, []byte("deposit"), []byte("arg")}, arguments)
+
+	parsedTransfers, parsedFunction, parsedArguments, err := ParseTokenTransferCall(function, arguments)
+	require.Nil(t, err)
+	require.Equal(t, transfers, parsedTransfers)
+	require.Equal(t, "deposit", parsedFunction)
+	require.Equal(t, [][]byte{[]byte("arg")}, parsedArguments)
+}
+
+func TestTokenTransferCall_Multi(t *testing.T) {
+	transfers := []*TokenTransfer{
+		{TokenIdentifier: []byte("TOKEN-1"), Value: big.NewInt(1)},
+		{TokenIdentifier: []byte("TOKEN-2"), Value: big.NewInt(0)},
+	}
+
+	function, arguments := TokenTransferCall(transfers, "", nil)
+	require.Equal(t, MultiTokenTransferFunctionName, function)
+	require.Equal(t, [][]byte{{2}, []byte("TOKEN-1"), {1}, []byte("TOKEN-2"), {}}, arguments)
+
+	parsedTransfers, parsedFunction, parsedArguments, err := ParseTokenTransferCall(function, arguments)
+	require.Nil(t, err)
+	require.Equal(t, transfers, parsedTransfers)
+	require.Equal(t, "", parsedFunction)
+	require.Len(t, parsedArguments, 0)
+}
+
+func TestParseTokenTransferCall_Errors(t *testing.T) {
+	testCases := []struct {
+		function  string
+		arguments [][]byte
+	}{
+		{"transfer", [][]byte{[]byte("TOKEN-1"), {1}}},
+		{TokenTransferFunctionName, [][]byte{[]byte("TOKEN-1")}},
+		{TokenTransferFunctionName, [][]byte{{}, {1}}},
+		{MultiTokenTransferFunctionName, [][]byte{}},
+		{MultiTokenTransferFunctionName, [][]byte{{0}}},
+		{MultiTokenTransferFunctionName, [][]byte{{2}, []byte("TOKEN-1"), {1}}},
+		{MultiTokenTransferFunctionName, [][]byte{{1, 0, 0, 0, 0, 0, 0, 0, 0}, []byte("TOKEN-1"), {1}}},
+	}
+
+	for _, testCase := range testCases {
+		_, _, _, err := ParseTokenTransferCall(testCase.function, testCase.arguments)
+		require.Equal(t, ErrInvalidTokenTransfer, err)
+	}
+}
+
+func TestCallData(t *testing.T) {
+	require.Equal(t, []byte("function"), CallData("function", nil))
+	require.Equal(t, []byte("DCTTransfer@544f4b454e2d31@2a@"), CallData(TokenTransferFunctionName, [][]byte{[]byte("TOKEN-1"), {42}, {}}))
+}
@@ -0,0 +1,115 @@
+package arwen
+
+import (
+	"math/big"
+	"unsafe"
+)
+
+// TraceEventType identifies the kind of an event received by an ExecutionTracer
+type TraceEventType string
+
+const (
+	// TraceEventAPICall is the call of an EI function by a smart contract
+	TraceEventAPICall TraceEventType = "apiCall"
+
+	// TraceEventExecutionEnter is the start of the execution of a smart contract
+	TraceEventExecutionEnter TraceEventType = "enter"
+
+	// TraceEventExecutionExit is the end of the execution of a smart contract
+	TraceEventExecutionExit TraceEventType = "exit"
+
+	// TraceEventStorageLoad is the read of a storage value
+	TraceEventStorageLoad TraceEventType = "storageLoad"
+
+	// TraceEventStorageStore is the write of a storage value
+	TraceEventStorageStore TraceEventType = "storageStore"
+
+	// TraceEventTransfer is a value transfer registered in the output
+	TraceEventTransfer TraceEventType = "transfer"
+
+	// TraceEventAsyncCall is the decision on how an async call is executed
+	TraceEventAsyncCall TraceEventType = "asyncCall"
+
+	// TraceEventMemory is the memory high-water mark of a contract instance,
+	// at the end of its execution
+	TraceEventMemory TraceEventType = "memory"
+)
+
+// Values of TraceEvent.Kind for the TraceEventExecutionEnter and TraceEventExecutionExit events
+const (
+	TraceKindTransaction     = "transaction"
+	TraceKindDestContext     = "destContext"
+	TraceKindSameContext     = "sameContext"
+	TraceKindCreateContract  = "createContract"
+	TraceKindUpgradeContract = "upgradeContract"
+)
+
+// TraceEvent is a structured event that happened during an execution; only
+// the fields relevant to its Type are set
+type TraceEvent struct {
+	Type TraceEventType
+
+	Kind        string   `json:",omitempty"`
+	Address     []byte   `json:",omitempty"`
+	Caller      []byte   `json:",omitempty"`
+	Function    string   `json:",omitempty"`
+	Arguments   []int64  `json:",omitempty"`
+	CallValue   *big.Int `json:",omitempty"`
+	GasProvided uint64   `json:",omitempty"`
+	GasUsed     uint64   `json:",omitempty"`
+	Key         []byte   `json:",omitempty"`
+	Value       []byte   `json:",omitempty"`
+	Destination []byte   `json:",omitempty"`
+	Data        []byte   `json:",omitempty"`
+	Mode        string   `json:",omitempty"`
+	ReturnCode  string   `json:",omitempty"`
+	Error       string   `json:",omitempty"`
+	MemoryPages uint32   `json:",omitempty"`
+}
+
+// ExecutionTracer receives the events happening during the executions on a VMHost
+type ExecutionTracer interface {
+	Trace(event *TraceEvent)
+}
+
+// noTrace is returned by TraceAPICall when the call is not traced
+func noTrace() {}
+
+// TraceAPICall starts tracing the call of an EI function and attributing the
+// gas it uses to its own category, if the VMHost running the instance has an
+// ExecutionTracer or profiles the gas used; the returned function must be
+// deferred, so that the gas used by the call is traced too
+func TraceAPICall(context unsafe.Pointer, name string, arguments ...int64) func() {
+	host := GetVmContext(context)
+	if host.Tracer() == nil && !host.Metering().IsGasProfiling() {
+		return noTrace
+	}
+
+	address := host.Runtime().GetSCAddress()
+	metering := host.Metering()
+	gasLeftBefore := metering.GasLeft()
+	previousGasCategory := metering.SetGasCategory(GasCategoryForAPI(name))
+
+	return func() {
+		metering.SetGasCategory(previousGasCategory)
+
+		gasUsed := uint64(0)
+		gasLeftAfter := metering.GasLeft()
+		if gasLeftAfter < gasLeftBefore {
+			gasUsed = gasLeftBefore - gasLeftAfter
+		}
+
+		tracer := host.Tracer()
+		if tracer == nil {
+			return
+		}
+
+		tracer.Trace(&TraceEvent{
+			Type:      TraceEventAPICall,
+			Address:   address,
+			Function:  name,
+			Arguments: arguments,
+			GasUsed:   gasUsed,
+		})
+	}
+}
@@ -18,3 +18,12 @@ func Test_DecodeArguments(t *testing.T) {
 	_, err = decodeArguments([]string{"foo"})
 	require.Equal(t, ErrInvalidArgumentEncoding, err)
 }
+
+func Test_LoadABIEndpoints(t *testing.T) {
+	endpoints, err := loadABIEndpoints("./testdata/counter.abi.json")
+	require.Nil(t, err)
+	require.Equal(t, []string{"increment", "decrement", "get"}, endpoints)
+
+	_, err = loadABIEndpoints("./testdata/missing.abi.json")
+	require.NotNil(t, err)
+}
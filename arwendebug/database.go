@@ -93,6 +93,21 @@ func (db *database) getOutcomeFile(uniqueID string) string {
 	return path.Join(db.rootPath, "out", fmt.Sprintf("%s.json", uniqueID))
 }
 
+func (db *database) storeTrace(key string, trace []byte) error {
+	if len(key) == 0 {
+		log.Trace("Database.storeTrace(), won't store (empty key)")
+		return nil
+	}
+
+	filePath := db.getTraceFile(key)
+	log.Trace("Database.storeTrace()", "file", filePath)
+	return ioutil.WriteFile(filePath, trace, 0644)
+}
+
+func (db *database) getTraceFile(uniqueID string) string {
+	return path.Join(db.rootPath, "out", fmt.Sprintf("%s.trace.jsonl", uniqueID))
+}
+
 func (db *database) unmarshalDataModel(filePath string, dataModel interface{}) error {
 	data, err := ioutil.ReadFile(filePath)
 	if err != nil {
@@ -45,6 +45,11 @@ func (f *DebugFacade) DeploySmartContract(request DeployRequest) (*DeployRespons
 		return nil, err
 	}
 
+	err = database.storeTrace(request.Outcome, world.getTrace())
+	if err != nil {
+		return nil, err
+	}
+
 	dumpOutcome(&response)
 	return response, err
 }
@@ -81,6 +86,11 @@ func (f *DebugFacade) UpgradeSmartContract(request UpgradeRequest) (*UpgradeResp
 		return nil, err
 	}
 
+	err = database.storeTrace(request.Outcome, world.getTrace())
+	if err != nil {
+		return nil, err
+	}
+
 	dumpOutcome(&response)
 	return response, err
 }
@@ -112,6 +122,11 @@ func (f *DebugFacade) RunSmartContract(request RunRequest) (*RunResponse, error)
 		return nil, err
 	}
 
+	err = database.storeTrace(request.Outcome, world.getTrace())
+	if err != nil {
+		return nil, err
+	}
+
 	dumpOutcome(&response)
 	return response, err
 }
@@ -138,6 +153,38 @@ func (f *DebugFacade) QuerySmartContract(request QueryRequest) (*QueryResponse,
 		return nil, err
 	}
 
+	err = database.storeTrace(request.Outcome, world.getTrace())
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+// VerifySmartContract checks the code of a smart contract against the rules
+// applied at deployment, without deploying it
+func (f *DebugFacade) VerifySmartContract(request VerifyRequest) (*VerifyResponse, error) {
+	log.Debug("Debugf.VerifySmartContract()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response := world.verifySmartContract(request)
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
 	dumpOutcome(&response)
 	return response, err
 }
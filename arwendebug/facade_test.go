@@ -1,15 +1,18 @@
 package arwendebug
 
 import (
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/kalyan3104/dme-vm-go/arwen"
 	"github.com/stretchr/testify/require"
 )
 
 var databasePath = "./testdata/db"
 var wasmCounterPath = "../test/contracts/counter/counter.wasm"
 var wasmErc20Path = "../test/contracts/erc20/erc20.wasm"
+var abiCounterPath = "./testdata/counter.abi.json"
 
 func init() {
 	_ = os.RemoveAll(databasePath)
@@ -105,3 +108,19 @@ func TestFacade_UpgradeContract_CounterToERC20(t *testing.T) {
 	require.Equal(t, int64(90), balanceOfAlice)
 	require.Equal(t, int64(10), balanceOfBob)
 }
+
+func TestFacade_VerifyContract(t *testing.T) {
+	context := newTestContext(t)
+
+	response := context.verifyContract(wasmCounterPath, abiCounterPath)
+	require.True(t, response.Valid)
+	require.Nil(t, response.Error)
+
+	response = context.verifyContract(wasmCounterPath, "", "increment", "decrement")
+	require.False(t, response.Valid)
+	require.True(t, errors.Is(response.Error, arwen.ErrEndpointNotDeclared))
+	require.Contains(t, response.ErrorMessage, "get")
+
+	response = context.verifyContract(wasmCounterPath, "")
+	require.True(t, response.Valid)
+}
@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	vmcommon "github.com/kalyan3104/dme-vm-common"
+	"github.com/kalyan3104/dme-vm-go/arwen"
 )
 
 // RequestBase is a CLI / REST request message
@@ -75,6 +76,7 @@ func (request *ContractRequestBase) digest() error {
 // ContractResponseBase is a CLI / REST response message
 type ContractResponseBase struct {
 	ResponseBase
-	Input  *vmcommon.VMInput
-	Output *vmcommon.VMOutput
+	Input      *vmcommon.VMInput
+	Output     *vmcommon.VMOutput
+	GasProfile *arwen.GasProfile
 }
@@ -3,7 +3,7 @@ package arwendebug
 import (
 	"io/ioutil"
 
-	vmcommon "github.com/kalyan3104/dme-vm-common"
+	"github.com/kalyan3104/dme-vm-go/arwen"
 )
 
 // DeployRequest is a CLI / REST request message
@@ -24,25 +24,12 @@ func (request *DeployRequest) digest() error {
 		return err
 	}
 
-	if len(request.CodeHex) > 0 {
-		request.Code, err = fromHex(request.CodeHex)
-		if err != nil {
-			return NewRequestErrorMessageInner("invalid contract code", err)
-		}
-	}
-
-	if len(request.CodePath) > 0 {
-		request.Code, err = ioutil.ReadFile(request.CodePath)
-		if err != nil {
-			return err
-		}
-	}
-
-	if len(request.Code) == 0 {
-		return NewRequestError("invalid contract code")
+	request.Code, err = loadCode(request.CodeHex, request.CodePath)
+	if err != nil {
+		return err
 	}
 
-	request.CodeMetadataBytes = (&vmcommon.CodeMetadata{Upgradeable: true}).ToBytes()
+	request.CodeMetadataBytes = (&arwen.CodeMetadata{Upgradeable: true}).ToBytes()
 	if len(request.CodeMetadata) > 0 {
 		request.CodeMetadataBytes, err = fromHex(request.CodeMetadata)
 		if err != nil {
@@ -58,6 +45,31 @@ func (request *DeployRequest) digest() error {
 	return nil
 }
 
+func loadCode(codeHex string, codePath string) ([]byte, error) {
+	var code []byte
+	var err error
+
+	if len(codeHex) > 0 {
+		code, err = fromHex(codeHex)
+		if err != nil {
+			return nil, NewRequestErrorMessageInner("invalid contract code", err)
+		}
+	}
+
+	if len(codePath) > 0 {
+		code, err = ioutil.ReadFile(codePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(code) == 0 {
+		return nil, NewRequestError("invalid contract code")
+	}
+
+	return code, nil
+}
+
 // DeployResponse is a CLI / REST response message
 type DeployResponse struct {
 	ContractResponseBase
@@ -0,0 +1,72 @@
+package arwendebug
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// VerifyRequest is a CLI / REST request message
+type VerifyRequest struct {
+	RequestBase
+	CodeHex   string
+	Code      []byte
+	CodePath  string
+	AbiPath   string
+	Endpoints []string
+}
+
+// abiDataModel holds the part of a contract ABI which is checked against the
+// exports of the contract
+type abiDataModel struct {
+	Endpoints []struct {
+		Name string `json:"name"`
+	} `json:"endpoints"`
+}
+
+func (request *VerifyRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	request.Code, err = loadCode(request.CodeHex, request.CodePath)
+	if err != nil {
+		return err
+	}
+
+	if len(request.AbiPath) > 0 {
+		request.Endpoints, err = loadABIEndpoints(request.AbiPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func loadABIEndpoints(abiPath string) ([]string, error) {
+	data, err := ioutil.ReadFile(abiPath)
+	if err != nil {
+		return nil, err
+	}
+
+	abi := &abiDataModel{}
+	err = json.Unmarshal(data, abi)
+	if err != nil {
+		return nil, NewRequestErrorMessageInner("invalid ABI", err)
+	}
+
+	endpoints := make([]string, 0, len(abi.Endpoints))
+	for _, endpoint := range abi.Endpoints {
+		endpoints = append(endpoints, endpoint.Name)
+	}
+
+	return endpoints, nil
+}
+
+// VerifyResponse is a CLI / REST response message
+type VerifyResponse struct {
+	ResponseBase
+	Valid        bool
+	ErrorMessage string
+}
@@ -149,6 +149,23 @@ func (context *testContext) queryContract(contract string, impersonated string,
 	return response
 }
 
+func (context *testContext) verifyContract(codePath string, abiPath string, endpoints ...string) *VerifyResponse {
+	request := VerifyRequest{
+		RequestBase: context.createRequestBase(),
+		CodePath:    codePath,
+		AbiPath:     abiPath,
+		Endpoints:   endpoints,
+	}
+
+	response, err := context.facade.VerifySmartContract(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+
+	return response
+}
+
 func (response *ContractResponseBase) getFirstResultAsInt64() int64 {
 	result, err := response.Output.GetFirstReturnData(vmcommon.AsBigInt)
 	if err != nil {
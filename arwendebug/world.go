@@ -1,6 +1,8 @@
 package arwendebug
 
 import (
+	"bytes"
+
 	vmcommon "github.com/kalyan3104/dme-vm-common"
 	"github.com/kalyan3104/dme-vm-go/arwen"
 	"github.com/kalyan3104/dme-vm-go/arwen/host"
@@ -13,10 +15,18 @@ type worldDataModel struct {
 	Accounts AccountsMap
 }
 
+// debugVM is the VM of a debugging world, which is also inspected after
+// each execution
+type debugVM interface {
+	vmcommon.VMExecutionHandler
+	arwen.VMHost
+}
+
 type world struct {
 	id             string
 	blockchainHook *BlockchainHookMock
-	vm             vmcommon.VMExecutionHandler
+	vm             debugVM
+	trace          *bytes.Buffer
 }
 
 func newWorldDataModel(worldID string) *worldDataModel {
@@ -40,10 +50,15 @@ func newWorld(dataModel *worldDataModel) (*world, error) {
 		return nil, err
 	}
 
+	trace := &bytes.Buffer{}
+	vm.SetTracer(arwen.NewJSONLinesTracer(trace))
+	vm.Metering().SetGasProfiling(true)
+
 	return &world{
 		id:             dataModel.ID,
 		blockchainHook: blockchainHook,
 		vm:             vm,
+		trace:          trace,
 	}, nil
 }
 
@@ -59,6 +74,7 @@ func getHostParameters() *arwen.VMHostParameters {
 func (w *world) deploySmartContract(request DeployRequest) *DeployResponse {
 	input := w.prepareDeployInput(request)
 	log.Trace("w.deploySmartContract()", "input", prettyJson(input))
+	w.trace.Reset()
 
 	vmOutput, err := w.vm.RunSmartContractCreate(input)
 	if err == nil {
@@ -68,6 +84,7 @@ func (w *world) deploySmartContract(request DeployRequest) *DeployResponse {
 	response := &DeployResponse{}
 	response.Input = &input.VMInput
 	response.Output = vmOutput
+	response.GasProfile = w.vm.Metering().GasProfile()
 	response.Error = err
 	response.ContractAddress = w.blockchainHook.LastCreatedContractAddress
 	response.ContractAddressHex = toHex(response.ContractAddress)
@@ -77,6 +94,7 @@ func (w *world) deploySmartContract(request DeployRequest) *DeployResponse {
 func (w *world) upgradeSmartContract(request UpgradeRequest) *UpgradeResponse {
 	input := w.prepareUpgradeInput(request)
 	log.Trace("w.upgradeSmartContract()", "input", prettyJson(input))
+	w.trace.Reset()
 
 	vmOutput, err := w.vm.RunSmartContractCall(input)
 	if err == nil {
@@ -86,6 +104,7 @@ func (w *world) upgradeSmartContract(request UpgradeRequest) *UpgradeResponse {
 	response := &UpgradeResponse{}
 	response.Input = &input.VMInput
 	response.Output = vmOutput
+	response.GasProfile = w.vm.Metering().GasProfile()
 	response.Error = err
 
 	return response
@@ -94,6 +113,7 @@ func (w *world) upgradeSmartContract(request UpgradeRequest) *UpgradeResponse {
 func (w *world) runSmartContract(request RunRequest) *RunResponse {
 	input := w.prepareCallInput(request)
 	log.Trace("w.runSmartContract()", "input", prettyJson(input))
+	w.trace.Reset()
 
 	vmOutput, err := w.vm.RunSmartContractCall(input)
 	if err == nil {
@@ -103,6 +123,7 @@ func (w *world) runSmartContract(request RunRequest) *RunResponse {
 	response := &RunResponse{}
 	response.Input = &input.VMInput
 	response.Output = vmOutput
+	response.GasProfile = w.vm.Metering().GasProfile()
 	response.Error = err
 
 	return response
@@ -111,17 +132,34 @@ func (w *world) runSmartContract(request RunRequest) *RunResponse {
 func (w *world) querySmartContract(request QueryRequest) *QueryResponse {
 	input := w.prepareCallInput(request.RunRequest)
 	log.Trace("w.querySmartContract()", "input", prettyJson(input))
+	w.trace.Reset()
 
 	vmOutput, err := w.vm.RunSmartContractCall(input)
 
 	response := &QueryResponse{}
 	response.Input = &input.VMInput
 	response.Output = vmOutput
+	response.GasProfile = w.vm.Metering().GasProfile()
 	response.Error = err
 
 	return response
 }
 
+func (w *world) verifySmartContract(request VerifyRequest) *VerifyResponse {
+	log.Trace("w.verifySmartContract()", "endpoints", request.Endpoints)
+
+	err := w.vm.VerifyContractCode(request.Code, request.Endpoints)
+
+	response := &VerifyResponse{}
+	response.Error = err
+	response.Valid = err == nil
+	if err != nil {
+		response.ErrorMessage = err.Error()
+	}
+
+	return response
+}
+
 func (w *world) createAccount(request CreateAccountRequest) *CreateAccountResponse {
 	log.Trace("w.createAccount()", "request", prettyJson(request))
 
@@ -130,6 +168,11 @@ func (w *world) createAccount(request CreateAccountRequest) *CreateAccountRespon
 	return &CreateAccountResponse{Account: account}
 }
 
+// getTrace returns the JSON-lines trace of the last execution
+func (w *world) getTrace() []byte {
+	return w.trace.Bytes()
+}
+
 func (w *world) toDataModel() *worldDataModel {
 	return &worldDataModel{
 		ID:       w.id,
@@ -20,7 +20,15 @@ type ArwenTestExecutor struct {
 	fileResolver mjparse.FileResolver
 	World        *worldhook.BlockchainHookMock
 	vm           vmi.VMExecutionHandler
+	vmHost       arwen.VMHost
 	checkGas     bool
+
+	// TxOutputObserver, if set, receives the output of every transaction executed
+	TxOutputObserver func(txIndex string, output *vmi.VMOutput)
+
+	// GasProfileObserver, if set, enables the gas profiling and receives the
+	// gas profile of every transaction executed
+	GasProfileObserver func(txIndex string, profile *arwen.GasProfile)
 }
 
 var _ mc.TestExecutor = (*ArwenTestExecutor)(nil)
@@ -47,6 +55,7 @@ func NewArwenTestExecutor() (*ArwenTestExecutor, error) {
 		fileResolver: nil,
 		World:        world,
 		vm:           vm,
+		vmHost:       vm,
 		checkGas:     true,
 	}, nil
 }
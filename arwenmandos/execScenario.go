@@ -76,11 +76,22 @@ func (ae *ArwenTestExecutor) ExecuteStep(generalStep mj.Step) error {
 
 // ExecuteTxStep executes a tx step and updates mock state.
 func (ae *ArwenTestExecutor) ExecuteTxStep(txStep *mj.TxStep) (*vmi.VMOutput, error) {
+	metering := ae.vmHost.Metering()
+	metering.SetGasProfiling(ae.GasProfileObserver != nil)
+
 	output, err := ae.executeTx(txStep.TxIdent, txStep.Tx)
 	if err != nil {
 		return nil, err
 	}
 
+	if ae.TxOutputObserver != nil {
+		ae.TxOutputObserver(txStep.TxIdent, output)
+	}
+
+	if ae.GasProfileObserver != nil {
+		ae.GasProfileObserver(txStep.TxIdent, metering.GasProfile())
+	}
+
 	// check results
 	if txStep.ExpectedResult != nil {
 		err = checkTxResults(txStep.TxIdent, txStep.ExpectedResult, ae.checkGas, output)
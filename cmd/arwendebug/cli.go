@@ -93,6 +93,18 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 		Destination: &args.CodeMetadata,
 	}
 
+	// For verify
+	flagAbiPath := cli.StringFlag{
+		Name:        "abi-path",
+		Destination: &args.AbiPath,
+	}
+
+	flagEndpoints := cli.StringSliceFlag{
+		Required: false,
+		Name:     "endpoints",
+		Value:    &args.Endpoints,
+	}
+
 	// For create-account
 	flagAccountAddress := cli.StringFlag{
 		Required:    true,
@@ -215,6 +227,23 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 				flagArguments,
 			},
 		},
+		{
+			Name:        "verify",
+			Description: "verify smart contract code without deploying it",
+			Action: func(context *cli.Context) error {
+				_, err := facade.VerifySmartContract(args.toVerifyRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagOutcome,
+				flagWorld,
+				flagDatabase,
+				flagCode,
+				flagCodePath,
+				flagAbiPath,
+				flagEndpoints,
+			},
+		},
 		{
 			Name:        "create-account",
 			Description: "create account",
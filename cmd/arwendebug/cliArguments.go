@@ -20,6 +20,8 @@ type cliArguments struct {
 	Code            string
 	CodePath        string
 	CodeMetadata    string
+	AbiPath         string
+	Endpoints       cli.StringSlice
 	Value           string
 	GasLimit        uint64
 	GasPrice        uint64
@@ -90,6 +92,17 @@ func (args *cliArguments) toQueryRequest() arwendebug.QueryRequest {
 	return *request
 }
 
+func (args *cliArguments) toVerifyRequest() arwendebug.VerifyRequest {
+	request := &arwendebug.VerifyRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.CodeHex = args.Code
+	request.CodePath = args.CodePath
+	request.AbiPath = args.AbiPath
+	request.Endpoints = args.Endpoints
+	return *request
+}
+
 func (args *cliArguments) toCreateAccountRequest() arwendebug.CreateAccountRequest {
 	request := &arwendebug.CreateAccountRequest{}
 	args.populateRequestBase(&request.RequestBase)
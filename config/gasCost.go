@@ -3,52 +3,68 @@ package config
 import "github.com/kalyan3104/dme-vm-go/wasmer"
 
 type BaseOperationCost struct {
-	StorePerByte    uint64
-	ReleasePerByte  uint64
-	DataCopyPerByte uint64
-	PersistPerByte  uint64
-	CompilePerByte  uint64
+	StorePerByte      uint64
+	ReleasePerByte    uint64
+	DataCopyPerByte   uint64
+	PersistPerByte    uint64
+	CompilePerByte    uint64
+	MemoryGrowPerPage uint64
 }
 
 type Kalyan3104APICost struct {
-	GetSCAddress         uint64
-	GetOwnerAddress      uint64
-	IsSmartContract      uint64
-	GetShardOfAddress    uint64
-	GetExternalBalance   uint64
-	GetBlockHash         uint64
-	TransferValue        uint64
-	GetArgument          uint64
-	GetFunction          uint64
-	GetNumArguments      uint64
-	StorageStore         uint64
-	StorageLoad          uint64
-	GetCaller            uint64
-	GetCallValue         uint64
-	Log                  uint64
-	Finish               uint64
-	SignalError          uint64
-	GetBlockTimeStamp    uint64
-	GetGasLeft           uint64
-	Int64GetArgument     uint64
-	Int64StorageStore    uint64
-	Int64StorageLoad     uint64
-	Int64Finish          uint64
-	GetStateRootHash     uint64
-	GetBlockNonce        uint64
-	GetBlockEpoch        uint64
-	GetBlockRound        uint64
-	GetBlockRandomSeed   uint64
-	ExecuteOnSameContext uint64
-	ExecuteOnDestContext uint64
-	DelegateExecution    uint64
-	ExecuteReadOnly      uint64
-	AsyncCallStep        uint64
-	AsyncCallbackGasLock uint64
-	CreateContract       uint64
-	GetReturnData        uint64
-	GetNumReturnData     uint64
-	GetReturnDataSize    uint64
+	GetSCAddress            uint64
+	GetOwnerAddress         uint64
+	IsSmartContract         uint64
+	GetShardOfAddress       uint64
+	GetExternalBalance      uint64
+	GetBlockHash            uint64
+	TransferValue           uint64
+	GetArgument             uint64
+	GetFunction             uint64
+	GetNumArguments         uint64
+	StorageStore            uint64
+	StorageLoad             uint64
+	GetCaller               uint64
+	GetCallValue            uint64
+	Log                     uint64
+	Finish                  uint64
+	SignalError             uint64
+	GetBlockTimeStamp       uint64
+	GetGasLeft              uint64
+	Int64GetArgument        uint64
+	Int64StorageStore       uint64
+	Int64StorageLoad        uint64
+	Int64Finish             uint64
+	GetStateRootHash        uint64
+	GetBlockNonce           uint64
+	GetBlockEpoch           uint64
+	GetBlockRound           uint64
+	GetBlockRandomSeed      uint64
+	ExecuteOnSameContext    uint64
+	ExecuteOnDestContext    uint64
+	DelegateExecution       uint64
+	ExecuteReadOnly         uint64
+	AsyncCallStep           uint64
+	AsyncCallbackGasLock    uint64
+	CreateContract          uint64
+	GetReturnData           uint64
+	GetNumReturnData        uint64
+	GetReturnDataSize       uint64
+	StorageCountKeys        uint64
+	StorageGetKeys          uint64
+	StorageKeyIteration     uint64
+	StorageLoadFromAddress  uint64
+	TransferToken           uint64
+	GetNumTokenTransfers    uint64
+	GetTokenIdentifier      uint64
+	GetTokenCallValue       uint64
+	GetCodeHash             uint64
+	GetCodeMetadata         uint64
+	GetCurrentTxHash        uint64
+	GetGasPrice             uint64
+	GetAccountNonce         uint64
+	IsPayable               uint64
+	SetAsyncContextDeadline uint64
 }
 
 type EthAPICost struct {
@@ -88,46 +104,82 @@ type EthAPICost struct {
 }
 
 type BigIntAPICost struct {
-	BigIntNew                  uint64
-	BigIntUnsignedByteLength   uint64
-	BigIntSignedByteLength     uint64
-	BigIntGetUnsignedBytes     uint64
-	BigIntGetSignedBytes       uint64
-	BigIntSetUnsignedBytes     uint64
-	BigIntSetSignedBytes       uint64
-	BigIntIsInt64              uint64
-	BigIntGetInt64             uint64
-	BigIntSetInt64             uint64
-	BigIntAdd                  uint64
-	BigIntSub                  uint64
-	BigIntMul                  uint64
-	BigIntTDiv                 uint64
-	BigIntTMod                 uint64
-	BigIntEDiv                 uint64
-	BigIntEMod                 uint64
-	BigIntAbs                  uint64
-	BigIntNeg                  uint64
-	BigIntSign                 uint64
-	BigIntCmp                  uint64
-	BigIntNot                  uint64
-	BigIntAnd                  uint64
-	BigIntOr                   uint64
-	BigIntXor                  uint64
-	BigIntShr                  uint64
-	BigIntShl                  uint64
-	BigIntFinishUnsigned       uint64
-	BigIntFinishSigned         uint64
-	BigIntStorageLoadUnsigned  uint64
-	BigIntStorageStoreUnsigned uint64
-	BigIntGetUnsignedArgument  uint64
-	BigIntGetSignedArgument    uint64
-	BigIntGetCallValue         uint64
-	BigIntGetExternalBalance   uint64
+	BigIntNew                            uint64
+	BigIntUnsignedByteLength             uint64
+	BigIntSignedByteLength               uint64
+	BigIntGetUnsignedBytes               uint64
+	BigIntGetSignedBytes                 uint64
+	BigIntSetUnsignedBytes               uint64
+	BigIntSetSignedBytes                 uint64
+	BigIntIsInt64                        uint64
+	BigIntGetInt64                       uint64
+	BigIntSetInt64                       uint64
+	BigIntAdd                            uint64
+	BigIntSub                            uint64
+	BigIntMul                            uint64
+	BigIntTDiv                           uint64
+	BigIntTMod                           uint64
+	BigIntEDiv                           uint64
+	BigIntEMod                           uint64
+	BigIntAbs                            uint64
+	BigIntNeg                            uint64
+	BigIntSign                           uint64
+	BigIntCmp                            uint64
+	BigIntNot                            uint64
+	BigIntAnd                            uint64
+	BigIntOr                             uint64
+	BigIntXor                            uint64
+	BigIntShr                            uint64
+	BigIntShl                            uint64
+	BigIntFinishUnsigned                 uint64
+	BigIntFinishSigned                   uint64
+	BigIntStorageLoadUnsigned            uint64
+	BigIntStorageStoreUnsigned           uint64
+	BigIntStorageLoadUnsignedFromAddress uint64
+	BigIntGetTokenCallValue              uint64
+	BigIntGetUnsignedArgument            uint64
+	BigIntGetSignedArgument              uint64
+	BigIntGetCallValue                   uint64
+	BigIntGetExternalBalance             uint64
+	BigIntPow                            uint64
+	BigIntSqrt                           uint64
+	BigIntLog2                           uint64
+	BigIntModPow                         uint64
+	BigIntModInverse                     uint64
+	BigIntCostPerByte                    uint64
+}
+
+type BigFloatAPICost struct {
+	BigFloatNew        uint64
+	BigFloatAdd        uint64
+	BigFloatSub        uint64
+	BigFloatMul        uint64
+	BigFloatDiv        uint64
+	BigFloatRound      uint64
+	BigFloatTruncate   uint64
+	BigFloatFromBigInt uint64
+	BigFloatToBigInt   uint64
+}
+
+type ManagedBufferAPICost struct {
+	MBufferNew          uint64
+	MBufferFromArgument uint64
+	MBufferStorageStore uint64
+	MBufferStorageLoad  uint64
+	MBufferAppend       uint64
+	MBufferGetSlice     uint64
+	MBufferFinish       uint64
+	MBufferToBigInt     uint64
 }
 
 type CryptoAPICost struct {
-	SHA256    uint64
-	Keccak256 uint64
+	SHA256        uint64
+	Keccak256     uint64
+	Ripemd160     uint64
+	HashData      uint64
+	HashPerByte   uint64
+	VerifyEd25519 uint64
+	VerifyBLS     uint64
 }
 
 type WASMOpcodeCost struct {
@@ -581,12 +633,14 @@ type WASMOpcodeCost struct {
 }
 
 type GasCost struct {
-	BaseOperationCost BaseOperationCost
-	BigIntAPICost     BigIntAPICost
-	EthAPICost        EthAPICost
-	Kalyan3104APICost Kalyan3104APICost
-	CryptoAPICost     CryptoAPICost
-	WASMOpcodeCost    WASMOpcodeCost
+	BaseOperationCost    BaseOperationCost
+	BigIntAPICost        BigIntAPICost
+	BigFloatAPICost      BigFloatAPICost
+	ManagedBufferAPICost ManagedBufferAPICost
+	EthAPICost           EthAPICost
+	Kalyan3104APICost    Kalyan3104APICost
+	CryptoAPICost        CryptoAPICost
+	WASMOpcodeCost       WASMOpcodeCost
 }
 
 func (opcode_costs_struct *WASMOpcodeCost) ToOpcodeCostsArray() [wasmer.OPCODE_COUNT]uint32 {
@@ -47,6 +47,28 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 		return nil, err
 	}
 
+	bigFloatOps := &BigFloatAPICost{}
+	err = mapstructure.Decode(gasMap["BigFloatAPICost"], bigFloatOps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForZeroUint64Fields(*bigFloatOps)
+	if err != nil {
+		return nil, err
+	}
+
+	managedBufferOps := &ManagedBufferAPICost{}
+	err = mapstructure.Decode(gasMap["ManagedBufferAPICost"], managedBufferOps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForZeroUint64Fields(*managedBufferOps)
+	if err != nil {
+		return nil, err
+	}
+
 	ethOps := &EthAPICost{}
 	err = mapstructure.Decode(gasMap["EthAPICost"], ethOps)
 	if err != nil {
@@ -81,12 +103,14 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 	}
 
 	gasCost := &GasCost{
-		BaseOperationCost: *baseOps,
-		BigIntAPICost:     *bigIntOps,
-		EthAPICost:        *ethOps,
-		Kalyan3104APICost: *kalyan3104Ops,
-		CryptoAPICost:     *cryptOps,
-		WASMOpcodeCost:    *opcodeCosts,
+		BaseOperationCost:    *baseOps,
+		BigIntAPICost:        *bigIntOps,
+		BigFloatAPICost:      *bigFloatOps,
+		ManagedBufferAPICost: *managedBufferOps,
+		EthAPICost:           *ethOps,
+		Kalyan3104APICost:    *kalyan3104Ops,
+		CryptoAPICost:        *cryptOps,
+		WASMOpcodeCost:       *opcodeCosts,
 	}
 
 	return gasCost, nil
@@ -120,6 +144,8 @@ func FillGasMap(gasMap GasScheduleMap, value, asyncCallbackGasLock uint64) GasSc
 	gasMap["Kalyan3104APICost"] = FillGasMap_Kalyan3104APICosts(value, asyncCallbackGasLock)
 	gasMap["EthAPICost"] = FillGasMap_EthereumAPICosts(value)
 	gasMap["BigIntAPICost"] = FillGasMap_BigIntAPICosts(value)
+	gasMap["BigFloatAPICost"] = FillGasMap_BigFloatAPICosts(value)
+	gasMap["ManagedBufferAPICost"] = FillGasMap_ManagedBufferAPICosts(value)
 	gasMap["CryptoAPICost"] = FillGasMap_CryptoAPICosts(value)
 	gasMap["WASMOpcodeCost"] = FillGasMap_WASMOpcodeValues(value)
 
@@ -141,6 +167,7 @@ func FillGasMap_BaseOperationCosts(value uint64) map[string]uint64 {
 	gasMap["ReleasePerByte"] = value
 	gasMap["PersistPerByte"] = value
 	gasMap["CompilePerByte"] = value
+	gasMap["MemoryGrowPerPage"] = value
 
 	return gasMap
 }
@@ -185,6 +212,21 @@ func FillGasMap_Kalyan3104APICosts(value, asyncCallbackGasLock uint64) map[strin
 	gasMap["GetReturnData"] = value
 	gasMap["GetNumReturnData"] = value
 	gasMap["GetReturnDataSize"] = value
+	gasMap["StorageCountKeys"] = value
+	gasMap["StorageGetKeys"] = value
+	gasMap["StorageKeyIteration"] = value
+	gasMap["StorageLoadFromAddress"] = value
+	gasMap["TransferToken"] = value
+	gasMap["GetNumTokenTransfers"] = value
+	gasMap["GetTokenIdentifier"] = value
+	gasMap["GetTokenCallValue"] = value
+	gasMap["GetCodeHash"] = value
+	gasMap["GetCodeMetadata"] = value
+	gasMap["GetCurrentTxHash"] = value
+	gasMap["GetGasPrice"] = value
+	gasMap["GetAccountNonce"] = value
+	gasMap["IsPayable"] = value
+	gasMap["SetAsyncContextDeadline"] = value
 
 	return gasMap
 }
@@ -261,10 +303,47 @@ func FillGasMap_BigIntAPICosts(value uint64) map[string]uint64 {
 	gasMap["BigIntFinishSigned"] = value
 	gasMap["BigIntStorageLoadUnsigned"] = value
 	gasMap["BigIntStorageStoreUnsigned"] = value
+	gasMap["BigIntStorageLoadUnsignedFromAddress"] = value
+	gasMap["BigIntGetTokenCallValue"] = value
 	gasMap["BigIntGetUnsignedArgument"] = value
 	gasMap["BigIntGetSignedArgument"] = value
 	gasMap["BigIntGetCallValue"] = value
 	gasMap["BigIntGetExternalBalance"] = value
+	gasMap["BigIntPow"] = value
+	gasMap["BigIntSqrt"] = value
+	gasMap["BigIntLog2"] = value
+	gasMap["BigIntModPow"] = value
+	gasMap["BigIntModInverse"] = value
+	gasMap["BigIntCostPerByte"] = value
+
+	return gasMap
+}
+
+func FillGasMap_BigFloatAPICosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["BigFloatNew"] = value
+	gasMap["BigFloatAdd"] = value
+	gasMap["BigFloatSub"] = value
+	gasMap["BigFloatMul"] = value
+	gasMap["BigFloatDiv"] = value
+	gasMap["BigFloatRound"] = value
+	gasMap["BigFloatTruncate"] = value
+	gasMap["BigFloatFromBigInt"] = value
+	gasMap["BigFloatToBigInt"] = value
+
+	return gasMap
+}
+
+func FillGasMap_ManagedBufferAPICosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["MBufferNew"] = value
+	gasMap["MBufferFromArgument"] = value
+	gasMap["MBufferStorageStore"] = value
+	gasMap["MBufferStorageLoad"] = value
+	gasMap["MBufferAppend"] = value
+	gasMap["MBufferGetSlice"] = value
+	gasMap["MBufferFinish"] = value
+	gasMap["MBufferToBigInt"] = value
 
 	return gasMap
 }
@@ -273,6 +352,11 @@ func FillGasMap_CryptoAPICosts(value uint64) map[string]uint64 {
 	gasMap := make(map[string]uint64)
 	gasMap["SHA256"] = value
 	gasMap["Keccak256"] = value
+	gasMap["Ripemd160"] = value
+	gasMap["HashData"] = value
+	gasMap["HashPerByte"] = value
+	gasMap["VerifyEd25519"] = value
+	gasMap["VerifyBLS"] = value
 
 	return gasMap
 }
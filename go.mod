@@ -8,6 +8,7 @@ require (
 	github.com/kalyan3104/dme-logger-go v0.0.2
 	github.com/kalyan3104/dme-vm-common v0.0.1
 	github.com/kalyan3104/dme-vm-util v0.0.1
+	github.com/kilic/bls12-381 v0.1.0
 	github.com/mitchellh/mapstructure v1.1.2
 	github.com/pelletier/go-toml v1.6.0
 	github.com/stretchr/testify v1.8.4
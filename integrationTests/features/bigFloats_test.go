@@ -0,0 +1,134 @@
+package featuresintegrationtest
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	twos "github.com/kalyan3104/dme-components-big-int/twos-complement"
+	vmi "github.com/kalyan3104/dme-vm-common"
+	arwen "github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/stretchr/testify/require"
+)
+
+const fixedPointDecimals = 18
+
+func getBigFloatsContractPath() string {
+	return filepath.Join(getTestRoot(), "contracts/big-floats/output/big-floats.wasm")
+}
+
+// fixedPointRat interprets value as a fixed-point number with 18 decimals
+func fixedPointRat(value *big.Int) *big.Rat {
+	return big.NewRat(1, 1).SetFrac(value, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(fixedPointDecimals), nil))
+}
+
+// ratToFixedPoint returns r * 10^decimals, truncated towards zero or rounded
+// half away from zero
+func ratToFixedPoint(r *big.Rat, decimals int64, round bool) *big.Int {
+	scaled := big.NewRat(1, 1).Mul(r, big.NewRat(1, 1).SetInt(big.NewInt(0).Exp(big.NewInt(10), big.NewInt(decimals), nil)))
+	if round {
+		half := big.NewRat(1, 2)
+		if scaled.Sign() < 0 {
+			scaled.Sub(scaled, half)
+		} else {
+			scaled.Add(scaled, half)
+		}
+	}
+	return big.NewInt(0).Quo(scaled.Num(), scaled.Denom())
+}
+
+func appendBigFloatsTestCase(
+	testCases []*pureFunctionIO,
+	functionName string,
+	arguments [][]byte,
+	result *big.Int,
+	expectedStatus vmi.ReturnCode, expectedMessage string,
+) []*pureFunctionIO {
+
+	expectedResults := [][]byte{}
+	if expectedStatus == vmi.Ok {
+		expectedResults = [][]byte{twos.ToBytes(result)}
+	}
+
+	return append(testCases, &pureFunctionIO{
+		functionName:    functionName,
+		arguments:       arguments,
+		expectedStatus:  expectedStatus,
+		expectedMessage: expectedMessage,
+		expectedResults: expectedResults,
+	})
+}
+
+func TestBigFloats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long test")
+	}
+
+	var testCases []*pureFunctionIO
+
+	oneAndAHalf, _ := big.NewInt(0).SetString("1500000000000000000", 10)
+	third, _ := big.NewInt(0).SetString("333333333333333333", 10)
+	large, _ := big.NewInt(0).SetString("-123456789012345678901234567890", 10)
+	numbers := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(-1),
+		oneAndAHalf,
+		third,
+		large,
+	}
+
+	for _, num1 := range numbers {
+		for _, num2 := range numbers {
+			arguments := [][]byte{twos.ToBytes(num1), twos.ToBytes(num2)}
+			x, y := fixedPointRat(num1), fixedPointRat(num2)
+
+			testCases = appendBigFloatsTestCase(testCases,
+				"add_fixed_point", arguments, big.NewInt(0).Add(num1, num2),
+				vmi.Ok, "")
+			testCases = appendBigFloatsTestCase(testCases,
+				"sub_fixed_point", arguments, big.NewInt(0).Sub(num1, num2),
+				vmi.Ok, "")
+			testCases = appendBigFloatsTestCase(testCases,
+				"mul_fixed_point", arguments, ratToFixedPoint(big.NewRat(1, 1).Mul(x, y), fixedPointDecimals, false),
+				vmi.Ok, "")
+
+			if num2.Sign() == 0 {
+				testCases = appendBigFloatsTestCase(testCases,
+					"div_fixed_point", arguments, nil,
+					vmi.ExecutionFailed, arwen.ErrDivZero.Error())
+			} else {
+				testCases = appendBigFloatsTestCase(testCases,
+					"div_fixed_point", arguments, ratToFixedPoint(big.NewRat(1, 1).Quo(x, y), fixedPointDecimals, false),
+					vmi.Ok, "")
+			}
+		}
+
+		for _, decimals := range []int64{0, 1, 17, 18, 30} {
+			arguments := [][]byte{twos.ToBytes(num1), big.NewInt(decimals).Bytes()}
+			testCases = appendBigFloatsTestCase(testCases,
+				"round_fixed_point", arguments, ratToFixedPoint(fixedPointRat(num1), decimals, true),
+				vmi.Ok, "")
+			testCases = appendBigFloatsTestCase(testCases,
+				"truncate_fixed_point", arguments, ratToFixedPoint(fixedPointRat(num1), decimals, false),
+				vmi.Ok, "")
+		}
+
+		arguments := [][]byte{twos.ToBytes(num1), big.NewInt(arwen.MaxBigFloatDecimals + 1).Bytes()}
+		testCases = appendBigFloatsTestCase(testCases,
+			"round_fixed_point", arguments, nil,
+			vmi.ExecutionFailed, arwen.ErrBigFloatDecimalsOutOfRange.Error())
+	}
+
+	logFunc := func(testCaseIndex, testCaseCount int) {
+		if testCaseIndex%100 == 0 {
+			fmt.Printf("Big float test case %d/%d\n", testCaseIndex, len(testCases))
+		}
+	}
+
+	pfe, err := newPureFunctionExecutor()
+	require.Nil(t, err)
+	pfe.initAccounts(getBigFloatsContractPath())
+	pfe.executePureFunctionTests(t, testCases, signedInterpreter, logFunc)
+}
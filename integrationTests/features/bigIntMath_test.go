@@ -0,0 +1,165 @@
+package featuresintegrationtest
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	twos "github.com/kalyan3104/dme-components-big-int/twos-complement"
+	vmi "github.com/kalyan3104/dme-vm-common"
+	arwen "github.com/kalyan3104/dme-vm-go/arwen"
+	"github.com/stretchr/testify/require"
+)
+
+func getBigIntMathContractPath() string {
+	return filepath.Join(getTestRoot(), "contracts/big-int-math/output/big-int-math.wasm")
+}
+
+func appendBigIntMathTestCase(
+	testCases []*pureFunctionIO,
+	functionName string,
+	arguments []*big.Int,
+	result *big.Int,
+	expectedStatus vmi.ReturnCode, expectedMessage string,
+) []*pureFunctionIO {
+
+	argumentsBytes := make([][]byte, len(arguments))
+	for i, argument := range arguments {
+		argumentsBytes[i] = twos.ToBytes(argument)
+	}
+
+	expectedResults := [][]byte{}
+	if expectedStatus == vmi.Ok {
+		expectedResults = [][]byte{twos.ToBytes(result)}
+	}
+
+	return append(testCases, &pureFunctionIO{
+		functionName:    functionName,
+		arguments:       argumentsBytes,
+		expectedStatus:  expectedStatus,
+		expectedMessage: expectedMessage,
+		expectedResults: expectedResults,
+	})
+}
+
+func TestBigIntMath(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long test")
+	}
+
+	var testCases []*pureFunctionIO
+
+	big1, _ := big.NewInt(0).SetString("18446744073709551616", 10)
+	big2, _ := big.NewInt(0).SetString("-123456789012345678901234567890", 10)
+	numbers := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(-1),
+		big.NewInt(2),
+		big.NewInt(12345),
+		big1,
+		big2,
+	}
+	moduli := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(-7),
+		big.NewInt(1),
+		big.NewInt(97),
+		big.NewInt(12345),
+		big1,
+	}
+
+	for _, num := range numbers {
+		// sqrt
+		if num.Sign() < 0 {
+			testCases = appendBigIntMathTestCase(testCases,
+				"sqrt_big_int", []*big.Int{num}, nil,
+				vmi.ExecutionFailed, arwen.ErrSqrtNegative.Error())
+		} else {
+			testCases = appendBigIntMathTestCase(testCases,
+				"sqrt_big_int", []*big.Int{num}, big.NewInt(0).Sqrt(num),
+				vmi.Ok, "")
+		}
+
+		// log2
+		if num.Sign() <= 0 {
+			testCases = appendBigIntMathTestCase(testCases,
+				"log2_big_int", []*big.Int{num}, nil,
+				vmi.ExecutionFailed, arwen.ErrLog2NonPositive.Error())
+		} else {
+			testCases = appendBigIntMathTestCase(testCases,
+				"log2_big_int", []*big.Int{num}, big.NewInt(int64(num.BitLen()-1)),
+				vmi.Ok, "")
+		}
+
+		// pow
+		for _, exponent := range []int64{-1, 0, 1, 2, 7, 100} {
+			exp := big.NewInt(exponent)
+			if exponent < 0 {
+				testCases = appendBigIntMathTestCase(testCases,
+					"pow_big_int", []*big.Int{num, exp}, nil,
+					vmi.ExecutionFailed, arwen.ErrNegativeExponent.Error())
+			} else {
+				testCases = appendBigIntMathTestCase(testCases,
+					"pow_big_int", []*big.Int{num, exp}, big.NewInt(0).Exp(num, exp, nil),
+					vmi.Ok, "")
+			}
+		}
+
+		for _, modulus := range moduli {
+			// mod pow
+			exp := big.NewInt(65537)
+			if modulus.Sign() <= 0 {
+				testCases = appendBigIntMathTestCase(testCases,
+					"mod_pow_big_int", []*big.Int{num, exp, modulus}, nil,
+					vmi.ExecutionFailed, arwen.ErrNonPositiveModulus.Error())
+			} else {
+				testCases = appendBigIntMathTestCase(testCases,
+					"mod_pow_big_int", []*big.Int{num, exp, modulus}, big.NewInt(0).Exp(num, exp, modulus),
+					vmi.Ok, "")
+			}
+
+			// mod inverse
+			if modulus.Sign() <= 0 {
+				testCases = appendBigIntMathTestCase(testCases,
+					"mod_inverse_big_int", []*big.Int{num, modulus}, nil,
+					vmi.ExecutionFailed, arwen.ErrNonPositiveModulus.Error())
+				continue
+			}
+			inverse := big.NewInt(0).ModInverse(num, modulus)
+			if inverse == nil {
+				testCases = appendBigIntMathTestCase(testCases,
+					"mod_inverse_big_int", []*big.Int{num, modulus}, nil,
+					vmi.ExecutionFailed, arwen.ErrNoModInverse.Error())
+			} else {
+				testCases = appendBigIntMathTestCase(testCases,
+					"mod_inverse_big_int", []*big.Int{num, modulus}, inverse,
+					vmi.Ok, "")
+			}
+		}
+	}
+
+	// a huge exponent is refused before being computed
+	hugeExponent := big.NewInt(0).Lsh(big.NewInt(1), 64)
+	testCases = appendBigIntMathTestCase(testCases,
+		"pow_big_int", []*big.Int{big2, hugeExponent}, nil,
+		vmi.ExecutionFailed, arwen.ErrNotEnoughGas.Error())
+	testCases = appendBigIntMathTestCase(testCases,
+		"pow_big_int", []*big.Int{big.NewInt(-1), hugeExponent}, big.NewInt(1),
+		vmi.Ok, "")
+	testCases = appendBigIntMathTestCase(testCases,
+		"mod_pow_big_int", []*big.Int{big2, hugeExponent, big1}, big.NewInt(0).Exp(big2, hugeExponent, big1),
+		vmi.Ok, "")
+
+	logFunc := func(testCaseIndex, testCaseCount int) {
+		if testCaseIndex%100 == 0 {
+			fmt.Printf("Big int math test case %d/%d\n", testCaseIndex, len(testCases))
+		}
+	}
+
+	pfe, err := newPureFunctionExecutor()
+	require.Nil(t, err)
+	pfe.initAccounts(getBigIntMathContractPath())
+	pfe.executePureFunctionTests(t, testCases, signedInterpreter, logFunc)
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	logger "github.com/kalyan3104/dme-logger-go"
+	vmcommon "github.com/kalyan3104/dme-vm-common"
 	am "github.com/kalyan3104/dme-vm-go/arwenmandos"
 	mc "github.com/kalyan3104/dme-vm-util/test-util/mandos/controller"
 	"github.com/stretchr/testify/require"
@@ -207,6 +208,24 @@ func TestTimelocks(t *testing.T) {
 	}
 }
 
+func TestSignatures(t *testing.T) {
+	executor, err := am.NewArwenTestExecutor()
+	require.Nil(t, err)
+	runner := mc.NewScenarioRunner(
+		executor,
+		mc.NewDefaultFileResolver(),
+	)
+	err = runner.RunAllJSONScenariosInDirectory(
+		getTestRoot(),
+		"signatures",
+		".scen.json",
+		[]string{})
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestPromises(t *testing.T) {
 	executor, err := am.NewArwenTestExecutor()
 	require.Nil(t, err)
@@ -224,3 +243,34 @@ func TestPromises(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestPromises_Deterministic(t *testing.T) {
+	runPromises := func() []*vmcommon.VMOutput {
+		executor, err := am.NewArwenTestExecutor()
+		require.Nil(t, err)
+
+		vmOutputs := make([]*vmcommon.VMOutput, 0)
+		executor.TxOutputObserver = func(_ string, output *vmcommon.VMOutput) {
+			vmOutputs = append(vmOutputs, output)
+		}
+
+		runner := mc.NewScenarioRunner(
+			executor,
+			mc.NewDefaultFileResolver(),
+		)
+		err = runner.RunAllJSONScenariosInDirectory(
+			getTestRoot(),
+			"promises",
+			".scen.json",
+			[]string{})
+		require.Nil(t, err)
+
+		return vmOutputs
+	}
+
+	expectedVMOutputs := runPromises()
+	require.NotEmpty(t, expectedVMOutputs)
+	for i := 0; i < 20; i++ {
+		require.Equal(t, expectedVMOutputs, runPromises())
+	}
+}
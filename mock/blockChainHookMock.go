@@ -147,8 +147,22 @@ func (b *BlockchainHookMock) GetBuiltinFunctionNames() vmcommon.FunctionNames {
 	return make(vmcommon.FunctionNames)
 }
 
-func (b *BlockchainHookMock) GetAllState(_ []byte) (map[string][]byte, error) {
-	return nil, nil
+func (b *BlockchainHookMock) GetAllState(address []byte) (map[string][]byte, error) {
+	if b.Err != nil {
+		return nil, b.Err
+	}
+
+	account, ok := b.Accounts[string(address)]
+	if !ok {
+		return nil, ErrAccountDoesntExist
+	}
+
+	state := make(map[string][]byte, len(account.Storage))
+	for key, value := range account.Storage {
+		state[key] = value
+	}
+
+	return state, nil
 }
 
 func (b *BlockchainHookMock) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
@@ -0,0 +1,28 @@
+package mock
+
+import (
+	"github.com/kalyan3104/dme-vm-go/arwen"
+)
+
+var _ arwen.ExecutionTracer = (*ExecutionTracerMock)(nil)
+
+// ExecutionTracerMock records the traced events
+type ExecutionTracerMock struct {
+	Events []*arwen.TraceEvent
+}
+
+// Trace records the event
+func (tracer *ExecutionTracerMock) Trace(event *arwen.TraceEvent) {
+	tracer.Events = append(tracer.Events, event)
+}
+
+// EventsOfType returns the recorded events of the given type
+func (tracer *ExecutionTracerMock) EventsOfType(eventType arwen.TraceEventType) []*arwen.TraceEvent {
+	events := make([]*arwen.TraceEvent, 0)
+	for _, event := range tracer.Events {
+		if event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+	return events
+}
@@ -10,6 +10,7 @@ var _ arwen.MeteringContext = (*MeteringContextMock)(nil)
 type MeteringContextMock struct {
 	GasCost           *config.GasCost
 	GasLeftMock       uint64
+	GasUsedMock       uint64
 	BlockGasLimitMock uint64
 	GasLocked         uint64
 	Err               error
@@ -25,6 +26,10 @@ func (m *MeteringContextMock) GasSchedule() *config.GasCost {
 }
 
 func (m *MeteringContextMock) UseGas(gas uint64) {
+	m.GasUsedMock += gas
+}
+
+func (m *MeteringContextMock) ForwardGas(gas uint64) {
 }
 
 func (m *MeteringContextMock) FreeGas(gas uint64) {
@@ -58,6 +63,13 @@ func (m *MeteringContextMock) BlockGasLimit() uint64 {
 	return m.BlockGasLimitMock
 }
 
+func (m *MeteringContextMock) UseGasForMemoryGrowth(pages uint32) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	return nil
+}
+
 func (m *MeteringContextMock) DeductInitialGasForExecution(contract []byte) error {
 	if m.Err != nil {
 		return m.Err
@@ -78,3 +90,27 @@ func (m *MeteringContextMock) DeductInitialGasForIndirectDeployment(input arwen.
 	}
 	return nil
 }
+
+func (m *MeteringContextMock) SetGasProfiling(_ bool) {
+}
+
+func (m *MeteringContextMock) IsGasProfiling() bool {
+	return false
+}
+
+func (m *MeteringContextMock) GasProfile() *arwen.GasProfile {
+	return nil
+}
+
+func (m *MeteringContextMock) SetGasCategory(_ string) string {
+	return ""
+}
+
+func (m *MeteringContextMock) StartGasProfile() {
+}
+
+func (m *MeteringContextMock) StartGasProfileFrame() {
+}
+
+func (m *MeteringContextMock) EndGasProfileFrame(_ []byte, _ uint64) {
+}
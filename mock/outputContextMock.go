@@ -22,6 +22,7 @@ type OutputContextMock struct {
 	Logs               []*vmcommon.LogEntry
 	OutputAccountMock  *vmcommon.OutputAccount
 	OutputAccountIsNew bool
+	LogLimitsMock      arwen.LogLimits
 	Err                error
 	TransferResult     error
 }
@@ -117,7 +118,16 @@ func (o *OutputContextMock) Finish(data []byte) {
 	o.ReturnDataMock = append(o.ReturnDataMock, data)
 }
 
-func (o *OutputContextMock) WriteLog(address []byte, topics [][]byte, data []byte) {
+func (o *OutputContextMock) SetLogLimits(limits arwen.LogLimits) {
+	o.LogLimitsMock = limits
+}
+
+func (o *OutputContextMock) LogLimits() arwen.LogLimits {
+	return o.LogLimitsMock
+}
+
+func (o *OutputContextMock) WriteLog(address []byte, topics [][]byte, data []byte) error {
+	return o.Err
 }
 
 func (o *OutputContextMock) Transfer(destination []byte, sender []byte, gasLimit uint64, value *big.Int, input []byte) error {
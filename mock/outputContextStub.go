@@ -19,7 +19,9 @@ type OutputContextStub struct {
 	CopyTopOfStackToActiveStateCalled func()
 	CensorVMOutputCalled              func()
 	GetOutputAccountCalled            func(address []byte) (*vmcommon.OutputAccount, bool)
-	WriteLogCalled                    func(address []byte, topics [][]byte, data []byte)
+	SetLogLimitsCalled                func(limits arwen.LogLimits)
+	LogLimitsCalled                   func() arwen.LogLimits
+	WriteLogCalled                    func(address []byte, topics [][]byte, data []byte) error
 	TransferCalled                    func(destination []byte, sender []byte, gasLimit uint64, value *big.Int, input []byte) error
 	SelfDestructCalled                func(address []byte, beneficiary []byte)
 	GetRefundCalled                   func() uint64
@@ -99,10 +101,24 @@ func (o *OutputContextStub) GetOutputAccount(address []byte) (*vmcommon.OutputAc
 	return nil, false
 }
 
-func (o *OutputContextStub) WriteLog(address []byte, topics [][]byte, data []byte) {
+func (o *OutputContextStub) SetLogLimits(limits arwen.LogLimits) {
+	if o.SetLogLimitsCalled != nil {
+		o.SetLogLimitsCalled(limits)
+	}
+}
+
+func (o *OutputContextStub) LogLimits() arwen.LogLimits {
+	if o.LogLimitsCalled != nil {
+		return o.LogLimitsCalled()
+	}
+	return arwen.LogLimits{}
+}
+
+func (o *OutputContextStub) WriteLog(address []byte, topics [][]byte, data []byte) error {
 	if o.WriteLogCalled != nil {
-		o.WriteLogCalled(address, topics, data)
+		return o.WriteLogCalled(address, topics, data)
 	}
+	return nil
 }
 
 func (o *OutputContextStub) Transfer(destination []byte, sender []byte, gasLimit uint64, value *big.Int, input []byte) error {
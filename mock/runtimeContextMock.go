@@ -22,10 +22,13 @@ type RuntimeContextMock struct {
 	FailCryptoAPI          bool
 	FailKalyan3104API      bool
 	FailBigIntAPI          bool
+	FailBigFloatAPI        bool
+	FailManagedBufferAPI   bool
 	AsyncCallInfo          *arwen.AsyncCallInfo
 	RunningInstances       uint64
 	CurrentTxHash          []byte
 	OriginalTxHash         []byte
+	Transfers              []*arwen.TokenTransfer
 }
 
 func (r *RuntimeContextMock) InitState() {
@@ -63,9 +66,38 @@ func (r *RuntimeContextMock) RunningInstancesCount() uint64 {
 	return r.RunningInstances
 }
 
+func (r *RuntimeContextMock) CallDepth() uint64 {
+	return 0
+}
+
+func (r *RuntimeContextMock) IsContractOnTheStack(address []byte) bool {
+	return false
+}
+
+func (r *RuntimeContextMock) StartCachedWasmerInstance(contract []byte, codeHash []byte, gasLimit uint64) error {
+	return r.StartWasmerInstance(contract, gasLimit)
+}
+
 func (r *RuntimeContextMock) SetMaxInstanceCount(uint64) {
 }
 
+func (r *RuntimeContextMock) SetModuleCacheSize(size int) {
+}
+
+func (r *RuntimeContextMock) SetMaxMemoryPages(maxPages uint32) {
+}
+
+func (r *RuntimeContextMock) GetMaxMemoryPages() uint32 {
+	return 0
+}
+
+func (r *RuntimeContextMock) GetMemoryPages() uint32 {
+	return 0
+}
+
+func (r *RuntimeContextMock) SetValidationPolicy(policy arwen.WASMValidationPolicy) {
+}
+
 func (r *RuntimeContextMock) ClearInstanceStack() {
 }
 
@@ -105,6 +137,14 @@ func (r *RuntimeContextMock) GetOriginalTxHash() []byte {
 	return r.OriginalTxHash
 }
 
+func (r *RuntimeContextMock) TokenTransfers() []*arwen.TokenTransfer {
+	return r.Transfers
+}
+
+func (r *RuntimeContextMock) SetTokenTransfers(transfers []*arwen.TokenTransfer) {
+	r.Transfers = transfers
+}
+
 func (r *RuntimeContextMock) ExtractCodeUpgradeFromArgs() ([]byte, []byte, error) {
 	arguments := r.VmInput.Arguments
 	if len(arguments) < 2 {
@@ -127,7 +167,14 @@ func (r *RuntimeContextMock) GetRuntimeBreakpointValue() arwen.BreakpointValue {
 	return r.CurrentBreakpointValue
 }
 
-func (r *RuntimeContextMock) VerifyContractCode() error {
+func (r *RuntimeContextMock) VerifyContractCode(code []byte) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	return nil
+}
+
+func (r *RuntimeContextMock) VerifyContractEndpoints(code []byte, endpoints []string) error {
 	if r.Err != nil {
 		return r.Err
 	}
@@ -206,6 +253,14 @@ func (r *RuntimeContextMock) BigIntAPIErrorShouldFailExecution() bool {
 	return r.FailBigIntAPI
 }
 
+func (r *RuntimeContextMock) BigFloatAPIErrorShouldFailExecution() bool {
+	return r.FailBigFloatAPI
+}
+
+func (r *RuntimeContextMock) ManagedBufferAPIErrorShouldFailExecution() bool {
+	return r.FailManagedBufferAPI
+}
+
 func (r *RuntimeContextMock) FailExecution(err error) {
 }
 
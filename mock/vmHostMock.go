@@ -11,23 +11,32 @@ var _ arwen.VMHost = (*VmHostMock)(nil)
 type VmHostMock struct {
 	BlockChainHook vmcommon.BlockchainHook
 	CryptoHook     vmcommon.CryptoHook
+	Verifier       arwen.SignatureVerifier
 
 	EthInput []byte
 
-	BlockchainContext arwen.BlockchainContext
-	RuntimeContext    arwen.RuntimeContext
-	OutputContext     arwen.OutputContext
-	MeteringContext   arwen.MeteringContext
-	StorageContext    arwen.StorageContext
-	BigIntContext     arwen.BigIntContext
+	BlockchainContext    arwen.BlockchainContext
+	RuntimeContext       arwen.RuntimeContext
+	OutputContext        arwen.OutputContext
+	MeteringContext      arwen.MeteringContext
+	StorageContext       arwen.StorageContext
+	BigIntContext        arwen.BigIntContext
+	BigFloatContext      arwen.BigFloatContext
+	ManagedBufferContext arwen.ManagedBufferContext
 
 	SCAPIMethods *wasmer.Imports
+
+	ExecutionTracer arwen.ExecutionTracer
 }
 
 func (host *VmHostMock) Crypto() vmcommon.CryptoHook {
 	return host.CryptoHook
 }
 
+func (host *VmHostMock) SignatureVerifier() arwen.SignatureVerifier {
+	return host.Verifier
+}
+
 func (host *VmHostMock) Blockchain() arwen.BlockchainContext {
 	return host.BlockchainContext
 }
@@ -52,10 +61,22 @@ func (host *VmHostMock) BigInt() arwen.BigIntContext {
 	return host.BigIntContext
 }
 
+func (host *VmHostMock) BigFloat() arwen.BigFloatContext {
+	return host.BigFloatContext
+}
+
+func (host *VmHostMock) ManagedBuffer() arwen.ManagedBufferContext {
+	return host.ManagedBufferContext
+}
+
 func (host *VmHostMock) CreateNewContract(input *vmcommon.ContractCreateInput) ([]byte, error) {
 	return nil, nil
 }
 
+func (host *VmHostMock) UpgradeContract(input *vmcommon.ContractCallInput) error {
+	return nil
+}
+
 func (host *VmHostMock) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (*arwen.AsyncContextInfo, error) {
 	return nil, nil
 }
@@ -64,6 +85,10 @@ func (host *VmHostMock) ExecuteOnDestContext(input *vmcommon.ContractCallInput)
 	return nil, nil, nil
 }
 
+func (host *VmHostMock) TransferTokens(destination []byte, gasLimit uint64, transfers []*arwen.TokenTransfer, function string, arguments [][]byte) error {
+	return nil
+}
+
 func (host *VmHostMock) EthereumCallData() []byte {
 	return host.EthInput
 }
@@ -87,3 +112,15 @@ func (host *VmHostMock) GetAPIMethods() *wasmer.Imports {
 func (host *VmHostMock) GetProtocolBuiltinFunctions() vmcommon.FunctionNames {
 	return make(vmcommon.FunctionNames)
 }
+
+func (host *VmHostMock) VerifyContractCode(code []byte, endpoints []string) error {
+	return nil
+}
+
+func (host *VmHostMock) Tracer() arwen.ExecutionTracer {
+	return host.ExecutionTracer
+}
+
+func (host *VmHostMock) SetTracer(tracer arwen.ExecutionTracer) {
+	host.ExecutionTracer = tracer
+}
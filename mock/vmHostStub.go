@@ -15,18 +15,26 @@ type VmHostStub struct {
 	ClearStateStackCalled func()
 
 	CryptoCalled                      func() vmcommon.CryptoHook
+	SignatureVerifierCalled           func() arwen.SignatureVerifier
 	BlockchainCalled                  func() arwen.BlockchainContext
 	RuntimeCalled                     func() arwen.RuntimeContext
 	BigIntCalled                      func() arwen.BigIntContext
+	BigFloatCalled                    func() arwen.BigFloatContext
+	ManagedBufferCalled               func() arwen.ManagedBufferContext
 	OutputCalled                      func() arwen.OutputContext
 	MeteringCalled                    func() arwen.MeteringContext
 	StorageCalled                     func() arwen.StorageContext
 	CreateNewContractCalled           func(input *vmcommon.ContractCreateInput) ([]byte, error)
+	UpgradeContractCalled             func(input *vmcommon.ContractCallInput) error
 	ExecuteOnSameContextCalled        func(input *vmcommon.ContractCallInput) (*arwen.AsyncContextInfo, error)
 	ExecuteOnDestContextCalled        func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *arwen.AsyncContextInfo, error)
+	TransferTokensCalled              func(destination []byte, gasLimit uint64, transfers []*arwen.TokenTransfer, function string, arguments [][]byte) error
 	EthereumCallDataCalled            func() []byte
 	GetAPIMethodsCalled               func() *wasmer.Imports
 	GetProtocolBuiltinFunctionsCalled func() vmcommon.FunctionNames
+	VerifyContractCodeCalled          func(code []byte, endpoints []string) error
+	TracerCalled                      func() arwen.ExecutionTracer
+	SetTracerCalled                   func(tracer arwen.ExecutionTracer)
 }
 
 func (vhs *VmHostStub) InitState() {
@@ -60,6 +68,13 @@ func (vhs *VmHostStub) Crypto() vmcommon.CryptoHook {
 	return nil
 }
 
+func (vhs *VmHostStub) SignatureVerifier() arwen.SignatureVerifier {
+	if vhs.SignatureVerifierCalled != nil {
+		return vhs.SignatureVerifierCalled()
+	}
+	return nil
+}
+
 func (vhs *VmHostStub) Blockchain() arwen.BlockchainContext {
 	if vhs.BlockchainCalled != nil {
 		return vhs.BlockchainCalled()
@@ -81,6 +96,20 @@ func (vhs *VmHostStub) BigInt() arwen.BigIntContext {
 	return nil
 }
 
+func (vhs *VmHostStub) BigFloat() arwen.BigFloatContext {
+	if vhs.BigFloatCalled != nil {
+		return vhs.BigFloatCalled()
+	}
+	return nil
+}
+
+func (vhs *VmHostStub) ManagedBuffer() arwen.ManagedBufferContext {
+	if vhs.ManagedBufferCalled != nil {
+		return vhs.ManagedBufferCalled()
+	}
+	return nil
+}
+
 func (vhs *VmHostStub) Output() arwen.OutputContext {
 	if vhs.OutputCalled != nil {
 		return vhs.OutputCalled()
@@ -109,6 +138,13 @@ func (vhs *VmHostStub) CreateNewContract(input *vmcommon.ContractCreateInput) ([
 	return nil, nil
 }
 
+func (vhs *VmHostStub) UpgradeContract(input *vmcommon.ContractCallInput) error {
+	if vhs.UpgradeContractCalled != nil {
+		return vhs.UpgradeContractCalled(input)
+	}
+	return nil
+}
+
 func (vhs *VmHostStub) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (*arwen.AsyncContextInfo, error) {
 	if vhs.ExecuteOnSameContextCalled != nil {
 		return vhs.ExecuteOnSameContextCalled(input)
@@ -123,6 +159,13 @@ func (vhs *VmHostStub) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (
 	return nil, nil, nil
 }
 
+func (vhs *VmHostStub) TransferTokens(destination []byte, gasLimit uint64, transfers []*arwen.TokenTransfer, function string, arguments [][]byte) error {
+	if vhs.TransferTokensCalled != nil {
+		return vhs.TransferTokensCalled(destination, gasLimit, transfers, function, arguments)
+	}
+	return nil
+}
+
 func (vhs *VmHostStub) EthereumCallData() []byte {
 	if vhs.EthereumCallDataCalled != nil {
 		return vhs.EthereumCallDataCalled()
@@ -143,3 +186,23 @@ func (vhs *VmHostStub) GetProtocolBuiltinFunctions() vmcommon.FunctionNames {
 	}
 	return make(vmcommon.FunctionNames)
 }
+
+func (vhs *VmHostStub) VerifyContractCode(code []byte, endpoints []string) error {
+	if vhs.VerifyContractCodeCalled != nil {
+		return vhs.VerifyContractCodeCalled(code, endpoints)
+	}
+	return nil
+}
+
+func (vhs *VmHostStub) Tracer() arwen.ExecutionTracer {
+	if vhs.TracerCalled != nil {
+		return vhs.TracerCalled()
+	}
+	return nil
+}
+
+func (vhs *VmHostStub) SetTracer(tracer arwen.ExecutionTracer) {
+	if vhs.SetTracerCalled != nil {
+		vhs.SetTracerCalled(tracer)
+	}
+}
@@ -0,0 +1,37 @@
+#include "../kalyan3104/context.h"
+
+byte address[32] = {0};
+byte result[32] = {0};
+
+// codeHash(address) returns the hash of the code deployed at address
+void codeHash() {
+	getArgument(0, address);
+	int length = getCodeHash(address, result);
+	finish(result, length);
+}
+
+// codeMetadata(address) returns the code metadata of the account at address
+void codeMetadata() {
+	getArgument(0, address);
+	int length = getCodeMetadata(address, result);
+	finish(result, length);
+}
+
+void payable() {
+	getArgument(0, address);
+	int64finish(isPayable(address));
+}
+
+void accountNonce() {
+	getArgument(0, address);
+	int64finish(getAccountNonce(address));
+}
+
+void gasPrice() {
+	int64finish(getGasPrice());
+}
+
+void currentTxHash() {
+	getCurrentTxHash(result);
+	finish(result, 32);
+}
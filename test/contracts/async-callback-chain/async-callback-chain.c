@@ -0,0 +1,105 @@
+#include "../kalyan3104/context.h"
+#include "../kalyan3104/test_utils.h"
+
+byte chainIdentifier[] = "chain";
+byte childAddress[] = "childSC.........................";
+byte thirdPartyAddress[] = "thirdPartyAddress...............";
+byte zeroValue[32] = {0};
+byte childFunctionName[] = "childFunction";
+byte callbackToChildName[] = "callbackToChild";
+byte callbackToThirdPartyName[] = "callbackToThirdParty";
+byte finalCallbackName[] = "finalCallback";
+byte counterKey[] = "counter";
+byte childResult[] = "child";
+byte groupIdentifier[] = "group";
+byte groupCallbackName[] = "groupCallback";
+byte groupsKey[] = "groups";
+byte errorsKey[] = "errors";
+
+void init() {
+}
+
+void incrementStoredValue(byte *key, int keyLength) {
+	long long value = int64storageLoad(key, keyLength);
+	int64storageStore(key, keyLength, value + 1);
+}
+
+void incrementCounter() {
+	incrementStoredValue(counterKey, 7);
+}
+
+void startChainToThirdParty() {
+	createAsyncCall(
+			chainIdentifier, 5,
+			childAddress, zeroValue,
+			childFunctionName, 13,
+			callbackToThirdPartyName, 20,
+			callbackToThirdPartyName, 20,
+			0
+	);
+}
+
+void startEndlessChain() {
+	createAsyncCall(
+			chainIdentifier, 5,
+			childAddress, zeroValue,
+			childFunctionName, 13,
+			callbackToChildName, 15,
+			callbackToChildName, 15,
+			0
+	);
+}
+
+void childFunction() {
+	finish(childResult, 5);
+}
+
+void callbackToThirdParty() {
+	incrementCounter();
+	createAsyncCall(
+			chainIdentifier, 5,
+			thirdPartyAddress, zeroValue,
+			childFunctionName, 13,
+			finalCallbackName, 13,
+			finalCallbackName, 13,
+			0
+	);
+}
+
+void callbackToChild() {
+	incrementCounter();
+	createAsyncCall(
+			chainIdentifier, 5,
+			childAddress, zeroValue,
+			childFunctionName, 13,
+			callbackToChildName, 15,
+			callbackToChildName, 15,
+			0
+	);
+}
+
+void finalCallback() {
+	incrementCounter();
+}
+
+void startGroupWithCallback() {
+	for (int i = 0; i < 2; i++) {
+		createAsyncCall(
+				groupIdentifier, 5,
+				childAddress, zeroValue,
+				childFunctionName, 13,
+				finalCallbackName, 13,
+				finalCallbackName, 13,
+				0
+		);
+	}
+	setAsyncContextCallback(groupIdentifier, 5, groupCallbackName, 13);
+}
+
+void groupCallback() {
+	incrementStoredValue(groupsKey, 6);
+}
+
+void errorCallback() {
+	incrementStoredValue(errorsKey, 6);
+}
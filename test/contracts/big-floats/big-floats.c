@@ -0,0 +1,67 @@
+#include "../kalyan3104/context.h"
+#include "../kalyan3104/bigInt.h"
+#include "../kalyan3104/bigFloat.h"
+
+#define DECIMALS 18
+
+bigFloat fixedPointArgument(int argumentIndex) {
+	bigInt argument = bigIntNew(0);
+	bigIntGetSignedArgument(argumentIndex, argument);
+	bigFloat value = bigFloatNew(0, 0);
+	bigFloatFromBigInt(value, argument, DECIMALS);
+	return value;
+}
+
+void finishFixedPoint(bigFloat value, int decimals) {
+	bigInt result = bigIntNew(0);
+	bigFloatToBigInt(result, value, decimals);
+	bigIntFinishSigned(result);
+}
+
+void add_fixed_point() {
+	bigFloat op1 = fixedPointArgument(0);
+	bigFloat op2 = fixedPointArgument(1);
+	bigFloat result = bigFloatNew(0, 0);
+	bigFloatAdd(result, op1, op2);
+	finishFixedPoint(result, DECIMALS);
+}
+
+void sub_fixed_point() {
+	bigFloat op1 = fixedPointArgument(0);
+	bigFloat op2 = fixedPointArgument(1);
+	bigFloat result = bigFloatNew(0, 0);
+	bigFloatSub(result, op1, op2);
+	finishFixedPoint(result, DECIMALS);
+}
+
+void mul_fixed_point() {
+	bigFloat op1 = fixedPointArgument(0);
+	bigFloat op2 = fixedPointArgument(1);
+	bigFloat result = bigFloatNew(0, 0);
+	bigFloatMul(result, op1, op2);
+	finishFixedPoint(result, DECIMALS);
+}
+
+void div_fixed_point() {
+	bigFloat op1 = fixedPointArgument(0);
+	bigFloat op2 = fixedPointArgument(1);
+	bigFloat result = bigFloatNew(0, 0);
+	bigFloatDiv(result, op1, op2, DECIMALS);
+	finishFixedPoint(result, DECIMALS);
+}
+
+void round_fixed_point() {
+	bigFloat op = fixedPointArgument(0);
+	bigFloat result = bigFloatNew(0, 0);
+	int decimals = (int)int64getArgument(1);
+	bigFloatRound(result, op, decimals);
+	finishFixedPoint(result, decimals);
+}
+
+void truncate_fixed_point() {
+	bigFloat op = fixedPointArgument(0);
+	bigFloat result = bigFloatNew(0, 0);
+	int decimals = (int)int64getArgument(1);
+	bigFloatTruncate(result, op, decimals);
+	finishFixedPoint(result, decimals);
+}
@@ -0,0 +1,48 @@
+#include "../kalyan3104/context.h"
+#include "../kalyan3104/bigInt.h"
+
+void pow_big_int() {
+	bigInt op1 = bigIntNew(0);
+	bigInt op2 = bigIntNew(0);
+	bigInt result = bigIntNew(0);
+	bigIntGetSignedArgument(0, op1);
+	bigIntGetSignedArgument(1, op2);
+	bigIntPow(result, op1, op2);
+	bigIntFinishSigned(result);
+}
+
+void sqrt_big_int() {
+	bigInt op = bigIntNew(0);
+	bigInt result = bigIntNew(0);
+	bigIntGetSignedArgument(0, op);
+	bigIntSqrt(result, op);
+	bigIntFinishSigned(result);
+}
+
+void mod_pow_big_int() {
+	bigInt base = bigIntNew(0);
+	bigInt exponent = bigIntNew(0);
+	bigInt modulus = bigIntNew(0);
+	bigInt result = bigIntNew(0);
+	bigIntGetSignedArgument(0, base);
+	bigIntGetSignedArgument(1, exponent);
+	bigIntGetSignedArgument(2, modulus);
+	bigIntModPow(result, base, exponent, modulus);
+	bigIntFinishSigned(result);
+}
+
+void mod_inverse_big_int() {
+	bigInt op = bigIntNew(0);
+	bigInt modulus = bigIntNew(0);
+	bigInt result = bigIntNew(0);
+	bigIntGetSignedArgument(0, op);
+	bigIntGetSignedArgument(1, modulus);
+	bigIntModInverse(result, op, modulus);
+	bigIntFinishSigned(result);
+}
+
+void log2_big_int() {
+	bigInt op = bigIntNew(0);
+	bigIntGetSignedArgument(0, op);
+	int64finish(bigIntLog2(op));
+}
@@ -0,0 +1,50 @@
+#include "../kalyan3104/context.h"
+
+byte eventIdentifier[] = "event";
+byte eventIdentifierLength[] = {5, 0, 0, 0};
+byte parentMessage[] = "parent";
+byte childMessage[] = "child";
+byte afterMessage[] = "after";
+byte failMessage[] = "child failed";
+byte childFunction[64] = {0};
+byte executeValue[32] = {0};
+byte scAddress[32] = {0};
+byte topicLengths[64] = {0};
+byte topics[32768] = {0};
+byte data[80000] = {0};
+
+void logMessage(byte *message, int length) {
+	writeEventLog(1, eventIdentifierLength, eventIdentifier, message, length);
+}
+
+// writeEvent(data, topics...) logs data with the given topics
+void writeEvent() {
+	int numArguments = getNumArguments();
+	int dataLength = getArgument(0, data);
+	int offset = 0;
+	for (int i = 1; i < numArguments; i++) {
+		int length = getArgument(i, topics + offset);
+		((int *)topicLengths)[i - 1] = length;
+		offset += length;
+	}
+	writeEventLog(numArguments - 1, topicLengths, topics, data, dataLength);
+}
+
+void childLogs() {
+	logMessage(childMessage, 5);
+}
+
+void childLogsAndFails() {
+	logMessage(childMessage, 5);
+	signalError(failMessage, 12);
+}
+
+// parentCallsChild(function) logs before and after calling the given function
+// of the same contract on a destination context
+void parentCallsChild() {
+	logMessage(parentMessage, 6);
+	int functionLength = getArgument(0, childFunction);
+	getSCAddress(scAddress);
+	executeOnDestContext(500000, scAddress, executeValue, childFunction, functionLength, 0, topicLengths, data);
+	logMessage(afterMessage, 5);
+}
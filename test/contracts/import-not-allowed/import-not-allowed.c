@@ -0,0 +1,8 @@
+#include "../kalyan3104/types.h"
+
+// Not provided by the VM, so the contract must be rejected at deployment
+void notAnAPIFunction();
+
+void init() {
+	notAnAPIFunction();
+}
@@ -0,0 +1,22 @@
+#ifndef _BIGFLOAT_H_
+#define _BIGFLOAT_H_
+
+#include "types.h"
+#include "bigInt.h"
+
+typedef unsigned int bigFloat;
+
+bigFloat  bigFloatNew(long long mantissa, int decimals);
+
+void      bigFloatAdd(bigFloat destination, bigFloat op1, bigFloat op2);
+void      bigFloatSub(bigFloat destination, bigFloat op1, bigFloat op2);
+void      bigFloatMul(bigFloat destination, bigFloat op1, bigFloat op2);
+void      bigFloatDiv(bigFloat destination, bigFloat op1, bigFloat op2, int decimals);
+
+void      bigFloatRound(bigFloat destination, bigFloat op, int decimals);
+void      bigFloatTruncate(bigFloat destination, bigFloat op, int decimals);
+
+void      bigFloatFromBigInt(bigFloat destination, bigInt op, int decimals);
+void      bigFloatToBigInt(bigInt destination, bigFloat op, int decimals);
+
+#endif
@@ -12,12 +12,19 @@ void      bigIntGetSignedArgument(int argumentIndex, bigInt argument);
 
 int       bigIntStorageLoadUnsigned(byte *key, int keyLength, bigInt value);
 int       bigIntStorageStoreUnsigned(byte *key, int keyLength, bigInt value);
+int       bigIntStorageLoadUnsignedFromAddress(byte *address, byte *key, int keyLength, bigInt value);
 
 void      bigIntAdd(bigInt destination, bigInt op1, bigInt op2);
 void      bigIntSub(bigInt destination, bigInt op1, bigInt op2);
 void      bigIntMul(bigInt destination, bigInt op1, bigInt op2);
 int       bigIntCmp(bigInt op1, bigInt op2);
 
+void      bigIntPow(bigInt destination, bigInt op1, bigInt op2);
+void      bigIntSqrt(bigInt destination, bigInt op);
+int       bigIntLog2(bigInt op);
+void      bigIntModPow(bigInt destination, bigInt base, bigInt exponent, bigInt modulus);
+void      bigIntModInverse(bigInt destination, bigInt op, bigInt modulus);
+
 int       bigIntIsInt64(bigInt reference);
 long long bigIntGetInt64(bigInt reference);
 void      bigIntSetInt64(bigInt destination, long long value);
@@ -25,6 +32,7 @@ void      bigIntSetInt64(bigInt destination, long long value);
 void      bigIntFinishUnsigned(bigInt reference);
 void      bigIntFinishSigned(bigInt reference);
 void      bigIntGetCallValue(bigInt destination);
+void      bigIntGetTokenCallValue(int index, bigInt destination);
 void      bigIntgetExternalBalance(byte *address, bigInt result);
 
 int       bigIntByteLength(bigInt reference);
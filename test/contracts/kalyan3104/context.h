@@ -7,20 +7,31 @@ void getSCAddress(byte *address);
 void getOwnerAddress(byte *address);
 int getShardOfAddress(byte *address);
 int isSmartContract(byte *address);
+int isPayable(byte *address);
+int getCodeHash(byte *address, byte *hash);
+int getCodeMetadata(byte *address, byte *codeMetadata);
+long long getAccountNonce(byte *address);
 
 // Call-related functions
 void getCaller(byte *callerAddress);
 int getFunction(byte *function);
 int getCallValue(byte *result);
 long long getGasLeft();
+long long getGasPrice();
+void getCurrentTxHash(byte *hash);
 void finish(byte *data, int length);
 void int64finish(long long value);
 void writeLog(byte *pointer, int length, byte *topicPtr, int numTopics);
+void writeEventLog(int numTopics, byte *topicLengths, byte *topics, byte *data, int dataLength);
 void asyncCall(byte *destination, byte *value, byte *data, int length);
+void createAsyncCall(byte *identifier, int identifierLength, byte *destination, byte *value, byte *data, int length, byte *successCallback, int successLength, byte *errorCallback, int errorLength, long long gas);
+int setAsyncContextCallback(byte *identifier, int identifierLength, byte *callback, int callbackLength);
+int setAsyncContextDeadline(byte *identifier, int identifierLength, long long deadline);
 void signalError(byte *message, int length);
 
 int executeOnSameContext(long long gas, byte *address, byte *value, byte *function, int functionLength, int numArguments, byte *argumentsLengths, byte *arguments);
 int executeOnDestContext(long long gas, byte *address, byte *value, byte *function, int functionLength, int numArguments, byte *argumentsLengths, byte *arguments);
+int upgradeContract(long long gas, byte *address, byte *value, byte *code, byte *codeMetadata, int codeLength, int numArguments, byte *argumentsLengths, byte *arguments);
 
 // Blockchain-related functions
 long long getBlockTimestamp();
@@ -36,12 +47,36 @@ int getArgumentLength(int argumentIndex);
 void getExternalBalance(byte *address, byte *balance);
 int transferValue(byte *destination, byte *value, byte *data, int length);
 
+// Token transfers, optionally calling a function of the destination; each value
+// is 32 bytes long, big-endian. Tokens received by the current call are
+// available through the getToken* functions.
+int transferToken(long long gas, byte *destination, byte *tokenIdentifier, int tokenIdentifierLength, byte *value, byte *function, int functionLength, int numArguments, byte *argumentsLengths, byte *arguments);
+int multiTransfer(long long gas, byte *destination, int numTransfers, byte *tokenIdentifierLengths, byte *tokenIdentifiers, byte *values, byte *function, int functionLength, int numArguments, byte *argumentsLengths, byte *arguments);
+int getNumTokenTransfers();
+int getTokenIdentifier(int index, byte *tokenIdentifier);
+int getTokenCallValue(int index, byte *value);
+
 // Storage-related functions
 int storageLoadLength(byte *key, int keyLength);
 int storageStore(byte *key, int keyLength, byte *data, int dataLength);
 int storageLoad(byte *key, int keyLength, byte *data);
 int int64storageStore(byte *key, int keyLength, long long value);
 long long int64storageLoad(byte *key, int keyLength);
+int storageLoadFromAddress(byte *address, byte *key, int keyLength, byte *data);
+
+// Keys starting with a prefix, in ascending order; each key is written to
+// result as a 4-byte big-endian length followed by the key itself. Returns the
+// number of keys written, at most maxKeys and as many as fit in resultLength.
+int storageCountKeysWithPrefix(byte *prefix, int prefixLength);
+int storageGetKeysWithPrefix(byte *prefix, int prefixLength, int startIndex, int maxKeys, byte *result, int resultLength);
+
+// Crypto-related functions
+int sha256(byte *data, int length, byte *result);
+int keccak256(byte *data, int length, byte *result);
+int ripemd160(byte *data, int length, byte *result);
+int hashData(int algorithmId, byte *data, int length, byte *result);
+int verifyEd25519(byte *key, byte *message, int length, byte *signature);
+int verifyBLS(byte *key, byte *message, int length, byte *signature);
 
 // Timelocks related functions
 int setStorageLock(byte *key, int keyLen, long long timeLock);
@@ -0,0 +1,20 @@
+#ifndef _MANAGED_BUFFER_H_
+#define _MANAGED_BUFFER_H_
+
+#include "types.h"
+#include "bigInt.h"
+
+typedef unsigned int mBuffer;
+
+mBuffer   mBufferNew();
+int       mBufferFromArgument(int argumentIndex, mBuffer destination);
+
+int       mBufferStorageStore(byte *key, int keyLength, mBuffer source);
+int       mBufferStorageLoad(byte *key, int keyLength, mBuffer destination);
+
+int       mBufferAppend(mBuffer accumulator, mBuffer data);
+int       mBufferGetSlice(mBuffer source, int startingPosition, int sliceLength, byte *result);
+int       mBufferFinish(mBuffer source);
+int       mBufferToBigInt(mBuffer source, bigInt destination);
+
+#endif
@@ -0,0 +1,74 @@
+#include "../kalyan3104/context.h"
+#include "../kalyan3104/bigInt.h"
+#include "../kalyan3104/managedBuffer.h"
+
+byte storageKey[] = "mBufferKey";
+byte childFunction[] = "childOverwritesBuffer";
+byte childArgumentsLengths[] = {5, 0, 0, 0};
+byte childArguments[] = "child";
+byte executeValue[32] = {0};
+byte scAddress[32] = {0};
+byte data[256] = {0};
+
+void concatArguments() {
+	mBuffer first = mBufferNew();
+	mBuffer second = mBufferNew();
+	mBufferFromArgument(0, first);
+	mBufferFromArgument(1, second);
+	mBufferAppend(first, second);
+	mBufferFinish(first);
+}
+
+void storeArgument() {
+	mBuffer value = mBufferNew();
+	mBufferFromArgument(0, value);
+	mBufferStorageStore(storageKey, 10, value);
+}
+
+void loadStored() {
+	mBuffer value = mBufferNew();
+	mBufferStorageLoad(storageKey, 10, value);
+	mBufferFinish(value);
+}
+
+void sliceArgument() {
+	mBuffer source = mBufferNew();
+	mBufferFromArgument(0, source);
+	int length = (int)int64getArgument(2);
+	if (mBufferGetSlice(source, (int)int64getArgument(1), length, data) != 0) {
+		return;
+	}
+	finish(data, length);
+}
+
+void argumentToBigInt() {
+	mBuffer source = mBufferNew();
+	bigInt destination = bigIntNew(0);
+	mBufferFromArgument(0, source);
+	mBufferToBigInt(source, destination);
+	bigIntFinishUnsigned(destination);
+}
+
+void finishUnknownHandle() {
+	mBufferFinish(1234);
+}
+
+void childOverwritesBuffer() {
+	int64finish(mBufferNew());
+	mBufferFromArgument(0, 0);
+	mBufferFinish(0);
+}
+
+void parentDestContext() {
+	mBufferFromArgument(0, 0);
+	getSCAddress(scAddress);
+	executeOnDestContext(500000, scAddress, executeValue, childFunction, 21, 1, childArgumentsLengths, childArguments);
+	mBufferFinish(0);
+}
+
+void parentSameContext() {
+	mBufferFromArgument(0, 0);
+	getSCAddress(scAddress);
+	executeOnSameContext(500000, scAddress, executeValue, childFunction, 21, 1, childArgumentsLengths, childArguments);
+	mBufferFinish(0);
+}
@@ -0,0 +1,25 @@
+(module
+  (import "env" "int64getArgument" (func $int64getArgument (param i32) (result i64)))
+  (import "env" "int64finish" (func $int64finish (param i64)))
+  (memory (export "memory") 2)
+  (func (export "init"))
+  (func (export "growMemory")
+    i32.const 0
+    call $int64getArgument
+    i32.wrap_i64
+    memory.grow
+    i32.const -1
+    i32.eq
+    if
+      unreachable
+    end
+    memory.size
+    i64.extend_i32_u
+    call $int64finish)
+  (func (export "growMemoryAndFail")
+    i32.const 0
+    call $int64getArgument
+    i32.wrap_i64
+    memory.grow
+    drop
+    unreachable))
@@ -0,0 +1,4 @@
+(module
+  (memory (export "memory") 33)
+  (func (export "init"))
+  (func (export "doNothing")))
@@ -24,3 +24,54 @@ void* wrongParamsAndReturn(int q, byte *p) {
 	getArgument(q, p);
 	return 0;
 }
+
+byte data[256] = {0};
+byte hash[32] = {0};
+byte expectedHash[32] = {0};
+
+void ripemd160Hash() {
+	int length = getArgument(0, data);
+	ripemd160(data, length, hash);
+	finish(hash, 20);
+}
+
+void hashWithAlgorithm() {
+	int length = getArgument(1, data);
+	int hashLength = hashData((int)int64getArgument(0), data, length, hash);
+	if (hashLength < 0) {
+		return;
+	}
+	finish(hash, hashLength);
+}
+
+// Bitcoin-style address check: the second argument must be the HASH160 of
+// the first, i.e. RIPEMD-160(SHA-256(publicKey))
+void checkHash160() {
+	int length = getArgument(0, data);
+	getArgument(1, expectedHash);
+	hashData(1, data, length, hash);
+	hashData(3, hash, 32, hash);
+
+	byte differences = 0;
+	for (int i = 0; i < 20; i++) {
+		differences |= hash[i] ^ expectedHash[i];
+	}
+	int64finish(differences == 0);
+}
+
+byte key[48] = {0};
+byte signature[96] = {0};
+
+void verifyEd25519Signature() {
+	getArgument(0, key);
+	int length = getArgument(1, data);
+	getArgument(2, signature);
+	int64finish(verifyEd25519(key, data, length, signature) == 0);
+}
+
+void verifyBLSSignature() {
+	getArgument(0, key);
+	int length = getArgument(1, data);
+	getArgument(2, signature);
+	int64finish(verifyBLS(key, data, length, signature) == 0);
+}
@@ -0,0 +1,38 @@
+#include "../kalyan3104/context.h"
+
+byte prefix[32] = {0};
+byte key[32] = {0};
+byte value[] = "v";
+byte result[1024] = {0};
+
+void finishKeys(int numKeys) {
+	int64finish(numKeys);
+	byte *current = result;
+	for (int i = 0; i < numKeys; i++) {
+		int length = (current[0] << 24) | (current[1] << 16) | (current[2] << 8) | current[3];
+		finish(current + 4, length);
+		current += 4 + length;
+	}
+}
+
+void countKeys() {
+	int prefixLength = getArgument(0, prefix);
+	int64finish(storageCountKeysWithPrefix(prefix, prefixLength));
+}
+
+void getKeys() {
+	int prefixLength = getArgument(0, prefix);
+	int startIndex = (int)int64getArgument(1);
+	int maxKeys = (int)int64getArgument(2);
+	int resultLength = (int)int64getArgument(3);
+	finishKeys(storageGetKeysWithPrefix(prefix, prefixLength, startIndex, maxKeys, result, resultLength));
+}
+
+void updateAndGetKeys() {
+	int keyLength = getArgument(0, key);
+	storageStore(key, keyLength, value, 1);
+	keyLength = getArgument(1, key);
+	storageStore(key, keyLength, value, 0);
+	int prefixLength = getArgument(2, prefix);
+	finishKeys(storageGetKeysWithPrefix(prefix, prefixLength, 0, 100, result, 1024));
+}
@@ -0,0 +1,21 @@
+#include "../kalyan3104/context.h"
+#include "../kalyan3104/bigInt.h"
+
+byte address[32] = {0};
+byte key[64] = {0};
+byte data[256] = {0};
+
+void readFromAddress() {
+	getArgument(0, address);
+	int keyLength = getArgument(1, key);
+	int dataLength = storageLoadFromAddress(address, key, keyLength, data);
+	finish(data, dataLength);
+}
+
+void readBigIntFromAddress() {
+	getArgument(0, address);
+	int keyLength = getArgument(1, key);
+	bigInt value = bigIntNew(0);
+	bigIntStorageLoadUnsignedFromAddress(address, key, keyLength, value);
+	bigIntFinishUnsigned(value);
+}
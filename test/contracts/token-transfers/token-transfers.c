@@ -0,0 +1,70 @@
+#include "../kalyan3104/context.h"
+#include "../kalyan3104/bigInt.h"
+
+byte destination[32] = {0};
+byte tokenIdentifiers[128] = {0};
+int tokenIdentifierLengths[2] = {0};
+byte values[64] = {0};
+byte function[64] = {0};
+byte buffer[64] = {0};
+byte transferFailed[] = "transfer failed";
+
+// sendToken(destination, token, value, [function]), the value being 32 bytes long
+void sendToken() {
+	getArgument(0, destination);
+	int tokenLength = getArgument(1, tokenIdentifiers);
+	getArgument(2, values);
+
+	int functionLength = 0;
+	if (getNumArguments() > 3) {
+		functionLength = getArgument(3, function);
+	}
+
+	int result = transferToken(500000, destination, tokenIdentifiers, tokenLength, values, function, functionLength, 0, 0, 0);
+	if (result != 0) {
+		signalError(transferFailed, 15);
+	}
+}
+
+// sendTokens(destination, token1, value1, token2, value2, [function]), the
+// values being 32 bytes long
+void sendTokens() {
+	getArgument(0, destination);
+	tokenIdentifierLengths[0] = getArgument(1, tokenIdentifiers);
+	getArgument(2, values);
+	tokenIdentifierLengths[1] = getArgument(3, tokenIdentifiers + tokenIdentifierLengths[0]);
+	getArgument(4, values + 32);
+
+	int functionLength = 0;
+	if (getNumArguments() > 5) {
+		functionLength = getArgument(5, function);
+	}
+
+	int result = multiTransfer(500000, destination, 2, (byte *)tokenIdentifierLengths, tokenIdentifiers, values, function, functionLength, 0, 0, 0);
+	if (result != 0) {
+		signalError(transferFailed, 15);
+	}
+}
+
+// receiveTokens() finishes the number of received tokens, followed by the
+// identifier and the value of each one, the value in both representations
+void receiveTokens() {
+	int numTransfers = getNumTokenTransfers();
+	int64finish(numTransfers);
+
+	for (int i = 0; i < numTransfers; i++) {
+		int length = getTokenIdentifier(i, buffer);
+		finish(buffer, length);
+
+		getTokenCallValue(i, buffer);
+		finish(buffer, 32);
+
+		bigInt value = bigIntNew(0);
+		bigIntGetTokenCallValue(i, value);
+		bigIntFinishUnsigned(value);
+	}
+}
+
+void tokenIdentifierOutOfRange() {
+	getTokenIdentifier(getNumTokenTransfers(), buffer);
+}
@@ -0,0 +1,32 @@
+#include "../kalyan3104/context.h"
+#include "../kalyan3104/test_utils.h"
+
+byte childAddress[32] = {0};
+byte childCodeMetadata[2] = {0};
+byte upgradeValue[32] = {0};
+u32 initArgumentsLengths[] = {1};
+byte initArguments[] = {0};
+byte childCode[4096] = {0};
+
+void init() {
+}
+
+void upgradeChildContract() {
+	getArgument(0, childAddress);
+	int codeLength = getArgumentLength(1);
+	getArgument(1, childCode);
+	getArgument(2, childCodeMetadata);
+
+	int result = upgradeContract(
+			500000,
+			childAddress,
+			upgradeValue,
+			childCode,
+			childCodeMetadata,
+			codeLength,
+			1,
+			(byte*)initArgumentsLengths,
+			initArguments
+	);
+	finishResult(result);
+}
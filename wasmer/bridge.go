@@ -254,6 +254,40 @@ func cWasmerInstantiateWithOptions(
 	))
 }
 
+func cWasmerCompileWithGasMetering(
+	module **cWasmerModuleT,
+	wasmBytes *cUchar,
+	wasmBytesLength cUint,
+	gasLimit uint64,
+) cWasmerResultT {
+	return (cWasmerResultT)(C.wasmer_compile_with_gas_metering(
+		(**C.wasmer_module_t)(unsafe.Pointer(module)),
+		(*C.uchar)(wasmBytes),
+		(C.uint)(wasmBytesLength),
+		(C.uint64_t)(gasLimit),
+	))
+}
+
+func cWasmerModuleInstantiate(
+	module *cWasmerModuleT,
+	instance **cWasmerInstanceT,
+	imports *cWasmerImportT,
+	importsLength cInt,
+) cWasmerResultT {
+	return (cWasmerResultT)(C.wasmer_module_instantiate(
+		(*C.wasmer_module_t)(module),
+		(**C.wasmer_instance_t)(unsafe.Pointer(instance)),
+		(*C.wasmer_import_t)(imports),
+		(C.int)(importsLength),
+	))
+}
+
+func cWasmerModuleDestroy(module *cWasmerModuleT) {
+	C.wasmer_module_destroy(
+		(*C.wasmer_module_t)(module),
+	)
+}
+
 func cWasmerLastErrorLength() cInt {
 	return (cInt)(C.wasmer_last_error_length())
 }
@@ -7,6 +7,10 @@ import (
 
 var ErrFailedInstantiation = errors.New("could not create wasmer instance")
 
+var ErrFailedCompilation = errors.New("could not compile wasmer module")
+
+var ErrInvalidModule = errors.New("invalid wasmer module")
+
 var ErrFailedCacheImports = errors.New("could not cache imports")
 
 var ErrInvalidBytecode = errors.New("invalid bytecode")
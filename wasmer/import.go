@@ -97,6 +97,16 @@ func (imports *Imports) Names() vmcommon.FunctionNames {
 	return names
 }
 
+// NamespaceNames returns the names of the functions imported in the given namespace
+func (imports *Imports) NamespaceNames(namespace string) vmcommon.FunctionNames {
+	names := make(vmcommon.FunctionNames)
+	var empty struct{}
+	for name := range imports.imports[namespace] {
+		names[name] = empty
+	}
+	return names
+}
+
 // Append adds a new imported function to the current set.
 func (imports *Imports) Append(importName string, implementation interface{}, cgoPointer unsafe.Pointer) (*Imports, error) {
 	var importType = reflect.TypeOf(implementation)
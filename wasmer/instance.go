@@ -84,6 +84,11 @@ type Instance struct {
 	Memory *Memory
 
 	Data unsafe.Pointer
+
+	// The difference between the points used as counted by the metering of
+	// the underlying instance and the points used as seen by the host; it is
+	// nonzero only for instances of a Module compiled with a larger gas limit.
+	pointsOffset uint64
 }
 
 type CompilationOptions struct {
@@ -105,6 +110,24 @@ func newWrappedError(target error) error {
 	return fmt.Errorf("%w: %s", target, lastError)
 }
 
+// ImportObject holds the imports with which compiled modules are
+// instantiated. Each host generates its own, such that hosts in the same
+// process do not share them.
+type ImportObject struct {
+	imports      *cWasmerImportT
+	importsCount int
+}
+
+// NewImportObject generates the imports with which compiled modules are
+// instantiated by NewInstanceFromModule().
+func NewImportObject(imports *Imports) *ImportObject {
+	wasmImportsCPointer, numberOfImports := generateWasmerImports(imports)
+	return &ImportObject{
+		imports:      wasmImportsCPointer,
+		importsCount: numberOfImports,
+	}
+}
+
 func SetImports(imports *Imports) error {
 	wasmImportsCPointer, numberOfImports := generateWasmerImports(imports)
 
@@ -204,11 +227,11 @@ func (instance *Instance) Clean() {
 }
 
 func (instance *Instance) GetPointsUsed() uint64 {
-	return cWasmerInstanceGetPointsUsed(instance.instance)
+	return cWasmerInstanceGetPointsUsed(instance.instance) - instance.pointsOffset
 }
 
 func (instance *Instance) SetPointsUsed(points uint64) {
-	cWasmerInstanceSetPointsUsed(instance.instance, points)
+	cWasmerInstanceSetPointsUsed(instance.instance, points+instance.pointsOffset)
 }
 
 func (instance *Instance) SetBreakpointValue(value uint64) {
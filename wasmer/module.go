@@ -0,0 +1,86 @@
+package wasmer
+
+import (
+	"unsafe"
+)
+
+// Module represents a compiled WebAssembly module, from which any number of
+// instances can be created without compiling the bytecode again.
+type Module struct {
+	// The underlying compiled WebAssembly module.
+	module *cWasmerModuleT
+
+	// The gas limit the metering of the module was compiled with. The
+	// instances of the module translate their points used, such that they run
+	// out of gas at their own gas limit instead.
+	gasLimit uint64
+}
+
+// CompileModule compiles the bytecode into a module with gas metering and
+// runtime breakpoints, able to run instances with a gas limit of at most
+// `gasLimit`.
+func CompileModule(bytes []byte, gasLimit uint64) (*Module, error) {
+	var c_module *cWasmerModuleT
+
+	if len(bytes) == 0 {
+		return nil, newWrappedError(ErrInvalidBytecode)
+	}
+
+	var compileResult = cWasmerCompileWithGasMetering(
+		&c_module,
+		(*cUchar)(unsafe.Pointer(&bytes[0])),
+		cUint(len(bytes)),
+		gasLimit,
+	)
+
+	if compileResult != cWasmerOk {
+		return nil, newWrappedError(ErrFailedCompilation)
+	}
+
+	return &Module{module: c_module, gasLimit: gasLimit}, nil
+}
+
+// NewInstanceFromModule instantiates the compiled module with the given
+// imports and gas limit.
+func NewInstanceFromModule(module *Module, importObject *ImportObject, gasLimit uint64) (*Instance, error) {
+	var c_instance *cWasmerInstanceT
+
+	if module == nil || module.module == nil || importObject == nil || gasLimit > module.gasLimit {
+		var emptyInstance = &Instance{instance: nil, Exports: nil, Memory: nil}
+		return emptyInstance, ErrInvalidModule
+	}
+
+	var instantiateResult = cWasmerModuleInstantiate(
+		module.module,
+		&c_instance,
+		importObject.imports,
+		cInt(importObject.importsCount),
+	)
+
+	if instantiateResult != cWasmerOk {
+		var emptyInstance = &Instance{instance: nil, Exports: nil, Memory: nil}
+		return emptyInstance, newWrappedError(ErrFailedInstantiation)
+	}
+
+	instance, err := newInstance(c_instance)
+	if err != nil {
+		return instance, err
+	}
+
+	instance.pointsOffset = module.gasLimit - gasLimit
+	instance.SetPointsUsed(0)
+	return instance, nil
+}
+
+// GasLimit returns the highest gas limit the instances of the module may have.
+func (module *Module) GasLimit() uint64 {
+	return module.gasLimit
+}
+
+// Destroy frees the compiled module; the instances created from it remain valid.
+func (module *Module) Destroy() {
+	if module.module != nil {
+		cWasmerModuleDestroy(module.module)
+		module.module = nil
+	}
+}